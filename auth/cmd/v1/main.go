@@ -60,6 +60,9 @@ func main() {
 			httpServer = infrastructure.ServeHTTP(app)
 		)
 
+		// signing keys of current & next period scheduled before serving any login
+		infrastructure.RotateSigningKeys(app)
+
 		server := &http.Server{
 			Addr:    fmt.Sprintf(":%d", app.Config.Server.AppPort),
 			Handler: httpServer,
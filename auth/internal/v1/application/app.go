@@ -11,6 +11,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/redis/go-redis/v9"
 	"github.com/sirupsen/logrus"
+	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 	"go.opentelemetry.io/otel"
@@ -73,6 +74,16 @@ func SetupApplication(ctx context.Context) (*App, error) {
 	db := mongoClient.Database(app.Config.Database.Name)
 	app.DB = db
 
+	// one signing key scheduled per rotation period, expired keys removed by mongo
+	_, err = db.Collection(app.Config.Database.SigningKeysCollection).Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "activate_at", Value: 1}}, Options: options.Index().SetUnique(true)},
+		{Keys: bson.D{{Key: "expire_at", Value: 1}}, Options: options.Index().SetExpireAfterSeconds(0)},
+	})
+	if err != nil {
+		app.Logger.Error("failed create signing keys indexes, error :", err)
+		return app, err
+	}
+
 	redisClient := redis.NewClient(&redis.Options{
 		Addr:     fmt.Sprintf("%s:%d", app.Config.Redis.Host, app.Config.Redis.Port),
 		Password: "", // no password set
@@ -81,7 +92,16 @@ func SetupApplication(ctx context.Context) (*App, error) {
 	app.Redis = redisClient
 
 	app.Application = gin.New()
+
+	// client IP used by login throttling, forwarded headers only honoured from configured proxies
+	err = app.Application.SetTrustedProxies(app.Config.Server.TrustedProxies)
+	if err != nil {
+		app.Logger.Error("failed set trusted proxies, error :", err)
+		return app, err
+	}
+
 	app.Application.Use(middleware.CORSMiddleware())
+	app.Application.Use(middleware.MetricsMiddleware())
 
 	app.Logger.Info("APP RUN SUCCESSFULLY")
 
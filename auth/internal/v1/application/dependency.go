@@ -9,23 +9,28 @@ import (
 type Dependency struct {
 	HealthCheckController controller.HealthCheckController
 	AuthController        controller.AuthController
+	KeyController         controller.KeyController
 }
 
 func SetupDependencyInjection(app *App) *Dependency {
 	// repository
 	healthCheckRepoImpl := repository.NewHealthCheckRepository(app.Context, app.Config, app.Logger, app.Tracer, app.DB, app.Redis)
 	authRepoImpl := repository.NewAuthRepository(app.Context, app.Config, app.Logger, app.Tracer, app.DB, app.Redis)
+	keyRepoImpl := repository.NewKeyRepository(app.Context, app.Config, app.Logger, app.Tracer, app.DB)
 
 	// service
 	healthCheckSvcImpl := service.NewHealthCheckService(app.Context, app.Config, app.Tracer, healthCheckRepoImpl)
-	authSvcImpl := service.NewAuthService(app.Context, app.Config, app.Logger, app.Tracer, app.Mailer, authRepoImpl)
+	keySvcImpl := service.NewKeyService(app.Context, app.Config, app.Logger, app.Tracer, keyRepoImpl)
+	authSvcImpl := service.NewAuthService(app.Context, app.Config, app.Logger, app.Tracer, app.Mailer, authRepoImpl, keySvcImpl)
 
 	// controller
 	healthCheckControllerImpl := controller.NewHealthCheckController(app.Context, app.Config, app.Tracer, healthCheckSvcImpl)
 	authControllerImpl := controller.NewAuthController(app.Context, app.Config, app.Logger, app.Tracer, authSvcImpl)
+	keyControllerImpl := controller.NewKeyController(app.Context, app.Config, app.Tracer, keySvcImpl)
 
 	return &Dependency{
 		HealthCheckController: healthCheckControllerImpl,
 		AuthController:        authControllerImpl,
+		KeyController:         keyControllerImpl,
 	}
 }
@@ -4,32 +4,37 @@ import "github.com/PickHD/singkatin-revamp/auth/internal/v1/helper"
 
 type (
 	Configuration struct {
-		Server   *Server
-		Common   *Common
-		Database *Database
-		Redis    *Redis
-		Secret   *Secret
-		Tracer   *Tracer
-		Mailer   *Mailer
+		Server     *Server
+		Common     *Common
+		Database   *Database
+		Redis      *Redis
+		Signing    *Signing
+		TOTP       *TOTP
+		BruteForce *BruteForce
+		Tracer     *Tracer
+		Mailer     *Mailer
 	}
 
 	Common struct {
-		JWTExpire int
+		AccessTokenExpire  int
+		RefreshTokenExpire int
 	}
 
 	Server struct {
-		AppPort int
-		AppEnv  string
-		AppName string
-		AppID   string
+		AppPort        int
+		AppEnv         string
+		AppName        string
+		AppID          string
+		TrustedProxies []string
 	}
 
 	Database struct {
-		Port                 int
-		Host                 string
-		Name                 string
-		UsersCollection      string
-		ShortenersCollection string
+		Port                  int
+		Host                  string
+		Name                  string
+		UsersCollection       string
+		ShortenersCollection  string
+		SigningKeysCollection string
 	}
 
 	Redis struct {
@@ -38,8 +43,26 @@ type (
 		TTL  int
 	}
 
-	Secret struct {
-		JWTSecret string
+	Signing struct {
+		Algorithm           string
+		KeyRotationInterval int
+		KeyEncryptionKey    string
+	}
+
+	TOTP struct {
+		Issuer          string
+		EnrolmentExpire int
+		ChallengeExpire int
+	}
+
+	BruteForce struct {
+		Window                    int
+		DelayAfter                int
+		BaseDelay                 int
+		MaxAttempts               int
+		MaxAttemptsIP             int
+		Lockout                   int
+		ForgotPasswordMaxAttempts int
 	}
 
 	Tracer struct {
@@ -60,28 +83,47 @@ type (
 func loadConfiguration() *Configuration {
 	return &Configuration{
 		Common: &Common{
-			JWTExpire: helper.GetEnvInt("JWT_EXPIRE"),
+			AccessTokenExpire:  helper.GetEnvInt("ACCESS_TOKEN_EXPIRE"),
+			RefreshTokenExpire: helper.GetEnvInt("REFRESH_TOKEN_EXPIRE"),
 		},
 		Server: &Server{
-			AppPort: helper.GetEnvInt("APP_PORT"),
-			AppEnv:  helper.GetEnvString("APP_ENV"),
-			AppName: helper.GetEnvString("APP_NAME"),
-			AppID:   helper.GetEnvString("APP_ID"),
+			AppPort:        helper.GetEnvInt("APP_PORT"),
+			AppEnv:         helper.GetEnvString("APP_ENV"),
+			AppName:        helper.GetEnvString("APP_NAME"),
+			AppID:          helper.GetEnvString("APP_ID"),
+			TrustedProxies: helper.GetEnvStringSlice("TRUSTED_PROXIES"),
 		},
 		Database: &Database{
-			Port:                 helper.GetEnvInt("DB_PORT"),
-			Host:                 helper.GetEnvString("DB_HOST"),
-			Name:                 helper.GetEnvString("DB_NAME"),
-			UsersCollection:      helper.GetEnvString("DB_COLLECTION_USERS"),
-			ShortenersCollection: helper.GetEnvString("DB_COLLECTION_SHORTENERS"),
+			Port:                  helper.GetEnvInt("DB_PORT"),
+			Host:                  helper.GetEnvString("DB_HOST"),
+			Name:                  helper.GetEnvString("DB_NAME"),
+			UsersCollection:       helper.GetEnvString("DB_COLLECTION_USERS"),
+			ShortenersCollection:  helper.GetEnvString("DB_COLLECTION_SHORTENERS"),
+			SigningKeysCollection: helper.GetEnvString("DB_COLLECTION_SIGNING_KEYS"),
 		},
 		Redis: &Redis{
 			Host: helper.GetEnvString("REDIS_HOST"),
 			Port: helper.GetEnvInt("REDIS_PORT"),
 			TTL:  helper.GetEnvInt("REDIS_TTL"),
 		},
-		Secret: &Secret{
-			JWTSecret: helper.GetEnvString("JWT_SECRET"),
+		Signing: &Signing{
+			Algorithm:           helper.GetEnvString("JWT_SIGNING_ALGORITHM"),
+			KeyRotationInterval: helper.GetEnvInt("JWT_KEY_ROTATION_INTERVAL"),
+			KeyEncryptionKey:    helper.GetEnvString("JWT_KEY_ENCRYPTION_KEY"),
+		},
+		TOTP: &TOTP{
+			Issuer:          helper.GetEnvString("TOTP_ISSUER"),
+			EnrolmentExpire: helper.GetEnvInt("TOTP_ENROLMENT_EXPIRE"),
+			ChallengeExpire: helper.GetEnvInt("MFA_CHALLENGE_EXPIRE"),
+		},
+		BruteForce: &BruteForce{
+			Window:                    helper.GetEnvInt("LOGIN_ATTEMPT_WINDOW"),
+			DelayAfter:                helper.GetEnvInt("LOGIN_DELAY_AFTER"),
+			BaseDelay:                 helper.GetEnvInt("LOGIN_DELAY_BASE"),
+			MaxAttempts:               helper.GetEnvInt("LOGIN_MAX_ATTEMPTS"),
+			MaxAttemptsIP:             helper.GetEnvInt("LOGIN_MAX_ATTEMPTS_IP"),
+			Lockout:                   helper.GetEnvInt("LOGIN_LOCKOUT"),
+			ForgotPasswordMaxAttempts: helper.GetEnvInt("FORGOT_PASSWORD_MAX_ATTEMPTS"),
 		},
 		Tracer: &Tracer{
 			JaegerURL: helper.GetEnvString("JAEGER_URL"),
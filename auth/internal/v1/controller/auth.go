@@ -3,10 +3,10 @@ package controller
 import (
 	"context"
 	"net/http"
-	"strings"
 
 	"github.com/PickHD/singkatin-revamp/auth/internal/v1/config"
 	"github.com/PickHD/singkatin-revamp/auth/internal/v1/helper"
+	"github.com/PickHD/singkatin-revamp/auth/internal/v1/middleware"
 	"github.com/PickHD/singkatin-revamp/auth/internal/v1/model"
 	"github.com/PickHD/singkatin-revamp/auth/internal/v1/service"
 	"github.com/gin-gonic/gin"
@@ -23,6 +23,14 @@ type (
 		ForgotPassword(ctx *gin.Context)
 		VerifyForgotPassword(ctx *gin.Context)
 		ResetPassword(ctx *gin.Context)
+		RefreshToken(ctx *gin.Context)
+		Logout(ctx *gin.Context)
+		LogoutAll(ctx *gin.Context)
+		LoginMFA(ctx *gin.Context)
+		EnrollTOTP(ctx *gin.Context)
+		ConfirmTOTP(ctx *gin.Context)
+		ResetTOTP(ctx *gin.Context)
+		DisableTOTP(ctx *gin.Context)
 	}
 
 	// AuthcontrollerImpl is an app auth struct that consists of all the dependencies needed for auth controller
@@ -70,12 +78,7 @@ func (ac *AuthControllerImpl) Register(ctx *gin.Context) {
 
 	data, err := ac.AuthSvc.RegisterUser(ctx, &req)
 	if err != nil {
-		if strings.Contains(err.Error(), string(model.Validation)) {
-			helper.NewResponses[any](ctx, http.StatusBadRequest, err.Error(), req.Email, err, nil)
-			return
-		}
-
-		helper.NewResponses[any](ctx, http.StatusInternalServerError, "Failed register user", data, err, nil)
+		helper.NewErrorResponses[any](ctx, err, "Failed register user", req.Email)
 		return
 	}
 
@@ -84,6 +87,7 @@ func (ac *AuthControllerImpl) Register(ctx *gin.Context) {
 
 // Check godoc
 // @Summary      Login users
+// @Description  Users with 2FA enabled responded mfa_token instead of access token, complete the login on /login/mfa
 // @Tags         Auth
 // @Accept       json
 // @Produce      json
@@ -91,6 +95,7 @@ func (ac *AuthControllerImpl) Register(ctx *gin.Context) {
 // @Success      200  {object}  helper.BaseResponse
 // @Failure      400  {object}  helper.BaseResponse
 // @Failure      404  {object}  helper.BaseResponse
+// @Failure      429  {object}  helper.BaseResponse
 // @Failure      500  {object}  helper.BaseResponse
 // @Router       /login [post]
 func (ac *AuthControllerImpl) Login(ctx *gin.Context) {
@@ -105,19 +110,11 @@ func (ac *AuthControllerImpl) Login(ctx *gin.Context) {
 		return
 	}
 
+	req.ClientIP = ctx.ClientIP()
+
 	data, err := ac.AuthSvc.LoginUser(ctx, &req)
 	if err != nil {
-		if strings.Contains(err.Error(), string(model.Validation)) {
-			helper.NewResponses[any](ctx, http.StatusBadRequest, err.Error(), req, err, nil)
-			return
-		}
-
-		if strings.Contains(err.Error(), string(model.NotFound)) {
-			helper.NewResponses[any](ctx, http.StatusNotFound, err.Error(), req, err, nil)
-			return
-		}
-
-		helper.NewResponses[any](ctx, http.StatusInternalServerError, "Failed login user", data, err, nil)
+		helper.NewErrorResponses[any](ctx, err, "Failed login user", req.Email)
 		return
 	}
 
@@ -165,6 +162,7 @@ func (ac *AuthControllerImpl) VerifyRegister(ctx *gin.Context) {
 // @Success      200  {object}  helper.BaseResponse
 // @Failure      400  {object}  helper.BaseResponse
 // @Failure      404  {object}  helper.BaseResponse
+// @Failure      429  {object}  helper.BaseResponse
 // @Failure      500  {object}  helper.BaseResponse
 // @Router       /forgot-password [post]
 func (ac *AuthControllerImpl) ForgotPassword(ctx *gin.Context) {
@@ -179,19 +177,11 @@ func (ac *AuthControllerImpl) ForgotPassword(ctx *gin.Context) {
 		return
 	}
 
+	req.ClientIP = ctx.ClientIP()
+
 	err := ac.AuthSvc.ForgotPasswordUser(ctx, &req)
 	if err != nil {
-		if strings.Contains(err.Error(), string(model.Validation)) {
-			helper.NewResponses[any](ctx, http.StatusBadRequest, err.Error(), req.Email, err, nil)
-			return
-		}
-
-		if strings.Contains(err.Error(), string(model.NotFound)) {
-			helper.NewResponses[any](ctx, http.StatusNotFound, err.Error(), req.Email, err, nil)
-			return
-		}
-
-		helper.NewResponses[any](ctx, http.StatusInternalServerError, "Failed request forgot password", nil, err, nil)
+		helper.NewErrorResponses[any](ctx, err, "Failed request forgot password", req.Email)
 		return
 	}
 
@@ -262,19 +252,292 @@ func (ac *AuthControllerImpl) ResetPassword(ctx *gin.Context) {
 
 	err := ac.AuthSvc.ResetPasswordUser(ctx, &req, getCode)
 	if err != nil {
-		if strings.Contains(err.Error(), string(model.Validation)) {
-			helper.NewResponses[any](ctx, http.StatusBadRequest, err.Error(), nil, err, nil)
-			return
-		}
+		helper.NewErrorResponses[any](ctx, err, "Failed reset password", nil)
+		return
+	}
+
+	helper.NewResponses[any](ctx, http.StatusOK, "Success reset password", nil, nil, nil)
+}
 
-		if strings.Contains(err.Error(), string(model.NotFound)) {
-			helper.NewResponses[any](ctx, http.StatusNotFound, err.Error(), nil, err, nil)
-			return
-		}
+// Check godoc
+// @Summary      Refresh Token Users
+// @Tags         Auth
+// @Accept       json
+// @Produce      json
+// @Param        refreshToken body model.RefreshTokenRequest true "refresh token user"
+// @Success      200  {object}  helper.BaseResponse
+// @Failure      400  {object}  helper.BaseResponse
+// @Failure      401  {object}  helper.BaseResponse
+// @Failure      500  {object}  helper.BaseResponse
+// @Router       /token/refresh [post]
+func (ac *AuthControllerImpl) RefreshToken(ctx *gin.Context) {
+	var req model.RefreshTokenRequest
 
-		helper.NewResponses[any](ctx, http.StatusInternalServerError, "Failed reset password", nil, err, nil)
+	tr := ac.Tracer.Tracer("Auth-RefreshToken Controller")
+	_, span := tr.Start(ctx, "Start RefreshToken")
+	defer span.End()
+
+	if err := ctx.BindJSON(&req); err != nil {
+		helper.NewResponses[any](ctx, http.StatusBadRequest, "Invalid request", nil, err, nil)
 		return
 	}
 
-	helper.NewResponses[any](ctx, http.StatusOK, "Success reset password", nil, nil, nil)
+	data, err := ac.AuthSvc.RefreshToken(ctx, &req)
+	if err != nil {
+		helper.NewErrorResponses[any](ctx, err, "Failed refresh token", nil)
+		return
+	}
+
+	helper.NewResponses[any](ctx, http.StatusOK, "Success refresh token", data, nil, nil)
+}
+
+// Check godoc
+// @Summary      Logout Users
+// @Tags         Auth
+// @Produce      json
+// @Param        Authorization header string true "Authorization Bearer <Place Access Token Here>"
+// @Success      200  {object}  helper.BaseResponse
+// @Failure      401  {object}  helper.BaseResponse
+// @Failure      500  {object}  helper.BaseResponse
+// @Router       /logout [post]
+func (ac *AuthControllerImpl) Logout(ctx *gin.Context) {
+	tr := ac.Tracer.Tracer("Auth-Logout Controller")
+	_, span := tr.Start(ctx, "Start Logout")
+	defer span.End()
+
+	claims, err := middleware.Extract(ctx)
+	if err != nil {
+		helper.NewErrorResponses[any](ctx, err, "Failed logout", nil)
+		return
+	}
+
+	err = ac.AuthSvc.Logout(ctx, claims)
+	if err != nil {
+		helper.NewErrorResponses[any](ctx, err, "Failed logout", nil)
+		return
+	}
+
+	helper.NewResponses[any](ctx, http.StatusOK, "Success logout", nil, nil, nil)
+}
+
+// Check godoc
+// @Summary      Logout All Sessions Users
+// @Tags         Auth
+// @Produce      json
+// @Param        Authorization header string true "Authorization Bearer <Place Access Token Here>"
+// @Success      200  {object}  helper.BaseResponse
+// @Failure      401  {object}  helper.BaseResponse
+// @Failure      500  {object}  helper.BaseResponse
+// @Router       /logout/all [post]
+func (ac *AuthControllerImpl) LogoutAll(ctx *gin.Context) {
+	tr := ac.Tracer.Tracer("Auth-LogoutAll Controller")
+	_, span := tr.Start(ctx, "Start LogoutAll")
+	defer span.End()
+
+	claims, err := middleware.Extract(ctx)
+	if err != nil {
+		helper.NewErrorResponses[any](ctx, err, "Failed logout all sessions", nil)
+		return
+	}
+
+	err = ac.AuthSvc.LogoutAll(ctx, claims)
+	if err != nil {
+		helper.NewErrorResponses[any](ctx, err, "Failed logout all sessions", nil)
+		return
+	}
+
+	helper.NewResponses[any](ctx, http.StatusOK, "Success logout all sessions", nil, nil, nil)
+}
+
+// Check godoc
+// @Summary      Login MFA Users
+// @Description  Complete login of users with 2FA enabled using TOTP or one time backup code
+// @Tags         Auth
+// @Accept       json
+// @Produce      json
+// @Param        mfa body model.MFALoginRequest true "login mfa user"
+// @Success      200  {object}  helper.BaseResponse
+// @Failure      400  {object}  helper.BaseResponse
+// @Failure      401  {object}  helper.BaseResponse
+// @Failure      429  {object}  helper.BaseResponse
+// @Failure      500  {object}  helper.BaseResponse
+// @Router       /login/mfa [post]
+func (ac *AuthControllerImpl) LoginMFA(ctx *gin.Context) {
+	var req model.MFALoginRequest
+
+	tr := ac.Tracer.Tracer("Auth-LoginMFA Controller")
+	_, span := tr.Start(ctx, "Start LoginMFA")
+	defer span.End()
+
+	if err := ctx.BindJSON(&req); err != nil {
+		helper.NewResponses[any](ctx, http.StatusBadRequest, "Invalid request", nil, err, nil)
+		return
+	}
+
+	req.ClientIP = ctx.ClientIP()
+
+	data, err := ac.AuthSvc.LoginMFA(ctx, &req)
+	if err != nil {
+		helper.NewErrorResponses[any](ctx, err, "Failed login user", nil)
+		return
+	}
+
+	helper.NewResponses[any](ctx, http.StatusOK, "Success login user", data, nil, nil)
+}
+
+// Check godoc
+// @Summary      Enroll 2FA Users
+// @Description  Generate TOTP secret as otpauth uri & QR code, confirm it with first code generated by authenticator app
+// @Tags         2FA
+// @Produce      json
+// @Param        Authorization header string true "Authorization Bearer <Place Access Token Here>"
+// @Success      200  {object}  helper.BaseResponse
+// @Failure      400  {object}  helper.BaseResponse
+// @Failure      401  {object}  helper.BaseResponse
+// @Failure      500  {object}  helper.BaseResponse
+// @Router       /2fa/enroll [post]
+func (ac *AuthControllerImpl) EnrollTOTP(ctx *gin.Context) {
+	tr := ac.Tracer.Tracer("Auth-EnrollTOTP Controller")
+	_, span := tr.Start(ctx, "Start EnrollTOTP")
+	defer span.End()
+
+	claims, err := middleware.Extract(ctx)
+	if err != nil {
+		helper.NewErrorResponses[any](ctx, err, "Failed enroll 2FA", nil)
+		return
+	}
+
+	data, err := ac.AuthSvc.EnrollTOTP(ctx, claims)
+	if err != nil {
+		helper.NewErrorResponses[any](ctx, err, "Failed enroll 2FA", nil)
+		return
+	}
+
+	helper.NewResponses[any](ctx, http.StatusOK, "Success enroll 2FA, please confirm with code from your authenticator app", data, nil, nil)
+}
+
+// Check godoc
+// @Summary      Confirm 2FA Users
+// @Description  Enable 2FA by confirming enrolment, one time backup codes only responded once
+// @Tags         2FA
+// @Accept       json
+// @Produce      json
+// @Param        Authorization header string true "Authorization Bearer <Place Access Token Here>"
+// @Param        confirm body model.TOTPConfirmRequest true "confirm 2FA user"
+// @Success      200  {object}  helper.BaseResponse
+// @Failure      400  {object}  helper.BaseResponse
+// @Failure      401  {object}  helper.BaseResponse
+// @Failure      404  {object}  helper.BaseResponse
+// @Failure      500  {object}  helper.BaseResponse
+// @Router       /2fa/confirm [post]
+func (ac *AuthControllerImpl) ConfirmTOTP(ctx *gin.Context) {
+	var req model.TOTPConfirmRequest
+
+	tr := ac.Tracer.Tracer("Auth-ConfirmTOTP Controller")
+	_, span := tr.Start(ctx, "Start ConfirmTOTP")
+	defer span.End()
+
+	claims, err := middleware.Extract(ctx)
+	if err != nil {
+		helper.NewErrorResponses[any](ctx, err, "Failed confirm 2FA", nil)
+		return
+	}
+
+	if err := ctx.BindJSON(&req); err != nil {
+		helper.NewResponses[any](ctx, http.StatusBadRequest, "Invalid request", nil, err, nil)
+		return
+	}
+
+	data, err := ac.AuthSvc.ConfirmTOTP(ctx, claims, &req)
+	if err != nil {
+		helper.NewErrorResponses[any](ctx, err, "Failed confirm 2FA", nil)
+		return
+	}
+
+	helper.NewResponses[any](ctx, http.StatusOK, "Success enable 2FA, please store backup codes safely", data, nil, nil)
+}
+
+// Check godoc
+// @Summary      Reset 2FA Users
+// @Description  Re-enroll 2FA with new authenticator after password re-verification, current one valid until confirmed
+// @Tags         2FA
+// @Accept       json
+// @Produce      json
+// @Param        Authorization header string true "Authorization Bearer <Place Access Token Here>"
+// @Param        password body model.PasswordConfirmRequest true "password user"
+// @Success      200  {object}  helper.BaseResponse
+// @Failure      400  {object}  helper.BaseResponse
+// @Failure      401  {object}  helper.BaseResponse
+// @Failure      429  {object}  helper.BaseResponse
+// @Failure      500  {object}  helper.BaseResponse
+// @Router       /2fa/reset [post]
+func (ac *AuthControllerImpl) ResetTOTP(ctx *gin.Context) {
+	var req model.PasswordConfirmRequest
+
+	tr := ac.Tracer.Tracer("Auth-ResetTOTP Controller")
+	_, span := tr.Start(ctx, "Start ResetTOTP")
+	defer span.End()
+
+	claims, err := middleware.Extract(ctx)
+	if err != nil {
+		helper.NewErrorResponses[any](ctx, err, "Failed reset 2FA", nil)
+		return
+	}
+
+	if err := ctx.BindJSON(&req); err != nil {
+		helper.NewResponses[any](ctx, http.StatusBadRequest, "Invalid request", nil, err, nil)
+		return
+	}
+
+	req.ClientIP = ctx.ClientIP()
+
+	data, err := ac.AuthSvc.ResetTOTP(ctx, claims, &req)
+	if err != nil {
+		helper.NewErrorResponses[any](ctx, err, "Failed reset 2FA", nil)
+		return
+	}
+
+	helper.NewResponses[any](ctx, http.StatusOK, "Success reset 2FA, please confirm with code from your new authenticator app", data, nil, nil)
+}
+
+// Check godoc
+// @Summary      Disable 2FA Users
+// @Tags         2FA
+// @Accept       json
+// @Produce      json
+// @Param        Authorization header string true "Authorization Bearer <Place Access Token Here>"
+// @Param        password body model.PasswordConfirmRequest true "password user"
+// @Success      200  {object}  helper.BaseResponse
+// @Failure      400  {object}  helper.BaseResponse
+// @Failure      401  {object}  helper.BaseResponse
+// @Failure      429  {object}  helper.BaseResponse
+// @Failure      500  {object}  helper.BaseResponse
+// @Router       /2fa/disable [post]
+func (ac *AuthControllerImpl) DisableTOTP(ctx *gin.Context) {
+	var req model.PasswordConfirmRequest
+
+	tr := ac.Tracer.Tracer("Auth-DisableTOTP Controller")
+	_, span := tr.Start(ctx, "Start DisableTOTP")
+	defer span.End()
+
+	claims, err := middleware.Extract(ctx)
+	if err != nil {
+		helper.NewErrorResponses[any](ctx, err, "Failed disable 2FA", nil)
+		return
+	}
+
+	if err := ctx.BindJSON(&req); err != nil {
+		helper.NewResponses[any](ctx, http.StatusBadRequest, "Invalid request", nil, err, nil)
+		return
+	}
+
+	req.ClientIP = ctx.ClientIP()
+
+	err = ac.AuthSvc.DisableTOTP(ctx, claims, &req)
+	if err != nil {
+		helper.NewErrorResponses[any](ctx, err, "Failed disable 2FA", nil)
+		return
+	}
+
+	helper.NewResponses[any](ctx, http.StatusOK, "Success disable 2FA", nil, nil, nil)
 }
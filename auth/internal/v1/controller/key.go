@@ -0,0 +1,72 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/PickHD/singkatin-revamp/auth/internal/v1/config"
+	"github.com/PickHD/singkatin-revamp/auth/internal/v1/helper"
+	"github.com/PickHD/singkatin-revamp/auth/internal/v1/service"
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt"
+	"go.opentelemetry.io/otel/sdk/trace"
+)
+
+// jwksMaxAge (seconds) is how long verifiers may cache published keys, kept far below key rotation interval
+const jwksMaxAge = 300
+
+type (
+	// KeyController is an interface that has all the function to be implemented inside key controller
+	KeyController interface {
+		JWKS(ctx *gin.Context)
+		RotateKeys() error
+		VerificationKey(token *jwt.Token) (interface{}, error)
+	}
+
+	// KeyControllerImpl is an app key struct that consists of all the dependencies needed for key controller
+	KeyControllerImpl struct {
+		Context context.Context
+		Config  *config.Configuration
+		Tracer  *trace.TracerProvider
+		KeySvc  service.KeyService
+	}
+)
+
+// NewKeyController return new instances key controller
+func NewKeyController(ctx context.Context, config *config.Configuration, tracer *trace.TracerProvider, keySvc service.KeyService) *KeyControllerImpl {
+	return &KeyControllerImpl{
+		Context: ctx,
+		Config:  config,
+		Tracer:  tracer,
+		KeySvc:  keySvc,
+	}
+}
+
+// JWKS respond public keys verifying access tokens as plain JSON Web Key Set, as expected by JWT libraries
+func (kc *KeyControllerImpl) JWKS(ctx *gin.Context) {
+	tr := kc.Tracer.Tracer("Auth-JWKS Controller")
+	_, span := tr.Start(ctx, "Start JWKS")
+	defer span.End()
+
+	data, err := kc.KeySvc.JWKS(ctx)
+	if err != nil {
+		helper.NewErrorResponses[any](ctx, err, "Failed get signing keys", nil)
+		return
+	}
+
+	ctx.Header("Cache-Control", fmt.Sprintf("public, max-age=%d", jwksMaxAge))
+	ctx.JSON(http.StatusOK, data)
+}
+
+func (kc *KeyControllerImpl) RotateKeys() error {
+	tr := kc.Tracer.Tracer("Auth-RotateKeys Controller")
+	ctx, span := tr.Start(kc.Context, "Start RotateKeys")
+	defer span.End()
+
+	return kc.KeySvc.RotateKeys(ctx)
+}
+
+func (kc *KeyControllerImpl) VerificationKey(token *jwt.Token) (interface{}, error) {
+	return kc.KeySvc.VerificationKey(token)
+}
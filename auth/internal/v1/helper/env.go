@@ -3,6 +3,7 @@ package helper
 import (
 	"os"
 	"strconv"
+	"strings"
 )
 
 func GetEnvString(e string) string {
@@ -20,3 +21,15 @@ func GetEnvBool(e string) bool {
 
 	return eBoolean
 }
+
+func GetEnvStringSlice(e string) []string {
+	values := []string{}
+
+	for _, v := range strings.Split(os.Getenv(e), ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
+		}
+	}
+
+	return values
+}
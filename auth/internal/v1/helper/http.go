@@ -1,6 +1,11 @@
 package helper
 
-import "github.com/gin-gonic/gin"
+import (
+	"net/http"
+
+	"github.com/PickHD/singkatin-revamp/auth/internal/v1/model"
+	"github.com/gin-gonic/gin"
+)
 
 type (
 	BaseResponse struct {
@@ -37,5 +42,22 @@ func NewResponses[T any](ctx *gin.Context, statusCode int, message string, data
 	})
 }
 
+// NewErrorResponses return JSON responses of err with HTTP status mapped from its kind,
+// unexpected errors responded with message instead of leaking their cause
+func NewErrorResponses[T any](ctx *gin.Context, err error, message string, data T) {
+	statusCode := model.HTTPStatus(err)
+	if statusCode == http.StatusInternalServerError {
+		NewResponses[T](ctx, statusCode, message, data, model.NewError(model.Internal, message), nil)
+		return
+	}
+
+	typedErr := model.AsError(err)
+	if retryAfter, ok := typedErr.Details[model.DetailRetryAfter]; ok {
+		ctx.Header("Retry-After", retryAfter)
+	}
+
+	NewResponses[T](ctx, statusCode, err.Error(), data, typedErr, nil)
+}
+
 // OptionsHandler will handing preflight requests
 func OptionsHandler(ctx *gin.Context) {}
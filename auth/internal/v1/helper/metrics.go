@@ -0,0 +1,52 @@
+package helper
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+const (
+	// LoginSucceeded, LoginFailed, LoginMFARequired & LoginBlocked are result label of login attempts
+	LoginSucceeded   = "succeeded"
+	LoginFailed      = "failed"
+	LoginMFARequired = "mfa_required"
+	LoginBlocked     = "blocked"
+
+	// RefreshSucceeded, RefreshFailed & RefreshReused are result label of token refreshes
+	RefreshSucceeded = "succeeded"
+	RefreshFailed    = "failed"
+	RefreshReused    = "reused"
+)
+
+var (
+	// HTTPRequestDuration observe latency of HTTP requests by method, route & status
+	HTTPRequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "http_request_duration_seconds",
+		Help:    "Latency of HTTP requests by method, route & status.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"method", "route", "status"})
+
+	// Registrations count users successfully registered
+	Registrations = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "auth_registrations_total",
+		Help: "Total users registered.",
+	})
+
+	// Logins count login attempts by result
+	Logins = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "auth_logins_total",
+		Help: "Total login attempts by result.",
+	}, []string{"result"})
+
+	// TokenRefreshes count refresh token rotations by result, reused means the whole token family revoked
+	TokenRefreshes = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "auth_token_refreshes_total",
+		Help: "Total refresh token rotations by result.",
+	}, []string{"result"})
+
+	// AttemptLockouts count lockouts of brute force protection by attempt type & subject (email / ip)
+	AttemptLockouts = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "auth_attempt_lockouts_total",
+		Help: "Total lockouts of login & forgot password attempts by type & subject.",
+	}, []string{"type", "subject"})
+)
@@ -0,0 +1,55 @@
+package helper
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"math/big"
+	"strings"
+
+	"github.com/skip2/go-qrcode"
+)
+
+const (
+	// backupCodeCharset avoid look alike characters, backup codes typed by hand
+	backupCodeCharset = "abcdefghjkmnpqrstuvwxyz23456789"
+	backupCodeLength  = 10
+
+	// qrCodeSize is width & height in pixels of 2FA enrolment QR code
+	qrCodeSize = 256
+)
+
+// GenerateBackupCode will generating one time 2FA backup code formatted as xxxxx-xxxxx
+func GenerateBackupCode() (string, error) {
+	sb := strings.Builder{}
+	sb.Grow(backupCodeLength + 1)
+
+	for i := 0; i < backupCodeLength; i++ {
+		if i == backupCodeLength/2 {
+			sb.WriteByte('-')
+		}
+
+		idx, err := rand.Int(rand.Reader, big.NewInt(int64(len(backupCodeCharset))))
+		if err != nil {
+			return "", err
+		}
+
+		sb.WriteByte(backupCodeCharset[idx.Int64()])
+	}
+
+	return sb.String(), nil
+}
+
+// NormalizeCode will removing spaces & dashes from 2FA code typed by users, so it compared as generated
+func NormalizeCode(code string) string {
+	return strings.ToLower(strings.NewReplacer(" ", "", "-", "").Replace(code))
+}
+
+// QRCodeDataURI will encoding content as PNG QR code data uri, ready to be rendered as image
+func QRCodeDataURI(content string) (string, error) {
+	png, err := qrcode.Encode(content, qrcode.Medium, qrCodeSize)
+	if err != nil {
+		return "", err
+	}
+
+	return "data:image/png;base64," + base64.StdEncoding.EncodeToString(png), nil
+}
@@ -2,7 +2,9 @@ package infrastructure
 
 import (
 	"github.com/PickHD/singkatin-revamp/auth/internal/v1/application"
+	"github.com/PickHD/singkatin-revamp/auth/internal/v1/middleware"
 	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
@@ -20,11 +22,21 @@ func ServeHTTP(app *application.App) *gin.Engine {
 func setupRouter(app *application.App) {
 	var dep = application.SetupDependencyInjection(app)
 
+	validateJWT := middleware.ValidateJWTMiddleware(dep.KeyController.VerificationKey)
+
+	app.Application.GET("/metrics", gin.WrapH(promhttp.Handler()))
+
+	app.Application.GET("/.well-known/jwks.json", dep.KeyController.JWKS)
+
 	v1 := app.Application.Group("/v1")
 	{
 		v1.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 
-		v1.GET("/health-check", dep.HealthCheckController.Check)
+		v1.GET("/live", dep.HealthCheckController.Live)
+
+		v1.GET("/ready", dep.HealthCheckController.Ready)
+
+		v1.GET("/health-check", dep.HealthCheckController.Ready)
 
 		v1.POST("/register", dep.AuthController.Register)
 
@@ -32,6 +44,22 @@ func setupRouter(app *application.App) {
 
 		v1.POST("/login", dep.AuthController.Login)
 
+		v1.POST("/login/mfa", dep.AuthController.LoginMFA)
+
+		v1.POST("/token/refresh", dep.AuthController.RefreshToken)
+
+		v1.POST("/logout", validateJWT, dep.AuthController.Logout)
+
+		v1.POST("/logout/all", validateJWT, dep.AuthController.LogoutAll)
+
+		v1.POST("/2fa/enroll", validateJWT, dep.AuthController.EnrollTOTP)
+
+		v1.POST("/2fa/confirm", validateJWT, dep.AuthController.ConfirmTOTP)
+
+		v1.POST("/2fa/reset", validateJWT, dep.AuthController.ResetTOTP)
+
+		v1.POST("/2fa/disable", validateJWT, dep.AuthController.DisableTOTP)
+
 		v1.POST("/forgot-password", dep.AuthController.ForgotPassword)
 
 		v1.GET("/forgot-password/verify", dep.AuthController.VerifyForgotPassword)
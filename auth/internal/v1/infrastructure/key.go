@@ -0,0 +1,32 @@
+package infrastructure
+
+import (
+	"time"
+
+	"github.com/PickHD/singkatin-revamp/auth/internal/v1/application"
+)
+
+// keyRotationCheckInterval is delay between checking signing keys scheduled for current & next period
+const keyRotationCheckInterval = time.Minute
+
+// RotateSigningKeys will scheduling signing keys of current & next rotation period, then keep checking in background
+func RotateSigningKeys(app *application.App) {
+	dep := application.SetupDependencyInjection(app)
+
+	if err := dep.KeyController.RotateKeys(); err != nil {
+		app.Logger.Error("RotateKeys ERROR, ", err)
+	}
+
+	app.Logger.Info("Checking Signing Keys Rotation every ", keyRotationCheckInterval, ".....")
+
+	go func() {
+		ticker := time.NewTicker(keyRotationCheckInterval)
+		defer ticker.Stop()
+
+		for range ticker.C {
+			if err := dep.KeyController.RotateKeys(); err != nil {
+				app.Logger.Error("RotateKeys ERROR, ", err)
+			}
+		}
+	}()
+}
@@ -0,0 +1,94 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/PickHD/singkatin-revamp/auth/internal/v1/helper"
+	"github.com/PickHD/singkatin-revamp/auth/internal/v1/model"
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt"
+)
+
+const (
+	payloadUserID    string = "user_id"
+	payloadJTI       string = "jti"
+	payloadSessionID string = "sid"
+	payloadIssuedAt  string = "iat"
+	payloadExpires   string = "exp"
+)
+
+// ValidateJWTMiddleware responsible to validating jwt in header each request against signing key picked by keyFunc,
+// revocation checked by the handler itself
+func ValidateJWTMiddleware(keyFunc jwt.Keyfunc) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		// validate JWT coming from request, if valid decode into a struct
+		claims, err := validate(ctx, keyFunc)
+		if err != nil {
+			helper.NewResponses[any](ctx, http.StatusUnauthorized, "Unauthorized access, reason : "+err.Error(), nil, err, nil)
+			return
+		}
+
+		// pass decoded claims into ctx
+		ctx.Set(model.KeyJWTValidAccess, claims)
+
+		// going to next handler..
+		ctx.Next()
+	}
+}
+
+// Extract will extracting claims data from ctx
+func Extract(ctx *gin.Context) (*model.AccessClaims, error) {
+	claims, ok := ctx.MustGet(model.KeyJWTValidAccess).(*model.AccessClaims)
+	if !ok {
+		return nil, model.NewError(model.Type, "type assertion extract data error")
+	}
+
+	return claims, nil
+}
+
+// validate will checking validity of signed JWT token from request in
+func validate(ctx *gin.Context, keyFunc jwt.Keyfunc) (*model.AccessClaims, error) {
+	header := ctx.GetHeader("Authorization")
+	if !strings.HasPrefix(header, "Bearer ") {
+		return nil, model.NewError(model.NotFound, "Token not found")
+	}
+
+	validToken, err := jwt.Parse(strings.TrimPrefix(header, "Bearer "), keyFunc)
+	if err != nil {
+		return nil, err
+	}
+
+	mapClaims, ok := validToken.Claims.(jwt.MapClaims)
+	if !ok {
+		return nil, model.NewError(model.Type, "type assertion claims error")
+	}
+
+	claims := &model.AccessClaims{}
+
+	if claims.UserID, ok = mapClaims[payloadUserID].(string); !ok {
+		return nil, model.NewError(model.Type, "type assertion user_id error")
+	}
+
+	if claims.JTI, ok = mapClaims[payloadJTI].(string); !ok {
+		return nil, model.NewError(model.Type, "type assertion jti error")
+	}
+
+	if claims.SessionID, ok = mapClaims[payloadSessionID].(string); !ok {
+		return nil, model.NewError(model.Type, "type assertion sid error")
+	}
+
+	issuedAt, ok := mapClaims[payloadIssuedAt].(float64)
+	if !ok {
+		return nil, model.NewError(model.Type, "type assertion iat error")
+	}
+	claims.IssuedAt = int64(issuedAt)
+
+	expiresAt, ok := mapClaims[payloadExpires].(float64)
+	if !ok {
+		return nil, model.NewError(model.Type, "type assertion exp error")
+	}
+	claims.ExpiresAt = int64(expiresAt)
+
+	return claims, nil
+}
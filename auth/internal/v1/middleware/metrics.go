@@ -0,0 +1,26 @@
+package middleware
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/PickHD/singkatin-revamp/auth/internal/v1/helper"
+	"github.com/gin-gonic/gin"
+)
+
+// MetricsMiddleware will observing latency & status of every request
+func MetricsMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+
+		c.Next()
+
+		// unmatched routes grouped together to keep labels bounded
+		route := c.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
+
+		helper.HTTPRequestDuration.WithLabelValues(c.Request.Method, route, strconv.Itoa(c.Writer.Status())).Observe(time.Since(start).Seconds())
+	}
+}
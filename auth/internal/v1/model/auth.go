@@ -24,13 +24,72 @@ type (
 	LoginRequest struct {
 		Email    string `json:"email"`
 		Password string `json:"password"`
+		ClientIP string `json:"-"`
 	}
 
-	// LoginResponse consist response of success login as users
+	// LoginResponse consist response of success login as users, also responded when refreshing tokens.
+	// Users with 2FA enabled responded MFA challenge token instead, exchanged with tokens by completing login with TOTP / backup code
 	LoginResponse struct {
-		AccessToken string    `json:"access_token"`
-		Type        string    `json:"type"`
-		ExpireAt    time.Time `json:"expired_at"`
+		AccessToken           string     `json:"access_token,omitempty"`
+		Type                  string     `json:"type,omitempty"`
+		ExpireAt              *time.Time `json:"expired_at,omitempty"`
+		RefreshToken          string     `json:"refresh_token,omitempty"`
+		RefreshTokenExpiredAt *time.Time `json:"refresh_token_expired_at,omitempty"`
+		MFARequired           bool       `json:"mfa_required,omitempty"`
+		MFAToken              string     `json:"mfa_token,omitempty"`
+		MFAExpiredAt          *time.Time `json:"mfa_expired_at,omitempty"`
+	}
+
+	// MFALoginRequest consist request of completing login as users with 2FA enabled
+	MFALoginRequest struct {
+		MFAToken string `json:"mfa_token"`
+		Code     string `json:"code"`
+		ClientIP string `json:"-"`
+	}
+
+	// TOTPEnrolmentResponse consist response of enrolling 2FA, secret provisioned into authenticator app by otpauth uri / its QR code
+	TOTPEnrolmentResponse struct {
+		Secret     string    `json:"secret"`
+		OTPAuthURI string    `json:"otpauth_uri"`
+		QRCode     string    `json:"qr_code"`
+		ExpireAt   time.Time `json:"expired_at"`
+	}
+
+	// TOTPConfirmRequest consist request of confirming 2FA enrolment with first code generated
+	TOTPConfirmRequest struct {
+		Code string `json:"code"`
+	}
+
+	// TOTPConfirmResponse consist one time backup codes, only responded once when 2FA enabled
+	TOTPConfirmResponse struct {
+		BackupCodes []string `json:"backup_codes"`
+	}
+
+	// PasswordConfirmRequest consist request re-verifying users password before changing 2FA
+	PasswordConfirmRequest struct {
+		Password string `json:"password"`
+		ClientIP string `json:"-"`
+	}
+
+	// RefreshTokenRequest consist request of exchanging refresh token with new pair of tokens
+	RefreshTokenRequest struct {
+		RefreshToken string `json:"refresh_token"`
+	}
+
+	// AccessClaims consist decoded claims of valid access token
+	AccessClaims struct {
+		UserID    string
+		JTI       string
+		SessionID string
+		IssuedAt  int64
+		ExpiresAt int64
+	}
+
+	// RefreshToken consist data of issued refresh token, stored by its hash
+	RefreshToken struct {
+		UserID   string `redis:"user_id"`
+		FamilyID string `redis:"family_id"`
+		UsedAt   int64  `redis:"used_at,omitempty"`
 	}
 
 	VerifyCodeResponse struct {
@@ -39,12 +98,22 @@ type (
 
 	// ForgotPasswordRequest consist request of forgot password users
 	ForgotPasswordRequest struct {
-		Email string `json:"email"`
+		Email    string `json:"email"`
+		ClientIP string `json:"-"`
 	}
 
 	// VerificationType consist type of verification
 	VerificationType string
 
+	// AttemptType consist type of attempts protected from brute force, counted per email & per client IP
+	AttemptType string
+
+	// AttemptsBlock consist reason of attempts blocked along with remaining duration
+	AttemptsBlock struct {
+		Reason     string
+		RetryAfter time.Duration
+	}
+
 	ResetPasswordRequest struct {
 		NewPassword string `json:"new_password"`
 	}
@@ -55,6 +124,15 @@ const (
 	ForgotPasswordVerification VerificationType = "forgot_password_verification"
 )
 
+const (
+	LoginAttempt          AttemptType = "login"
+	ForgotPasswordAttempt AttemptType = "forgot_password"
+
+	// AttemptsDelayed & AttemptsLocked are reason of attempts blocked, throttled progressively or locked out for a while
+	AttemptsDelayed = "delayed"
+	AttemptsLocked  = "locked"
+)
+
 var (
 	IsValidEmail, _ = regexp.Compile(`^(?P<name>[a-zA-Z0-9.!#$%&'*+/=?^_ \x60{|}~-]+)@(?P<domain>[a-zA-Z0-9](?:[a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?(?:\.[a-zA-Z0-9](?:[a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?)*)$`)
 )
@@ -1,21 +1,118 @@
 package model
 
 import (
+	"errors"
 	"fmt"
-
-	"github.com/pkg/errors"
+	"net/http"
+	"strings"
 )
 
 type ErrorKind string
 
 const (
-	Validation ErrorKind = "Validation Error"
-	Type       ErrorKind = "Type Error"
-	NotFound   ErrorKind = "Not Found"
-	Unknown    ErrorKind = "Unknown Error"
+	Validation      ErrorKind = "Validation Error"
+	Type            ErrorKind = "Type Error"
+	NotFound        ErrorKind = "Not Found"
+	Unauthorized    ErrorKind = "Unauthorized"
+	TooManyRequests ErrorKind = "Too Many Requests"
+	Unknown         ErrorKind = "Unknown Error"
+	Internal        ErrorKind = "Internal Server Error"
+)
+
+// CodeRefreshTokenReused is code of error responded when rotated refresh token presented again
+const CodeRefreshTokenReused = "REFRESH_TOKEN_REUSED"
+
+// CodeAttemptsDelayed & CodeAccountLocked are codes of errors responded when attempts throttled or temporarily locked out
+const (
+	CodeAttemptsDelayed = "ATTEMPTS_DELAYED"
+	CodeAccountLocked   = "ACCOUNT_LOCKED"
 )
 
-// NewError return wrapped dynamic errors
+// DetailRetryAfter is detail key of seconds before attempts allowed again
+const DetailRetryAfter = "retry_after"
+
+// httpStatuses is HTTP status responded for every kind of errors, kinds not listed responded as internal server error
+var httpStatuses = map[ErrorKind]int{
+	Validation:      http.StatusBadRequest,
+	Type:            http.StatusBadRequest,
+	NotFound:        http.StatusNotFound,
+	Unauthorized:    http.StatusUnauthorized,
+	TooManyRequests: http.StatusTooManyRequests,
+}
+
+// Error is typed error shared across HTTP, gRPC & queue boundaries, matched using errors.As
+type Error struct {
+	Kind    ErrorKind         `json:"kind"`
+	Code    string            `json:"code"`
+	Message string            `json:"message"`
+	Details map[string]string `json:"details,omitempty"`
+	Cause   error             `json:"-"`
+}
+
+// NewError return typed error of kind, coded by its kind
 func NewError(kind ErrorKind, msg string) error {
-	return errors.New(fmt.Sprintf("%s: %s", string(kind), msg))
+	return &Error{Kind: kind, Code: ErrorCode(kind), Message: msg}
+}
+
+// WrapError return typed error of kind wrapping the error caused it
+func WrapError(kind ErrorKind, msg string, cause error) error {
+	return &Error{Kind: kind, Code: ErrorCode(kind), Message: msg, Cause: cause}
+}
+
+// ErrorCode return default machine readable code of kind, e.g. NOT_FOUND
+func ErrorCode(kind ErrorKind) string {
+	return strings.ToUpper(strings.ReplaceAll(string(kind), " ", "_"))
+}
+
+func (e *Error) Error() string {
+	if e.Cause != nil {
+		return fmt.Sprintf("%s: %s: %s", e.Kind, e.Message, e.Cause.Error())
+	}
+
+	return fmt.Sprintf("%s: %s", e.Kind, e.Message)
+}
+
+func (e *Error) Unwrap() error {
+	return e.Cause
+}
+
+// WithCode replace default code of error with more specific one
+func (e *Error) WithCode(code string) *Error {
+	e.Code = code
+	return e
+}
+
+// WithDetail attach detail describing the error, e.g. invalid field
+func (e *Error) WithDetail(key string, value string) *Error {
+	if e.Details == nil {
+		e.Details = make(map[string]string)
+	}
+
+	e.Details[key] = value
+	return e
+}
+
+// AsError return typed error inside chain of err, errors not typed treated as internal error
+func AsError(err error) *Error {
+	var e *Error
+	if errors.As(err, &e) {
+		return e
+	}
+
+	return &Error{Kind: Internal, Code: ErrorCode(Internal), Message: err.Error(), Cause: err}
+}
+
+// IsKind checking err is typed error of kind
+func IsKind(err error, kind ErrorKind) bool {
+	var e *Error
+	return errors.As(err, &e) && e.Kind == kind
+}
+
+// HTTPStatus return HTTP status of err mapped from its kind
+func HTTPStatus(err error) int {
+	if code, ok := httpStatuses[AsError(err).Kind]; ok {
+		return code
+	}
+
+	return http.StatusInternalServerError
 }
@@ -0,0 +1,23 @@
+package model
+
+const (
+	// HealthUp & HealthDown are status of service or its dependencies
+	HealthUp   = "UP"
+	HealthDown = "DOWN"
+)
+
+type (
+	// DependencyHealth consist status, latency & error of checking single dependency
+	DependencyHealth struct {
+		Name      string  `json:"name"`
+		Status    string  `json:"status"`
+		LatencyMs float64 `json:"latency_ms"`
+		Error     string  `json:"error,omitempty"`
+	}
+
+	// HealthReport consist overall status of service along with status of every dependency
+	HealthReport struct {
+		Status       string             `json:"status"`
+		Dependencies []DependencyHealth `json:"dependencies,omitempty"`
+	}
+)
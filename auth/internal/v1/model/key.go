@@ -1,5 +1,23 @@
 package model
 
 const (
-	VerificationKey = "%s:%s"
+	VerificationKey       = "%s:%s"
+	RefreshTokenKey       = "refresh_token:%s"
+	RefreshTokenFamilyKey = "refresh_token_family:%s"
+	RefreshTokenUserKey   = "refresh_token_user:%s"
+	TOTPEnrolmentKey      = "totp_enrolment:%s"
+	TOTPUsedCodeKey       = "totp_used:%s:%s"
+	MFAChallengeKey       = "mfa_challenge:%s"
+
+	// AttemptsKey is sliding window of attempts by type & subject (email / client IP), AttemptsBlockKey block them until expired
+	AttemptsKey      = "attempts:%s:%s"
+	AttemptsBlockKey = "attempts_block:%s:%s"
+
+	// RevokedAccessTokenKey & RevokedAccessUserKey are revocation list keys of single access token by its jti
+	// & every access token of users issued before stored unix time, shared with user services
+	RevokedAccessTokenKey = "revoked_access_token:%s"
+	RevokedAccessUserKey  = "revoked_access_user:%s"
+
+	// KeyJWTValidAccess is context key identifier for valid jwt token
+	KeyJWTValidAccess = "ValidJWTAccess"
 )
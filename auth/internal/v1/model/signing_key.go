@@ -0,0 +1,44 @@
+package model
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type (
+	// SigningKey consist data of key signing access tokens, scheduled to sign tokens between activate_at & retire_at,
+	// and published for verifying them until expire_at
+	SigningKey struct {
+		ID         primitive.ObjectID `bson:"_id,omitempty"`
+		KID        string             `bson:"kid"`
+		Algorithm  string             `bson:"algorithm"`
+		PrivateKey string             `bson:"private_key"`
+		ActivateAt time.Time          `bson:"activate_at"`
+		RetireAt   time.Time          `bson:"retire_at"`
+		ExpireAt   time.Time          `bson:"expire_at"`
+	}
+
+	// JWK consist public part of signing key as JSON Web Key (RFC 7517)
+	JWK struct {
+		KeyType   string `json:"kty"`
+		KeyID     string `json:"kid"`
+		Use       string `json:"use"`
+		Algorithm string `json:"alg"`
+		N         string `json:"n,omitempty"`
+		E         string `json:"e,omitempty"`
+		Curve     string `json:"crv,omitempty"`
+		X         string `json:"x,omitempty"`
+	}
+
+	// JWKS consist set of published JWK
+	JWKS struct {
+		Keys []JWK `json:"keys"`
+	}
+)
+
+const (
+	// AlgorithmRS256 & AlgorithmEdDSA are supported algorithm of signing keys
+	AlgorithmRS256 = "RS256"
+	AlgorithmEdDSA = "EdDSA"
+)
@@ -9,11 +9,14 @@ import (
 type (
 	// User consist data of users
 	User struct {
-		ID         primitive.ObjectID `bson:"_id,omitempty"`
-		FullName   string             `bson:"fullname,omitempty"`
-		IsVerified bool               `bson:"is_verified,omitempty"`
-		Email      string             `bson:"email,omitempty"`
-		Password   string             `bson:"password,omitempty"`
-		CreatedAt  time.Time          `bson:"created_at,omitempty"`
+		ID          primitive.ObjectID `bson:"_id,omitempty"`
+		FullName    string             `bson:"fullname,omitempty"`
+		IsVerified  bool               `bson:"is_verified,omitempty"`
+		Email       string             `bson:"email,omitempty"`
+		Password    string             `bson:"password,omitempty"`
+		TOTPEnabled bool               `bson:"totp_enabled,omitempty"`
+		TOTPSecret  string             `bson:"totp_secret,omitempty"`
+		BackupCodes []string           `bson:"backup_codes,omitempty"`
+		CreatedAt   time.Time          `bson:"created_at,omitempty"`
 	}
 )
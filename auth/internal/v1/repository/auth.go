@@ -3,6 +3,7 @@ package repository
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"time"
 
 	"github.com/PickHD/singkatin-revamp/auth/internal/v1/config"
@@ -24,6 +25,28 @@ type (
 		GetVerificationByCode(ctx context.Context, code string, verificationType model.VerificationType) (string, error)
 		UpdateVerifyStatusByEmail(ctx context.Context, email string) error
 		UpdatePasswordByEmail(ctx context.Context, email string, newPassword string) error
+		FindByID(ctx context.Context, id string) (*model.User, error)
+		SetRefreshToken(ctx context.Context, tokenHash string, token *model.RefreshToken, duration time.Duration) error
+		GetRefreshToken(ctx context.Context, tokenHash string) (*model.RefreshToken, error)
+		MarkRefreshTokenUsed(ctx context.Context, tokenHash string) (bool, error)
+		RevokeRefreshTokenFamily(ctx context.Context, familyID string) error
+		RevokeRefreshTokenFamiliesByUserID(ctx context.Context, userID string) error
+		RevokeAccessToken(ctx context.Context, jti string, duration time.Duration) error
+		RevokeAccessTokensByUserID(ctx context.Context, userID string, issuedBefore int64, duration time.Duration) error
+		IsAccessTokenRevoked(ctx context.Context, claims *model.AccessClaims) (bool, error)
+		SetTOTPEnrolment(ctx context.Context, userID string, secret string, duration time.Duration) error
+		GetTOTPEnrolment(ctx context.Context, userID string) (string, error)
+		EnableTOTPByID(ctx context.Context, id primitive.ObjectID, secret string, backupCodes []string) error
+		DisableTOTPByID(ctx context.Context, id primitive.ObjectID) error
+		UseBackupCode(ctx context.Context, id primitive.ObjectID, codeHash string) (bool, error)
+		MarkTOTPCodeUsed(ctx context.Context, userID string, code string, duration time.Duration) (bool, error)
+		SetMFAChallenge(ctx context.Context, tokenHash string, userID string, duration time.Duration) error
+		AttemptMFAChallenge(ctx context.Context, tokenHash string) (string, int64, error)
+		DeleteMFAChallenge(ctx context.Context, tokenHash string) error
+		RecordAttempt(ctx context.Context, attemptType model.AttemptType, subject string, window time.Duration) (int64, error)
+		ClearAttempts(ctx context.Context, attemptType model.AttemptType, subject string) error
+		SetAttemptsBlock(ctx context.Context, attemptType model.AttemptType, subject string, reason string, duration time.Duration) error
+		GetAttemptsBlock(ctx context.Context, attemptType model.AttemptType, subject string) (*model.AttemptsBlock, error)
 	}
 
 	// AuthRepositoryImpl is an app auth struct that consists of all the dependencies needed for auth repository
@@ -171,3 +194,477 @@ func (ar *AuthRepositoryImpl) UpdatePasswordByEmail(ctx context.Context, email s
 
 	return nil
 }
+
+func (ar *AuthRepositoryImpl) FindByID(ctx context.Context, id string) (*model.User, error) {
+	tr := ar.Tracer.Tracer("Auth-FindByID repository")
+	ctx, span := tr.Start(ctx, "Start FindByID")
+	defer span.End()
+
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, model.NewError(model.Validation, "invalid user id")
+	}
+
+	user := model.User{}
+
+	err = ar.DB.Collection(ar.Config.Database.UsersCollection).FindOne(ctx, bson.D{{Key: "_id", Value: objID}, {Key: "is_verified", Value: true}}).Decode(&user)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, model.NewError(model.NotFound, "users not found")
+		}
+
+		ar.Logger.Error("AuthRepositoryImpl.FindByID FindOne ERROR, ", err)
+		return nil, err
+	}
+
+	return &user, nil
+}
+
+func (ar *AuthRepositoryImpl) SetRefreshToken(ctx context.Context, tokenHash string, token *model.RefreshToken, duration time.Duration) error {
+	tr := ar.Tracer.Tracer("Auth-SetRefreshToken repository")
+	ctx, span := tr.Start(ctx, "Start SetRefreshToken")
+	defer span.End()
+
+	tokenKey := fmt.Sprintf(model.RefreshTokenKey, tokenHash)
+	familyKey := fmt.Sprintf(model.RefreshTokenFamilyKey, token.FamilyID)
+	userKey := fmt.Sprintf(model.RefreshTokenUserKey, token.UserID)
+
+	// store token & register it into its family & the family into its user at once,
+	// family lives as long as its latest token
+	_, err := ar.Redis.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.HSet(ctx, tokenKey, token)
+		pipe.Expire(ctx, tokenKey, duration)
+		pipe.SAdd(ctx, familyKey, tokenHash)
+		pipe.Expire(ctx, familyKey, duration)
+		pipe.SAdd(ctx, userKey, token.FamilyID)
+		pipe.Expire(ctx, userKey, duration)
+
+		return nil
+	})
+	if err != nil {
+		ar.Logger.Error("AuthRepositoryImpl.SetRefreshToken TxPipelined ERROR, ", err)
+		return err
+	}
+
+	return nil
+}
+
+func (ar *AuthRepositoryImpl) GetRefreshToken(ctx context.Context, tokenHash string) (*model.RefreshToken, error) {
+	tr := ar.Tracer.Tracer("Auth-GetRefreshToken repository")
+	ctx, span := tr.Start(ctx, "Start GetRefreshToken")
+	defer span.End()
+
+	result := ar.Redis.HGetAll(ctx, fmt.Sprintf(model.RefreshTokenKey, tokenHash))
+	if result.Err() != nil {
+		ar.Logger.Error("AuthRepositoryImpl.GetRefreshToken HGetAll ERROR, ", result.Err())
+		return nil, result.Err()
+	}
+
+	// HGetAll respond empty map instead of redis.Nil when key not exists
+	if len(result.Val()) == 0 {
+		return nil, redis.Nil
+	}
+
+	token := model.RefreshToken{}
+
+	err := result.Scan(&token)
+	if err != nil {
+		ar.Logger.Error("AuthRepositoryImpl.GetRefreshToken Scan ERROR, ", err)
+		return nil, err
+	}
+
+	return &token, nil
+}
+
+func (ar *AuthRepositoryImpl) MarkRefreshTokenUsed(ctx context.Context, tokenHash string) (bool, error) {
+	tr := ar.Tracer.Tracer("Auth-MarkRefreshTokenUsed repository")
+	ctx, span := tr.Start(ctx, "Start MarkRefreshTokenUsed")
+	defer span.End()
+
+	// HSETNX only succeed for the first caller, concurrent rotations of same token treated as reuse
+	ok, err := ar.Redis.HSetNX(ctx, fmt.Sprintf(model.RefreshTokenKey, tokenHash), "used_at", time.Now().Unix()).Result()
+	if err != nil {
+		ar.Logger.Error("AuthRepositoryImpl.MarkRefreshTokenUsed HSetNX ERROR, ", err)
+		return false, err
+	}
+
+	return ok, nil
+}
+
+func (ar *AuthRepositoryImpl) RevokeRefreshTokenFamily(ctx context.Context, familyID string) error {
+	tr := ar.Tracer.Tracer("Auth-RevokeRefreshTokenFamily repository")
+	ctx, span := tr.Start(ctx, "Start RevokeRefreshTokenFamily")
+	defer span.End()
+
+	familyKey := fmt.Sprintf(model.RefreshTokenFamilyKey, familyID)
+
+	tokenHashes, err := ar.Redis.SMembers(ctx, familyKey).Result()
+	if err != nil {
+		ar.Logger.Error("AuthRepositoryImpl.RevokeRefreshTokenFamily SMembers ERROR, ", err)
+		return err
+	}
+
+	keys := make([]string, 0, len(tokenHashes)+1)
+	for _, tokenHash := range tokenHashes {
+		keys = append(keys, fmt.Sprintf(model.RefreshTokenKey, tokenHash))
+	}
+	keys = append(keys, familyKey)
+
+	err = ar.Redis.Del(ctx, keys...).Err()
+	if err != nil {
+		ar.Logger.Error("AuthRepositoryImpl.RevokeRefreshTokenFamily Del ERROR, ", err)
+		return err
+	}
+
+	return nil
+}
+
+func (ar *AuthRepositoryImpl) RevokeRefreshTokenFamiliesByUserID(ctx context.Context, userID string) error {
+	tr := ar.Tracer.Tracer("Auth-RevokeRefreshTokenFamiliesByUserID repository")
+	ctx, span := tr.Start(ctx, "Start RevokeRefreshTokenFamiliesByUserID")
+	defer span.End()
+
+	userKey := fmt.Sprintf(model.RefreshTokenUserKey, userID)
+
+	familyIDs, err := ar.Redis.SMembers(ctx, userKey).Result()
+	if err != nil {
+		ar.Logger.Error("AuthRepositoryImpl.RevokeRefreshTokenFamiliesByUserID SMembers ERROR, ", err)
+		return err
+	}
+
+	for _, familyID := range familyIDs {
+		err = ar.RevokeRefreshTokenFamily(ctx, familyID)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = ar.Redis.Del(ctx, userKey).Err()
+	if err != nil {
+		ar.Logger.Error("AuthRepositoryImpl.RevokeRefreshTokenFamiliesByUserID Del ERROR, ", err)
+		return err
+	}
+
+	return nil
+}
+
+func (ar *AuthRepositoryImpl) RevokeAccessToken(ctx context.Context, jti string, duration time.Duration) error {
+	tr := ar.Tracer.Tracer("Auth-RevokeAccessToken repository")
+	ctx, span := tr.Start(ctx, "Start RevokeAccessToken")
+	defer span.End()
+
+	err := ar.Redis.SetEx(ctx, fmt.Sprintf(model.RevokedAccessTokenKey, jti), 1, duration).Err()
+	if err != nil {
+		ar.Logger.Error("AuthRepositoryImpl.RevokeAccessToken SetEx ERROR, ", err)
+		return err
+	}
+
+	return nil
+}
+
+func (ar *AuthRepositoryImpl) RevokeAccessTokensByUserID(ctx context.Context, userID string, issuedBefore int64, duration time.Duration) error {
+	tr := ar.Tracer.Tracer("Auth-RevokeAccessTokensByUserID repository")
+	ctx, span := tr.Start(ctx, "Start RevokeAccessTokensByUserID")
+	defer span.End()
+
+	err := ar.Redis.SetEx(ctx, fmt.Sprintf(model.RevokedAccessUserKey, userID), issuedBefore, duration).Err()
+	if err != nil {
+		ar.Logger.Error("AuthRepositoryImpl.RevokeAccessTokensByUserID SetEx ERROR, ", err)
+		return err
+	}
+
+	return nil
+}
+
+func (ar *AuthRepositoryImpl) IsAccessTokenRevoked(ctx context.Context, claims *model.AccessClaims) (bool, error) {
+	tr := ar.Tracer.Tracer("Auth-IsAccessTokenRevoked repository")
+	ctx, span := tr.Start(ctx, "Start IsAccessTokenRevoked")
+	defer span.End()
+
+	values, err := ar.Redis.MGet(ctx, fmt.Sprintf(model.RevokedAccessTokenKey, claims.JTI), fmt.Sprintf(model.RevokedAccessUserKey, claims.UserID)).Result()
+	if err != nil {
+		ar.Logger.Error("AuthRepositoryImpl.IsAccessTokenRevoked MGet ERROR, ", err)
+		return false, err
+	}
+
+	if values[0] != nil {
+		return true, nil
+	}
+
+	if revokedBefore, ok := values[1].(string); ok {
+		issuedBefore, err := strconv.ParseInt(revokedBefore, 10, 64)
+		if err != nil {
+			ar.Logger.Error("AuthRepositoryImpl.IsAccessTokenRevoked ParseInt ERROR, ", err)
+			return false, err
+		}
+
+		return claims.IssuedAt < issuedBefore, nil
+	}
+
+	return false, nil
+}
+
+func (ar *AuthRepositoryImpl) SetTOTPEnrolment(ctx context.Context, userID string, secret string, duration time.Duration) error {
+	tr := ar.Tracer.Tracer("Auth-SetTOTPEnrolment repository")
+	ctx, span := tr.Start(ctx, "Start SetTOTPEnrolment")
+	defer span.End()
+
+	err := ar.Redis.SetEx(ctx, fmt.Sprintf(model.TOTPEnrolmentKey, userID), secret, duration).Err()
+	if err != nil {
+		ar.Logger.Error("AuthRepositoryImpl.SetTOTPEnrolment SetEx ERROR, ", err)
+		return err
+	}
+
+	return nil
+}
+
+func (ar *AuthRepositoryImpl) GetTOTPEnrolment(ctx context.Context, userID string) (string, error) {
+	tr := ar.Tracer.Tracer("Auth-GetTOTPEnrolment repository")
+	ctx, span := tr.Start(ctx, "Start GetTOTPEnrolment")
+	defer span.End()
+
+	result := ar.Redis.Get(ctx, fmt.Sprintf(model.TOTPEnrolmentKey, userID))
+	if result.Err() != nil {
+		ar.Logger.Error("AuthRepositoryImpl.GetTOTPEnrolment Get ERROR, ", result.Err())
+		return "", result.Err()
+	}
+
+	return result.Val(), nil
+}
+
+func (ar *AuthRepositoryImpl) EnableTOTPByID(ctx context.Context, id primitive.ObjectID, secret string, backupCodes []string) error {
+	tr := ar.Tracer.Tracer("Auth-EnableTOTPByID repository")
+	ctx, span := tr.Start(ctx, "Start EnableTOTPByID")
+	defer span.End()
+
+	_, err := ar.DB.Collection(ar.Config.Database.UsersCollection).UpdateOne(ctx,
+		bson.D{{Key: "_id", Value: id}}, bson.M{
+			"$set": bson.D{
+				{Key: "totp_enabled", Value: true},
+				{Key: "totp_secret", Value: secret},
+				{Key: "backup_codes", Value: backupCodes},
+			},
+		})
+	if err != nil {
+		ar.Logger.Error("AuthRepositoryImpl.EnableTOTPByID UpdateOne ERROR, ", err)
+		return err
+	}
+
+	// enrolment confirmed, pending secret no longer needed
+	err = ar.Redis.Del(ctx, fmt.Sprintf(model.TOTPEnrolmentKey, id.Hex())).Err()
+	if err != nil {
+		ar.Logger.Error("AuthRepositoryImpl.EnableTOTPByID Del ERROR, ", err)
+		return err
+	}
+
+	return nil
+}
+
+func (ar *AuthRepositoryImpl) DisableTOTPByID(ctx context.Context, id primitive.ObjectID) error {
+	tr := ar.Tracer.Tracer("Auth-DisableTOTPByID repository")
+	ctx, span := tr.Start(ctx, "Start DisableTOTPByID")
+	defer span.End()
+
+	_, err := ar.DB.Collection(ar.Config.Database.UsersCollection).UpdateOne(ctx,
+		bson.D{{Key: "_id", Value: id}}, bson.M{
+			"$unset": bson.D{
+				{Key: "totp_enabled", Value: ""},
+				{Key: "totp_secret", Value: ""},
+				{Key: "backup_codes", Value: ""},
+			},
+		})
+	if err != nil {
+		ar.Logger.Error("AuthRepositoryImpl.DisableTOTPByID UpdateOne ERROR, ", err)
+		return err
+	}
+
+	return nil
+}
+
+func (ar *AuthRepositoryImpl) UseBackupCode(ctx context.Context, id primitive.ObjectID, codeHash string) (bool, error) {
+	tr := ar.Tracer.Tracer("Auth-UseBackupCode repository")
+	ctx, span := tr.Start(ctx, "Start UseBackupCode")
+	defer span.End()
+
+	// pulling matched code at once, so concurrent login can't use the same code twice
+	res, err := ar.DB.Collection(ar.Config.Database.UsersCollection).UpdateOne(ctx,
+		bson.D{{Key: "_id", Value: id}, {Key: "backup_codes", Value: codeHash}}, bson.M{
+			"$pull": bson.D{{Key: "backup_codes", Value: codeHash}},
+		})
+	if err != nil {
+		ar.Logger.Error("AuthRepositoryImpl.UseBackupCode UpdateOne ERROR, ", err)
+		return false, err
+	}
+
+	return res.ModifiedCount > 0, nil
+}
+
+func (ar *AuthRepositoryImpl) MarkTOTPCodeUsed(ctx context.Context, userID string, code string, duration time.Duration) (bool, error) {
+	tr := ar.Tracer.Tracer("Auth-MarkTOTPCodeUsed repository")
+	ctx, span := tr.Start(ctx, "Start MarkTOTPCodeUsed")
+	defer span.End()
+
+	ok, err := ar.Redis.SetNX(ctx, fmt.Sprintf(model.TOTPUsedCodeKey, userID, code), 1, duration).Result()
+	if err != nil {
+		ar.Logger.Error("AuthRepositoryImpl.MarkTOTPCodeUsed SetNX ERROR, ", err)
+		return false, err
+	}
+
+	return ok, nil
+}
+
+func (ar *AuthRepositoryImpl) SetMFAChallenge(ctx context.Context, tokenHash string, userID string, duration time.Duration) error {
+	tr := ar.Tracer.Tracer("Auth-SetMFAChallenge repository")
+	ctx, span := tr.Start(ctx, "Start SetMFAChallenge")
+	defer span.End()
+
+	challengeKey := fmt.Sprintf(model.MFAChallengeKey, tokenHash)
+
+	_, err := ar.Redis.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.HSet(ctx, challengeKey, "user_id", userID, "attempts", 0)
+		pipe.Expire(ctx, challengeKey, duration)
+
+		return nil
+	})
+	if err != nil {
+		ar.Logger.Error("AuthRepositoryImpl.SetMFAChallenge TxPipelined ERROR, ", err)
+		return err
+	}
+
+	return nil
+}
+
+func (ar *AuthRepositoryImpl) AttemptMFAChallenge(ctx context.Context, tokenHash string) (string, int64, error) {
+	tr := ar.Tracer.Tracer("Auth-AttemptMFAChallenge repository")
+	ctx, span := tr.Start(ctx, "Start AttemptMFAChallenge")
+	defer span.End()
+
+	challengeKey := fmt.Sprintf(model.MFAChallengeKey, tokenHash)
+
+	var (
+		userID   *redis.StringCmd
+		attempts *redis.IntCmd
+	)
+
+	// counting attempt along with reading challenge, so code can't be guessed by flooding requests
+	_, err := ar.Redis.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		userID = pipe.HGet(ctx, challengeKey, "user_id")
+		attempts = pipe.HIncrBy(ctx, challengeKey, "attempts", 1)
+
+		return nil
+	})
+	if err != nil {
+		if err == redis.Nil {
+			// HIncrBy created key without expiry for challenge not exists
+			ar.Redis.Del(ctx, challengeKey)
+			return "", 0, redis.Nil
+		}
+
+		ar.Logger.Error("AuthRepositoryImpl.AttemptMFAChallenge TxPipelined ERROR, ", err)
+		return "", 0, err
+	}
+
+	return userID.Val(), attempts.Val(), nil
+}
+
+func (ar *AuthRepositoryImpl) DeleteMFAChallenge(ctx context.Context, tokenHash string) error {
+	tr := ar.Tracer.Tracer("Auth-DeleteMFAChallenge repository")
+	ctx, span := tr.Start(ctx, "Start DeleteMFAChallenge")
+	defer span.End()
+
+	err := ar.Redis.Del(ctx, fmt.Sprintf(model.MFAChallengeKey, tokenHash)).Err()
+	if err != nil {
+		ar.Logger.Error("AuthRepositoryImpl.DeleteMFAChallenge Del ERROR, ", err)
+		return err
+	}
+
+	return nil
+}
+
+func (ar *AuthRepositoryImpl) RecordAttempt(ctx context.Context, attemptType model.AttemptType, subject string, window time.Duration) (int64, error) {
+	tr := ar.Tracer.Tracer("Auth-RecordAttempt repository")
+	ctx, span := tr.Start(ctx, "Start RecordAttempt")
+	defer span.End()
+
+	attemptsKey := fmt.Sprintf(model.AttemptsKey, attemptType, subject)
+	now := time.Now()
+
+	var count *redis.IntCmd
+
+	// attempts older than window dropped before counting, so counter slide along instead of reset at fixed period
+	_, err := ar.Redis.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.ZRemRangeByScore(ctx, attemptsKey, "-inf", strconv.FormatInt(now.Add(-window).UnixMilli(), 10))
+		pipe.ZAdd(ctx, attemptsKey, redis.Z{Score: float64(now.UnixMilli()), Member: now.UnixNano()})
+		count = pipe.ZCard(ctx, attemptsKey)
+		pipe.PExpire(ctx, attemptsKey, window)
+
+		return nil
+	})
+	if err != nil {
+		ar.Logger.Error("AuthRepositoryImpl.RecordAttempt TxPipelined ERROR, ", err)
+		return 0, err
+	}
+
+	return count.Val(), nil
+}
+
+func (ar *AuthRepositoryImpl) ClearAttempts(ctx context.Context, attemptType model.AttemptType, subject string) error {
+	tr := ar.Tracer.Tracer("Auth-ClearAttempts repository")
+	ctx, span := tr.Start(ctx, "Start ClearAttempts")
+	defer span.End()
+
+	err := ar.Redis.Del(ctx, fmt.Sprintf(model.AttemptsKey, attemptType, subject)).Err()
+	if err != nil {
+		ar.Logger.Error("AuthRepositoryImpl.ClearAttempts Del ERROR, ", err)
+		return err
+	}
+
+	return nil
+}
+
+func (ar *AuthRepositoryImpl) SetAttemptsBlock(ctx context.Context, attemptType model.AttemptType, subject string, reason string, duration time.Duration) error {
+	tr := ar.Tracer.Tracer("Auth-SetAttemptsBlock repository")
+	ctx, span := tr.Start(ctx, "Start SetAttemptsBlock")
+	defer span.End()
+
+	err := ar.Redis.Set(ctx, fmt.Sprintf(model.AttemptsBlockKey, attemptType, subject), reason, duration).Err()
+	if err != nil {
+		ar.Logger.Error("AuthRepositoryImpl.SetAttemptsBlock Set ERROR, ", err)
+		return err
+	}
+
+	return nil
+}
+
+func (ar *AuthRepositoryImpl) GetAttemptsBlock(ctx context.Context, attemptType model.AttemptType, subject string) (*model.AttemptsBlock, error) {
+	tr := ar.Tracer.Tracer("Auth-GetAttemptsBlock repository")
+	ctx, span := tr.Start(ctx, "Start GetAttemptsBlock")
+	defer span.End()
+
+	blockKey := fmt.Sprintf(model.AttemptsBlockKey, attemptType, subject)
+
+	var (
+		reason *redis.StringCmd
+		ttl    *redis.DurationCmd
+	)
+
+	_, err := ar.Redis.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		reason = pipe.Get(ctx, blockKey)
+		ttl = pipe.PTTL(ctx, blockKey)
+
+		return nil
+	})
+	if err != nil {
+		if err == redis.Nil {
+			return nil, redis.Nil
+		}
+
+		ar.Logger.Error("AuthRepositoryImpl.GetAttemptsBlock TxPipelined ERROR, ", err)
+		return nil, err
+	}
+
+	return &model.AttemptsBlock{
+		Reason:     reason.Val(),
+		RetryAfter: ttl.Val(),
+	}, nil
+}
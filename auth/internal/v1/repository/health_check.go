@@ -13,7 +13,8 @@ import (
 type (
 	// HealthCheckRepository is an interface that has all the function to be implemented inside health check repository
 	HealthCheckRepository interface {
-		Check() (bool, error)
+		PingDB(ctx context.Context) error
+		PingRedis(ctx context.Context) error
 	}
 
 	// HealthCheckRepositoryImpl is an app health check struct that consists of all the dependencies needed for health check repository
@@ -39,20 +40,28 @@ func NewHealthCheckRepository(ctx context.Context, config *config.Configuration,
 	}
 }
 
-func (hr *HealthCheckRepositoryImpl) Check() (bool, error) {
-	tr := hr.Tracer.Tracer("Auth-Check Repository")
-	_, span := tr.Start(hr.Context, "Start Check")
+func (hr *HealthCheckRepositoryImpl) PingDB(ctx context.Context) error {
+	tr := hr.Tracer.Tracer("Auth-PingDB Repository")
+	ctx, span := tr.Start(ctx, "Start PingDB")
 	defer span.End()
 
-	if err := hr.DB.Client().Ping(hr.Context, nil); err != nil {
-		hr.Logger.Error("HealthCheckRepositoryImpl.Check() Ping DB ERROR, ", err)
-		return false, nil
+	if err := hr.DB.Client().Ping(ctx, nil); err != nil {
+		hr.Logger.Error("HealthCheckRepositoryImpl.PingDB ERROR, ", err)
+		return err
 	}
 
-	if err := hr.Redis.Ping(hr.Context).Err(); err != nil {
-		hr.Logger.Error("HealthCheckRepositoryImpl.Check() Ping Redis ERROR, ", err)
-		return false, nil
+	return nil
+}
+
+func (hr *HealthCheckRepositoryImpl) PingRedis(ctx context.Context) error {
+	tr := hr.Tracer.Tracer("Auth-PingRedis Repository")
+	ctx, span := tr.Start(ctx, "Start PingRedis")
+	defer span.End()
+
+	if err := hr.Redis.Ping(ctx).Err(); err != nil {
+		hr.Logger.Error("HealthCheckRepositoryImpl.PingRedis ERROR, ", err)
+		return err
 	}
 
-	return true, nil
+	return nil
 }
@@ -0,0 +1,85 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/PickHD/singkatin-revamp/auth/internal/v1/config"
+	"github.com/PickHD/singkatin-revamp/auth/internal/v1/model"
+	"github.com/sirupsen/logrus"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.opentelemetry.io/otel/sdk/trace"
+)
+
+type (
+	// KeyRepository is an interface that has all the function to be implemented inside key repository
+	KeyRepository interface {
+		FindSigningKeys(ctx context.Context) ([]model.SigningKey, error)
+		CreateSigningKey(ctx context.Context, req *model.SigningKey) error
+	}
+
+	// KeyRepositoryImpl is an app key struct that consists of all the dependencies needed for key repository
+	KeyRepositoryImpl struct {
+		Context context.Context
+		Config  *config.Configuration
+		Logger  *logrus.Logger
+		Tracer  *trace.TracerProvider
+		DB      *mongo.Database
+	}
+)
+
+// NewKeyRepository return new instances key repository
+func NewKeyRepository(ctx context.Context, config *config.Configuration, logger *logrus.Logger, tracer *trace.TracerProvider, db *mongo.Database) *KeyRepositoryImpl {
+	return &KeyRepositoryImpl{
+		Context: ctx,
+		Config:  config,
+		Logger:  logger,
+		Tracer:  tracer,
+		DB:      db,
+	}
+}
+
+func (kr *KeyRepositoryImpl) FindSigningKeys(ctx context.Context) ([]model.SigningKey, error) {
+	tr := kr.Tracer.Tracer("Auth-FindSigningKeys repository")
+	ctx, span := tr.Start(ctx, "Start FindSigningKeys")
+	defer span.End()
+
+	keys := []model.SigningKey{}
+
+	cur, err := kr.DB.Collection(kr.Config.Database.SigningKeysCollection).Find(ctx,
+		bson.D{{Key: "expire_at", Value: bson.D{{Key: "$gt", Value: time.Now()}}}},
+		options.Find().SetSort(bson.D{{Key: "activate_at", Value: 1}}))
+	if err != nil {
+		kr.Logger.Error("KeyRepositoryImpl.FindSigningKeys Find ERROR, ", err)
+		return nil, err
+	}
+
+	err = cur.All(ctx, &keys)
+	if err != nil {
+		kr.Logger.Error("KeyRepositoryImpl.FindSigningKeys All ERROR, ", err)
+		return nil, err
+	}
+
+	return keys, nil
+}
+
+func (kr *KeyRepositoryImpl) CreateSigningKey(ctx context.Context, req *model.SigningKey) error {
+	tr := kr.Tracer.Tracer("Auth-CreateSigningKey repository")
+	ctx, span := tr.Start(ctx, "Start CreateSigningKey")
+	defer span.End()
+
+	_, err := kr.DB.Collection(kr.Config.Database.SigningKeysCollection).InsertOne(ctx, req)
+	if err != nil {
+		// activate_at is unique, another instance already scheduled key of the same period
+		if mongo.IsDuplicateKeyError(err) {
+			return nil
+		}
+
+		kr.Logger.Error("KeyRepositoryImpl.CreateSigningKey InsertOne ERROR, ", err)
+		return err
+	}
+
+	return nil
+}
@@ -3,6 +3,9 @@ package service
 import (
 	"context"
 	"fmt"
+	"math"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/PickHD/singkatin-revamp/auth/internal/v1/config"
@@ -10,12 +13,39 @@ import (
 	"github.com/PickHD/singkatin-revamp/auth/internal/v1/model"
 	"github.com/PickHD/singkatin-revamp/auth/internal/v1/repository"
 	"github.com/golang-jwt/jwt"
+	"github.com/pquerna/otp/totp"
 	"github.com/redis/go-redis/v9"
 	"github.com/sirupsen/logrus"
 	"go.opentelemetry.io/otel/sdk/trace"
 	"gopkg.in/gomail.v2"
 )
 
+const (
+	// refreshTokenBytes & refreshTokenFamilyBytes are random bytes length of generated refresh token & its family id
+	refreshTokenBytes       = 32
+	refreshTokenFamilyBytes = 16
+
+	// accessTokenIDBytes is random bytes length of access token jti, used to revoke single access token
+	accessTokenIDBytes = 16
+
+	// mfaTokenBytes is random bytes length of MFA challenge token, mfaMaxAttempts is codes allowed to be tried per challenge
+	mfaTokenBytes  = 32
+	mfaMaxAttempts = 5
+
+	// backupCodeCount is number of one time backup codes generated when 2FA enabled
+	backupCodeCount = 10
+
+	// totpUsedCodeExpire cover every time step a TOTP code accepted, so code can't be replayed
+	totpUsedCodeExpire = 90 * time.Second
+
+	// attemptSubjectEmail & attemptSubjectIP are prefix of subjects attempts counted by
+	attemptSubjectEmail = "email"
+	attemptSubjectIP    = "ip"
+
+	// maxDelayShift bound doubling of progressive delay, so it can't overflow before capped by lockout
+	maxDelayShift = 20
+)
+
 type (
 	// AuthService is an interface that has all the function to be implemented inside auth service
 	AuthService interface {
@@ -24,6 +54,14 @@ type (
 		VerifyCode(ctx context.Context, code string, verificationType model.VerificationType) (*model.VerifyCodeResponse, error)
 		ForgotPasswordUser(ctx context.Context, req *model.ForgotPasswordRequest) error
 		ResetPasswordUser(ctx context.Context, req *model.ResetPasswordRequest, code string) error
+		RefreshToken(ctx context.Context, req *model.RefreshTokenRequest) (*model.LoginResponse, error)
+		Logout(ctx context.Context, claims *model.AccessClaims) error
+		LogoutAll(ctx context.Context, claims *model.AccessClaims) error
+		LoginMFA(ctx context.Context, req *model.MFALoginRequest) (*model.LoginResponse, error)
+		EnrollTOTP(ctx context.Context, claims *model.AccessClaims) (*model.TOTPEnrolmentResponse, error)
+		ConfirmTOTP(ctx context.Context, claims *model.AccessClaims, req *model.TOTPConfirmRequest) (*model.TOTPConfirmResponse, error)
+		ResetTOTP(ctx context.Context, claims *model.AccessClaims, req *model.PasswordConfirmRequest) (*model.TOTPEnrolmentResponse, error)
+		DisableTOTP(ctx context.Context, claims *model.AccessClaims, req *model.PasswordConfirmRequest) error
 	}
 
 	// AuthServiceImpl is an app auth struct that consists of all the dependencies needed for auth service
@@ -34,11 +72,12 @@ type (
 		Tracer   *trace.TracerProvider
 		Mailer   *gomail.Dialer
 		AuthRepo repository.AuthRepository
+		KeySvc   KeyService
 	}
 )
 
 // NewAuthService return new instances auth service
-func NewAuthService(ctx context.Context, config *config.Configuration, logger *logrus.Logger, tracer *trace.TracerProvider, mailer *gomail.Dialer, authRepo repository.AuthRepository) *AuthServiceImpl {
+func NewAuthService(ctx context.Context, config *config.Configuration, logger *logrus.Logger, tracer *trace.TracerProvider, mailer *gomail.Dialer, authRepo repository.AuthRepository, keySvc KeyService) *AuthServiceImpl {
 	return &AuthServiceImpl{
 		Context:  ctx,
 		Config:   config,
@@ -46,6 +85,7 @@ func NewAuthService(ctx context.Context, config *config.Configuration, logger *l
 		Tracer:   tracer,
 		Mailer:   mailer,
 		AuthRepo: authRepo,
+		KeySvc:   keySvc,
 	}
 }
 
@@ -90,6 +130,8 @@ func (as *AuthServiceImpl) RegisterUser(ctx context.Context, req *model.Register
 		return nil, err
 	}
 
+	helper.Registrations.Inc()
+
 	return &model.RegisterResponse{
 		ID:         data.ID.Hex(),
 		Email:      data.Email,
@@ -107,27 +149,121 @@ func (as *AuthServiceImpl) LoginUser(ctx context.Context, req *model.LoginReques
 		return nil, err
 	}
 
+	err = as.checkAttempts(ctx, model.LoginAttempt, req.Email, req.ClientIP)
+	if err != nil {
+		helper.Logins.WithLabelValues(helper.LoginBlocked).Inc()
+		return nil, err
+	}
+
 	user, err := as.AuthRepo.FindByEmail(ctx, req.Email)
 	if err != nil {
+		// unknown email counted as well, so existing users can't be told apart by lockouts
+		if model.IsKind(err, model.NotFound) {
+			return nil, as.failLogin(ctx, req.Email, req.ClientIP, nil, err)
+		}
+
+		helper.Logins.WithLabelValues(helper.LoginFailed).Inc()
 		return nil, err
 	}
 
 	// verify user password by comparing incoming request password with crypted password stored in database
 	if !helper.CheckPasswordHash(user.Password, req.Password) {
-		return nil, model.NewError(model.Validation, "invalid password")
+		return nil, as.failLogin(ctx, req.Email, req.ClientIP, user, model.NewError(model.Validation, "invalid password"))
+	}
+
+	// second step required, tokens only issued after completing login with TOTP / backup code
+	if user.TOTPEnabled {
+		data, err := as.createMFAChallenge(ctx, user)
+		if err != nil {
+			return nil, err
+		}
+
+		helper.Logins.WithLabelValues(helper.LoginMFARequired).Inc()
+
+		return data, nil
 	}
 
-	// generate access token jwt
-	token, expiredAt, err := as.generateJWT(user)
+	data, err := as.startSession(ctx, user)
 	if err != nil {
 		return nil, err
 	}
 
-	return &model.LoginResponse{
-		AccessToken: token,
-		Type:        "Bearer",
-		ExpireAt:    time.Now().Add(expiredAt),
-	}, nil
+	err = as.AuthRepo.ClearAttempts(ctx, model.LoginAttempt, attemptSubject(attemptSubjectEmail, user.Email))
+	if err != nil {
+		return nil, err
+	}
+
+	helper.Logins.WithLabelValues(helper.LoginSucceeded).Inc()
+
+	return data, nil
+}
+
+func (as *AuthServiceImpl) LoginMFA(ctx context.Context, req *model.MFALoginRequest) (*model.LoginResponse, error) {
+	tr := as.Tracer.Tracer("Auth-LoginMFA service")
+	ctx, span := tr.Start(ctx, "Start LoginMFA")
+	defer span.End()
+
+	if req.MFAToken == "" || req.Code == "" {
+		return nil, model.NewError(model.Validation, "mfa token & code required")
+	}
+
+	tokenHash := helper.HashToken(req.MFAToken)
+
+	userID, attempts, err := as.AuthRepo.AttemptMFAChallenge(ctx, tokenHash)
+	if err != nil {
+		if err == redis.Nil {
+			return nil, model.NewError(model.Unauthorized, "mfa token invalid / expired")
+		}
+
+		return nil, err
+	}
+
+	// too many wrong codes, users have to login with password again
+	if attempts > mfaMaxAttempts {
+		err = as.AuthRepo.DeleteMFAChallenge(ctx, tokenHash)
+		if err != nil {
+			return nil, err
+		}
+
+		helper.Logins.WithLabelValues(helper.LoginFailed).Inc()
+		return nil, model.NewError(model.Unauthorized, "too many invalid codes, please login again")
+	}
+
+	user, err := as.AuthRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// wrong codes counted along with wrong passwords, so challenges can't be renewed to keep guessing codes
+	err = as.checkAttempts(ctx, model.LoginAttempt, user.Email, req.ClientIP)
+	if err != nil {
+		helper.Logins.WithLabelValues(helper.LoginBlocked).Inc()
+		return nil, err
+	}
+
+	err = as.verifySecondFactor(ctx, user, req.Code)
+	if err != nil {
+		return nil, as.failLogin(ctx, user.Email, req.ClientIP, user, err)
+	}
+
+	err = as.AuthRepo.DeleteMFAChallenge(ctx, tokenHash)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := as.startSession(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+
+	err = as.AuthRepo.ClearAttempts(ctx, model.LoginAttempt, attemptSubject(attemptSubjectEmail, user.Email))
+	if err != nil {
+		return nil, err
+	}
+
+	helper.Logins.WithLabelValues(helper.LoginSucceeded).Inc()
+
+	return data, nil
 }
 
 func (as *AuthServiceImpl) VerifyCode(ctx context.Context, code string, verificationType model.VerificationType) (*model.VerifyCodeResponse, error) {
@@ -167,7 +303,18 @@ func (as *AuthServiceImpl) ForgotPasswordUser(ctx context.Context, req *model.Fo
 		return model.NewError(model.Validation, "invalid email")
 	}
 
-	_, err := as.AuthRepo.FindByEmail(ctx, req.Email)
+	err := as.checkAttempts(ctx, model.ForgotPasswordAttempt, req.Email, req.ClientIP)
+	if err != nil {
+		return err
+	}
+
+	// every request counted, not just failed ones, so inbox of users can't be flooded with verification mails
+	_, err = as.recordAttempt(ctx, model.ForgotPasswordAttempt, req.Email, req.ClientIP)
+	if err != nil {
+		return err
+	}
+
+	_, err = as.AuthRepo.FindByEmail(ctx, req.Email)
 	if err != nil {
 		return err
 	}
@@ -214,6 +361,11 @@ func (as *AuthServiceImpl) ResetPasswordUser(ctx context.Context, req *model.Res
 		return err
 	}
 
+	user, err := as.AuthRepo.FindByEmail(ctx, getEmail)
+	if err != nil {
+		return err
+	}
+
 	hashedNewPassword, err := helper.HashPassword(req.NewPassword)
 	if err != nil {
 		return err
@@ -224,7 +376,225 @@ func (as *AuthServiceImpl) ResetPasswordUser(ctx context.Context, req *model.Res
 		return err
 	}
 
-	return nil
+	// sessions signed in with the old password may be the reason of reset, so all of them ended
+	return as.revokeSessions(ctx, user.ID.Hex())
+}
+
+func (as *AuthServiceImpl) RefreshToken(ctx context.Context, req *model.RefreshTokenRequest) (*model.LoginResponse, error) {
+	tr := as.Tracer.Tracer("Auth-RefreshToken service")
+	ctx, span := tr.Start(ctx, "Start RefreshToken")
+	defer span.End()
+
+	if req.RefreshToken == "" {
+		return nil, model.NewError(model.Validation, "refresh token required")
+	}
+
+	tokenHash := helper.HashToken(req.RefreshToken)
+
+	token, err := as.AuthRepo.GetRefreshToken(ctx, tokenHash)
+	if err != nil {
+		if err == redis.Nil {
+			helper.TokenRefreshes.WithLabelValues(helper.RefreshFailed).Inc()
+			return nil, model.NewError(model.Unauthorized, "refresh token invalid / expired")
+		}
+
+		return nil, err
+	}
+
+	// token already rotated, someone is replaying it. revoke the whole family so stolen chain unusable
+	if token.UsedAt != 0 {
+		return nil, as.revokeReusedFamily(ctx, token)
+	}
+
+	ok, err := as.AuthRepo.MarkRefreshTokenUsed(ctx, tokenHash)
+	if err != nil {
+		return nil, err
+	}
+
+	if !ok {
+		return nil, as.revokeReusedFamily(ctx, token)
+	}
+
+	user, err := as.AuthRepo.FindByID(ctx, token.UserID)
+	if err != nil {
+		helper.TokenRefreshes.WithLabelValues(helper.RefreshFailed).Inc()
+		return nil, err
+	}
+
+	data, err := as.issueTokens(ctx, user, token.FamilyID)
+	if err != nil {
+		return nil, err
+	}
+
+	helper.TokenRefreshes.WithLabelValues(helper.RefreshSucceeded).Inc()
+
+	return data, nil
+}
+
+func (as *AuthServiceImpl) Logout(ctx context.Context, claims *model.AccessClaims) error {
+	tr := as.Tracer.Tracer("Auth-Logout service")
+	ctx, span := tr.Start(ctx, "Start Logout")
+	defer span.End()
+
+	err := as.checkAccessTokenRevoked(ctx, claims)
+	if err != nil {
+		return err
+	}
+
+	// access token only need to stay in revocation list until it expired by itself
+	err = as.AuthRepo.RevokeAccessToken(ctx, claims.JTI, time.Until(time.Unix(claims.ExpiresAt, 0)))
+	if err != nil {
+		return err
+	}
+
+	// end the session by revoking refresh token family issued along with access token
+	return as.AuthRepo.RevokeRefreshTokenFamily(ctx, claims.SessionID)
+}
+
+func (as *AuthServiceImpl) LogoutAll(ctx context.Context, claims *model.AccessClaims) error {
+	tr := as.Tracer.Tracer("Auth-LogoutAll service")
+	ctx, span := tr.Start(ctx, "Start LogoutAll")
+	defer span.End()
+
+	err := as.checkAccessTokenRevoked(ctx, claims)
+	if err != nil {
+		return err
+	}
+
+	return as.revokeSessions(ctx, claims.UserID)
+}
+
+// revokeSessions will revoking every access & refresh token issued to users so far
+func (as *AuthServiceImpl) revokeSessions(ctx context.Context, userID string) error {
+	// iat only has seconds precision, so tokens issued within current second revoked as well
+	accessExpire := time.Duration(as.Config.Common.AccessTokenExpire) * time.Minute
+
+	err := as.AuthRepo.RevokeAccessTokensByUserID(ctx, userID, time.Now().Unix()+1, accessExpire)
+	if err != nil {
+		return err
+	}
+
+	return as.AuthRepo.RevokeRefreshTokenFamiliesByUserID(ctx, userID)
+}
+
+func (as *AuthServiceImpl) EnrollTOTP(ctx context.Context, claims *model.AccessClaims) (*model.TOTPEnrolmentResponse, error) {
+	tr := as.Tracer.Tracer("Auth-EnrollTOTP service")
+	ctx, span := tr.Start(ctx, "Start EnrollTOTP")
+	defer span.End()
+
+	user, err := as.authenticatedUser(ctx, claims)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.TOTPEnabled {
+		return nil, model.NewError(model.Validation, "2FA already enabled, reset it to enroll new authenticator")
+	}
+
+	return as.createTOTPEnrolment(ctx, user)
+}
+
+func (as *AuthServiceImpl) ConfirmTOTP(ctx context.Context, claims *model.AccessClaims, req *model.TOTPConfirmRequest) (*model.TOTPConfirmResponse, error) {
+	tr := as.Tracer.Tracer("Auth-ConfirmTOTP service")
+	ctx, span := tr.Start(ctx, "Start ConfirmTOTP")
+	defer span.End()
+
+	user, err := as.authenticatedUser(ctx, claims)
+	if err != nil {
+		return nil, err
+	}
+
+	secret, err := as.AuthRepo.GetTOTPEnrolment(ctx, claims.UserID)
+	if err != nil {
+		if err == redis.Nil {
+			return nil, model.NewError(model.NotFound, "2FA enrolment not found / expired")
+		}
+
+		return nil, err
+	}
+
+	if !totp.Validate(helper.NormalizeCode(req.Code), secret) {
+		return nil, model.NewError(model.Validation, "invalid code")
+	}
+
+	// only hashes stored, plain backup codes responded once
+	backupCodes := make([]string, 0, backupCodeCount)
+	backupCodeHashes := make([]string, 0, backupCodeCount)
+	for i := 0; i < backupCodeCount; i++ {
+		code, err := helper.GenerateBackupCode()
+		if err != nil {
+			return nil, err
+		}
+
+		backupCodes = append(backupCodes, code)
+		backupCodeHashes = append(backupCodeHashes, helper.HashToken(helper.NormalizeCode(code)))
+	}
+
+	err = as.AuthRepo.EnableTOTPByID(ctx, user.ID, secret, backupCodeHashes)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.TOTPConfirmResponse{
+		BackupCodes: backupCodes,
+	}, nil
+}
+
+func (as *AuthServiceImpl) ResetTOTP(ctx context.Context, claims *model.AccessClaims, req *model.PasswordConfirmRequest) (*model.TOTPEnrolmentResponse, error) {
+	tr := as.Tracer.Tracer("Auth-ResetTOTP service")
+	ctx, span := tr.Start(ctx, "Start ResetTOTP")
+	defer span.End()
+
+	user, err := as.authenticatedUser(ctx, claims)
+	if err != nil {
+		return nil, err
+	}
+
+	err = as.confirmPassword(ctx, user, req)
+	if err != nil {
+		return nil, err
+	}
+
+	// current authenticator & backup codes stay valid until new enrolment confirmed
+	return as.createTOTPEnrolment(ctx, user)
+}
+
+func (as *AuthServiceImpl) DisableTOTP(ctx context.Context, claims *model.AccessClaims, req *model.PasswordConfirmRequest) error {
+	tr := as.Tracer.Tracer("Auth-DisableTOTP service")
+	ctx, span := tr.Start(ctx, "Start DisableTOTP")
+	defer span.End()
+
+	user, err := as.authenticatedUser(ctx, claims)
+	if err != nil {
+		return err
+	}
+
+	if !user.TOTPEnabled {
+		return model.NewError(model.Validation, "2FA not enabled")
+	}
+
+	err = as.confirmPassword(ctx, user, req)
+	if err != nil {
+		return err
+	}
+
+	return as.AuthRepo.DisableTOTPByID(ctx, user.ID)
+}
+
+// confirmPassword re-verifying password of signed in users, failures counted along with login attempts
+// so stolen access tokens can't be used guessing the password
+func (as *AuthServiceImpl) confirmPassword(ctx context.Context, user *model.User, req *model.PasswordConfirmRequest) error {
+	err := as.checkAttempts(ctx, model.LoginAttempt, user.Email, req.ClientIP)
+	if err != nil {
+		helper.Logins.WithLabelValues(helper.LoginBlocked).Inc()
+		return err
+	}
+
+	if !helper.CheckPasswordHash(user.Password, req.Password) {
+		return as.failLogin(ctx, user.Email, req.ClientIP, user, model.NewError(model.Validation, "invalid password"))
+	}
+
+	return as.AuthRepo.ClearAttempts(ctx, model.LoginAttempt, attemptSubject(attemptSubjectEmail, user.Email))
 }
 
 func validateRegisterUser(req *model.RegisterRequest) error {
@@ -260,24 +630,206 @@ func validateLoginUser(req *model.LoginRequest) error {
 	return nil
 }
 
-func (as *AuthServiceImpl) generateJWT(user *model.User) (string, time.Duration, error) {
+// startSession start new refresh token family & issue its first tokens
+func (as *AuthServiceImpl) startSession(ctx context.Context, user *model.User) (*model.LoginResponse, error) {
+	familyID, err := helper.GenerateToken(refreshTokenFamilyBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	return as.issueTokens(ctx, user, familyID)
+}
+
+// createMFAChallenge generate short lived token identifying users passed password check, stored hashed
+func (as *AuthServiceImpl) createMFAChallenge(ctx context.Context, user *model.User) (*model.LoginResponse, error) {
+	mfaToken, err := helper.GenerateToken(mfaTokenBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	mfaExpire := time.Duration(as.Config.TOTP.ChallengeExpire) * time.Minute
+
+	err = as.AuthRepo.SetMFAChallenge(ctx, helper.HashToken(mfaToken), user.ID.Hex(), mfaExpire)
+	if err != nil {
+		return nil, err
+	}
+
+	expireAt := time.Now().Add(mfaExpire)
+
+	return &model.LoginResponse{
+		MFARequired:  true,
+		MFAToken:     mfaToken,
+		MFAExpiredAt: &expireAt,
+	}, nil
+}
+
+// verifySecondFactor accept either TOTP code not used yet or unused backup code
+func (as *AuthServiceImpl) verifySecondFactor(ctx context.Context, user *model.User, code string) error {
+	code = helper.NormalizeCode(code)
+
+	if totp.Validate(code, user.TOTPSecret) {
+		ok, err := as.AuthRepo.MarkTOTPCodeUsed(ctx, user.ID.Hex(), code, totpUsedCodeExpire)
+		if err != nil {
+			return err
+		}
+
+		if !ok {
+			return model.NewError(model.Unauthorized, "code already used")
+		}
+
+		return nil
+	}
+
+	ok, err := as.AuthRepo.UseBackupCode(ctx, user.ID, helper.HashToken(code))
+	if err != nil {
+		return err
+	}
+
+	if !ok {
+		return model.NewError(model.Unauthorized, "invalid code")
+	}
+
+	return nil
+}
+
+// createTOTPEnrolment generate new TOTP secret pending until confirmed with its first code
+func (as *AuthServiceImpl) createTOTPEnrolment(ctx context.Context, user *model.User) (*model.TOTPEnrolmentResponse, error) {
+	key, err := totp.Generate(totp.GenerateOpts{
+		Issuer:      as.Config.TOTP.Issuer,
+		AccountName: user.Email,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	qrCode, err := helper.QRCodeDataURI(key.URL())
+	if err != nil {
+		return nil, err
+	}
+
+	enrolmentExpire := time.Duration(as.Config.TOTP.EnrolmentExpire) * time.Minute
+
+	err = as.AuthRepo.SetTOTPEnrolment(ctx, user.ID.Hex(), key.Secret(), enrolmentExpire)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.TOTPEnrolmentResponse{
+		Secret:     key.Secret(),
+		OTPAuthURI: key.URL(),
+		QRCode:     qrCode,
+		ExpireAt:   time.Now().Add(enrolmentExpire),
+	}, nil
+}
+
+// authenticatedUser return users of access token not revoked yet
+func (as *AuthServiceImpl) authenticatedUser(ctx context.Context, claims *model.AccessClaims) (*model.User, error) {
+	err := as.checkAccessTokenRevoked(ctx, claims)
+	if err != nil {
+		return nil, err
+	}
+
+	return as.AuthRepo.FindByID(ctx, claims.UserID)
+}
+
+// issueTokens generate short lived access token & new refresh token belongs to family, stored hashed
+func (as *AuthServiceImpl) issueTokens(ctx context.Context, user *model.User, familyID string) (*model.LoginResponse, error) {
+	accessToken, accessExpire, err := as.generateJWT(ctx, user, familyID)
+	if err != nil {
+		return nil, err
+	}
+
+	refreshToken, err := helper.GenerateToken(refreshTokenBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	refreshExpire := time.Duration(as.Config.Common.RefreshTokenExpire) * time.Hour
+
+	err = as.AuthRepo.SetRefreshToken(ctx, helper.HashToken(refreshToken), &model.RefreshToken{
+		UserID:   user.ID.Hex(),
+		FamilyID: familyID,
+	}, refreshExpire)
+	if err != nil {
+		return nil, err
+	}
+
 	var (
-		payloadUserID   = "user_id"
-		payloadFullName = "full_name"
-		payloadEmail    = "email"
-		payloadExpires  = "exp"
-		JWTExpire       = time.Duration(as.Config.Common.JWTExpire) * time.Hour
+		now                   = time.Now()
+		expireAt              = now.Add(accessExpire)
+		refreshTokenExpiredAt = now.Add(refreshExpire)
 	)
 
+	return &model.LoginResponse{
+		AccessToken:           accessToken,
+		Type:                  "Bearer",
+		ExpireAt:              &expireAt,
+		RefreshToken:          refreshToken,
+		RefreshTokenExpiredAt: &refreshTokenExpiredAt,
+	}, nil
+}
+
+// revokeReusedFamily revoke every refresh token of family when reuse detected
+func (as *AuthServiceImpl) revokeReusedFamily(ctx context.Context, token *model.RefreshToken) error {
+	helper.TokenRefreshes.WithLabelValues(helper.RefreshReused).Inc()
+	as.Logger.Warnf("refresh token reuse detected for user %s, revoking token family", token.UserID)
+
+	err := as.AuthRepo.RevokeRefreshTokenFamily(ctx, token.FamilyID)
+	if err != nil {
+		return err
+	}
+
+	return &model.Error{
+		Kind:    model.Unauthorized,
+		Code:    model.CodeRefreshTokenReused,
+		Message: "refresh token already used, please login again",
+	}
+}
+
+// checkAccessTokenRevoked return unauthorized error when access token already revoked
+func (as *AuthServiceImpl) checkAccessTokenRevoked(ctx context.Context, claims *model.AccessClaims) error {
+	revoked, err := as.AuthRepo.IsAccessTokenRevoked(ctx, claims)
+	if err != nil {
+		return err
+	}
+
+	if revoked {
+		return model.NewError(model.Unauthorized, "token revoked")
+	}
+
+	return nil
+}
+
+func (as *AuthServiceImpl) generateJWT(ctx context.Context, user *model.User, sessionID string) (string, time.Duration, error) {
+	var (
+		payloadUserID    = "user_id"
+		payloadFullName  = "full_name"
+		payloadEmail     = "email"
+		payloadJTI       = "jti"
+		payloadSessionID = "sid"
+		payloadIssuedAt  = "iat"
+		payloadExpires   = "exp"
+		JWTExpire        = time.Duration(as.Config.Common.AccessTokenExpire) * time.Minute
+	)
+
+	jti, err := helper.GenerateToken(accessTokenIDBytes)
+	if err != nil {
+		return "", 0, err
+	}
+
+	now := time.Now()
+
 	claims := jwt.MapClaims{}
 	claims[payloadUserID] = user.ID.Hex()
 	claims[payloadFullName] = user.FullName
 	claims[payloadEmail] = user.Email
-	claims[payloadExpires] = time.Now().Add(JWTExpire).Unix()
+	claims[payloadJTI] = jti
+	claims[payloadSessionID] = sessionID
+	claims[payloadIssuedAt] = now.Unix()
+	claims[payloadExpires] = now.Add(JWTExpire).Unix()
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-
-	signedToken, err := token.SignedString([]byte(as.Config.Secret.JWTSecret))
+	// signed by currently active rotated key, verifiers pick its public key by kid header
+	signedToken, err := as.KeySvc.SignToken(ctx, claims)
 	if err != nil {
 		return "", 0, err
 	}
@@ -285,6 +837,163 @@ func (as *AuthServiceImpl) generateJWT(user *model.User) (string, time.Duration,
 	return signedToken, JWTExpire, nil
 }
 
+// checkAttempts reject attempts of email & client IP still blocked by previous attempts
+func (as *AuthServiceImpl) checkAttempts(ctx context.Context, attemptType model.AttemptType, email string, clientIP string) error {
+	subjects := []string{attemptSubject(attemptSubjectEmail, email)}
+	if clientIP != "" {
+		subjects = append(subjects, attemptSubject(attemptSubjectIP, clientIP))
+	}
+
+	for _, subject := range subjects {
+		block, err := as.AuthRepo.GetAttemptsBlock(ctx, attemptType, subject)
+		if err != nil {
+			if err == redis.Nil {
+				continue
+			}
+
+			return err
+		}
+
+		return attemptsBlockedError(block)
+	}
+
+	return nil
+}
+
+// recordAttempt count attempt of email & client IP within sliding window, delaying next attempts progressively
+// & locking them out once too many counted. Block of email returned when applied by this attempt
+func (as *AuthServiceImpl) recordAttempt(ctx context.Context, attemptType model.AttemptType, email string, clientIP string) (*model.AttemptsBlock, error) {
+	cfg := as.Config.BruteForce
+	window := time.Duration(cfg.Window) * time.Minute
+
+	// forgot password requests delayed right away, every one of them sent a mail
+	delayAfter, maxAttempts := cfg.DelayAfter, cfg.MaxAttempts
+	if attemptType == model.ForgotPasswordAttempt {
+		delayAfter, maxAttempts = 1, cfg.ForgotPasswordMaxAttempts
+	}
+
+	var emailBlock *model.AttemptsBlock
+
+	for _, scope := range []struct {
+		name        string
+		value       string
+		maxAttempts int
+	}{
+		{name: attemptSubjectEmail, value: email, maxAttempts: maxAttempts},
+		{name: attemptSubjectIP, value: clientIP, maxAttempts: cfg.MaxAttemptsIP},
+	} {
+		if scope.value == "" {
+			continue
+		}
+
+		subject := attemptSubject(scope.name, scope.value)
+
+		count, err := as.AuthRepo.RecordAttempt(ctx, attemptType, subject, window)
+		if err != nil {
+			return nil, err
+		}
+
+		block := as.attemptsBlock(count, delayAfter, scope.maxAttempts)
+		if block == nil {
+			continue
+		}
+
+		err = as.AuthRepo.SetAttemptsBlock(ctx, attemptType, subject, block.Reason, block.RetryAfter)
+		if err != nil {
+			return nil, err
+		}
+
+		if block.Reason == model.AttemptsLocked {
+			helper.AttemptLockouts.WithLabelValues(string(attemptType), scope.name).Inc()
+		}
+
+		if scope.name == attemptSubjectEmail {
+			emailBlock = block
+		}
+	}
+
+	return emailBlock, nil
+}
+
+// attemptsBlock return block applied after count attempts, delay doubled every attempt after delayAfter
+// until locked out at maxAttempts. Attempts below delayAfter not blocked
+func (as *AuthServiceImpl) attemptsBlock(count int64, delayAfter int, maxAttempts int) *model.AttemptsBlock {
+	cfg := as.Config.BruteForce
+	lockout := time.Duration(cfg.Lockout) * time.Minute
+
+	if maxAttempts > 0 && count >= int64(maxAttempts) {
+		return &model.AttemptsBlock{Reason: model.AttemptsLocked, RetryAfter: lockout}
+	}
+
+	if delayAfter <= 0 || count < int64(delayAfter) || cfg.BaseDelay <= 0 {
+		return nil
+	}
+
+	shift := count - int64(delayAfter)
+	if shift > maxDelayShift {
+		shift = maxDelayShift
+	}
+
+	delay := time.Duration(cfg.BaseDelay) * time.Second << shift
+	if lockout > 0 && delay > lockout {
+		delay = lockout
+	}
+
+	return &model.AttemptsBlock{Reason: model.AttemptsDelayed, RetryAfter: delay}
+}
+
+// failLogin record failed login attempt, responding lockout instead of cause once email locked out by it
+// & notifying users by email, so they know somebody trying to get into their account
+func (as *AuthServiceImpl) failLogin(ctx context.Context, email string, clientIP string, user *model.User, cause error) error {
+	helper.Logins.WithLabelValues(helper.LoginFailed).Inc()
+
+	block, err := as.recordAttempt(ctx, model.LoginAttempt, email, clientIP)
+	if err != nil {
+		return err
+	}
+
+	if block == nil || block.Reason != model.AttemptsLocked {
+		return cause
+	}
+
+	if user != nil {
+		body := fmt.Sprintf("<h1>Your account temporarily locked for %d minutes after too many failed login attempts</h1><p>If it wasn't you, please reset your password once lockout ended.</p>", as.Config.BruteForce.Lockout)
+
+		err = as.sendMail(as.Config.Mailer.Sender, []string{user.Email}, user.Email, "Account Locked", "Your Account Temporarily Locked", body)
+		if err != nil {
+			as.Logger.Error("AuthServiceImpl.failLogin sendMail ERROR, ", err)
+		}
+	}
+
+	return attemptsBlockedError(block)
+}
+
+// attemptSubject return subject attempts counted by, values lowered so casing of email can't bypass counters
+func attemptSubject(name string, value string) string {
+	return name + ":" + strings.ToLower(strings.TrimSpace(value))
+}
+
+// attemptsBlockedError return error of attempts blocked, telling how long until allowed again
+func attemptsBlockedError(block *model.AttemptsBlock) error {
+	retryAfter := strconv.FormatInt(int64(math.Ceil(block.RetryAfter.Seconds())), 10)
+
+	if block.Reason == model.AttemptsLocked {
+		return &model.Error{
+			Kind:    model.TooManyRequests,
+			Code:    model.CodeAccountLocked,
+			Message: "too many failed attempts, temporarily locked, please retry after " + retryAfter + " seconds",
+			Details: map[string]string{model.DetailRetryAfter: retryAfter},
+		}
+	}
+
+	return &model.Error{
+		Kind:    model.TooManyRequests,
+		Code:    model.CodeAttemptsDelayed,
+		Message: "too many attempts, please retry after " + retryAfter + " seconds",
+		Details: map[string]string{model.DetailRetryAfter: retryAfter},
+	}
+}
+
 func (as *AuthServiceImpl) sendMail(from string, to []string, cc string, ccTitle string, subject string, body string) error {
 	mailer := gomail.NewMessage()
 	mailer.SetHeader("From", from)
@@ -0,0 +1,409 @@
+package service
+
+import (
+	"context"
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/PickHD/singkatin-revamp/auth/internal/v1/config"
+	"github.com/PickHD/singkatin-revamp/auth/internal/v1/helper"
+	"github.com/PickHD/singkatin-revamp/auth/internal/v1/model"
+	"github.com/PickHD/singkatin-revamp/auth/internal/v1/repository"
+	"github.com/golang-jwt/jwt"
+	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/sdk/trace"
+)
+
+const (
+	// defaultKeyRotationInterval (hours) is period each signing key used when not configured
+	defaultKeyRotationInterval = 24
+
+	// keyCacheTTL is how long signing keys loaded from database reused, so keys rotated by other instances picked up
+	keyCacheTTL = time.Minute
+
+	// keyMinRefreshInterval is minimum delay between reloading keys for unknown kid, so tokens of made up kid
+	// can't flood database
+	keyMinRefreshInterval = 10 * time.Second
+
+	// keyEncryptionKeyBytes is size of AES-256 key encrypting stored private keys
+	keyEncryptionKeyBytes = 32
+
+	// keyExpireLeeway keep retired key published a while after the last token signed by it expired
+	keyExpireLeeway = 5 * time.Minute
+
+	// rsaKeyBits is size of generated RS256 keys
+	rsaKeyBits = 2048
+
+	// keyIDBytes is random bytes length of generated key id
+	keyIDBytes = 12
+)
+
+type (
+	// KeyService is an interface that has all the function to be implemented inside key service
+	KeyService interface {
+		RotateKeys(ctx context.Context) error
+		SignToken(ctx context.Context, claims jwt.MapClaims) (string, error)
+		VerificationKey(token *jwt.Token) (interface{}, error)
+		JWKS(ctx context.Context) (*model.JWKS, error)
+	}
+
+	// KeyServiceImpl is an app key struct that consists of all the dependencies needed for key service
+	KeyServiceImpl struct {
+		Context context.Context
+		Config  *config.Configuration
+		Logger  *logrus.Logger
+		Tracer  *trace.TracerProvider
+		KeyRepo repository.KeyRepository
+
+		mu          sync.RWMutex
+		keys        []parsedKey
+		loadedAt    time.Time
+		refreshedAt time.Time
+	}
+
+	// parsedKey is signing key along with its parsed key pair
+	parsedKey struct {
+		model.SigningKey
+		method  jwt.SigningMethod
+		private crypto.PrivateKey
+		public  crypto.PublicKey
+	}
+)
+
+// NewKeyService return new instances key service
+func NewKeyService(ctx context.Context, config *config.Configuration, logger *logrus.Logger, tracer *trace.TracerProvider, keyRepo repository.KeyRepository) *KeyServiceImpl {
+	return &KeyServiceImpl{
+		Context: ctx,
+		Config:  config,
+		Logger:  logger,
+		Tracer:  tracer,
+		KeyRepo: keyRepo,
+	}
+}
+
+func (ks *KeyServiceImpl) RotateKeys(ctx context.Context) error {
+	tr := ks.Tracer.Tracer("Auth-RotateKeys service")
+	ctx, span := tr.Start(ctx, "Start RotateKeys")
+	defer span.End()
+
+	keys, err := ks.loadKeys(ctx, true)
+	if err != nil {
+		return err
+	}
+
+	interval := ks.rotationInterval()
+	current := time.Now().Truncate(interval)
+
+	// key of next period scheduled as soon as current period started, so verifiers already know it
+	// long before it signing anything
+	for _, activateAt := range []time.Time{current, current.Add(interval)} {
+		if hasKeyActivateAt(keys, activateAt) {
+			continue
+		}
+
+		key, err := ks.generateKey(activateAt, interval)
+		if err != nil {
+			return err
+		}
+
+		err = ks.KeyRepo.CreateSigningKey(ctx, key)
+		if err != nil {
+			return err
+		}
+
+		ks.Logger.Info("scheduled signing key ", key.KID, " active at ", key.ActivateAt)
+	}
+
+	_, err = ks.loadKeys(ctx, true)
+	return err
+}
+
+func (ks *KeyServiceImpl) SignToken(ctx context.Context, claims jwt.MapClaims) (string, error) {
+	tr := ks.Tracer.Tracer("Auth-SignToken service")
+	ctx, span := tr.Start(ctx, "Start SignToken")
+	defer span.End()
+
+	key, err := ks.activeKey(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	token := jwt.NewWithClaims(key.method, claims)
+	token.Header["kid"] = key.KID
+
+	return token.SignedString(key.private)
+}
+
+func (ks *KeyServiceImpl) VerificationKey(token *jwt.Token) (interface{}, error) {
+	kid, ok := token.Header["kid"].(string)
+	if !ok {
+		return nil, model.NewError(model.Validation, "Invalid token, kid required")
+	}
+
+	key, err := ks.findKey(ks.Context, kid)
+	if err != nil {
+		return nil, err
+	}
+
+	if token.Method.Alg() != key.Algorithm {
+		return nil, model.NewError(model.Validation, "Invalid token")
+	}
+
+	return key.public, nil
+}
+
+func (ks *KeyServiceImpl) JWKS(ctx context.Context) (*model.JWKS, error) {
+	tr := ks.Tracer.Tracer("Auth-JWKS service")
+	ctx, span := tr.Start(ctx, "Start JWKS")
+	defer span.End()
+
+	keys, err := ks.loadKeys(ctx, false)
+	if err != nil {
+		return nil, err
+	}
+
+	jwks := &model.JWKS{Keys: make([]model.JWK, 0, len(keys))}
+
+	for _, key := range keys {
+		jwk := model.JWK{
+			KeyID:     key.KID,
+			Use:       "sig",
+			Algorithm: key.Algorithm,
+		}
+
+		switch public := key.public.(type) {
+		case *rsa.PublicKey:
+			jwk.KeyType = "RSA"
+			jwk.N = base64.RawURLEncoding.EncodeToString(public.N.Bytes())
+			jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(public.E)).Bytes())
+		case ed25519.PublicKey:
+			jwk.KeyType = "OKP"
+			jwk.Curve = "Ed25519"
+			jwk.X = base64.RawURLEncoding.EncodeToString(public)
+		}
+
+		jwks.Keys = append(jwks.Keys, jwk)
+	}
+
+	return jwks, nil
+}
+
+// activeKey return key scheduled to sign tokens right now, rotating keys when none scheduled yet
+func (ks *KeyServiceImpl) activeKey(ctx context.Context) (*parsedKey, error) {
+	for attempt := 0; attempt < 2; attempt++ {
+		keys, err := ks.loadKeys(ctx, attempt > 0)
+		if err != nil {
+			return nil, err
+		}
+
+		now := time.Now()
+		for i := range keys {
+			if !now.Before(keys[i].ActivateAt) && now.Before(keys[i].RetireAt) {
+				return &keys[i], nil
+			}
+		}
+
+		if attempt == 0 {
+			err = ks.RotateKeys(ctx)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return nil, model.NewError(model.Internal, "no active signing key")
+}
+
+// findKey return published key by its id, reloading keys once when not known yet at most every keyMinRefreshInterval
+func (ks *KeyServiceImpl) findKey(ctx context.Context, kid string) (*parsedKey, error) {
+	for attempt := 0; attempt < 2; attempt++ {
+		if attempt > 0 && !ks.refreshDue() {
+			break
+		}
+
+		keys, err := ks.loadKeys(ctx, attempt > 0)
+		if err != nil {
+			return nil, err
+		}
+
+		for i := range keys {
+			if keys[i].KID == kid {
+				return &keys[i], nil
+			}
+		}
+	}
+
+	return nil, model.NewError(model.Unauthorized, "unknown signing key")
+}
+
+// refreshDue reserving reload of keys for unknown kid, false when keys reloaded less than keyMinRefreshInterval ago
+func (ks *KeyServiceImpl) refreshDue() bool {
+	ks.mu.Lock()
+	defer ks.mu.Unlock()
+
+	if time.Since(ks.refreshedAt) < keyMinRefreshInterval {
+		return false
+	}
+
+	ks.refreshedAt = time.Now()
+
+	return true
+}
+
+// loadKeys return published signing keys, cached for keyCacheTTL unless forced
+func (ks *KeyServiceImpl) loadKeys(ctx context.Context, force bool) ([]parsedKey, error) {
+	ks.mu.RLock()
+	keys, loadedAt := ks.keys, ks.loadedAt
+	ks.mu.RUnlock()
+
+	if !force && time.Since(loadedAt) < keyCacheTTL {
+		return keys, nil
+	}
+
+	signingKeys, err := ks.KeyRepo.FindSigningKeys(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	kek, err := ks.keyEncryptionKey()
+	if err != nil {
+		return nil, err
+	}
+
+	keys = make([]parsedKey, 0, len(signingKeys))
+	for _, signingKey := range signingKeys {
+		key, err := parseSigningKey(kek, signingKey)
+		if err != nil {
+			ks.Logger.Error("KeyServiceImpl.loadKeys parse key ", signingKey.KID, " ERROR, ", err)
+			return nil, err
+		}
+
+		keys = append(keys, key)
+	}
+
+	ks.mu.Lock()
+	ks.keys, ks.loadedAt = keys, time.Now()
+	ks.mu.Unlock()
+
+	return keys, nil
+}
+
+// generateKey generate new key pair of configured algorithm, signing tokens for single rotation period
+func (ks *KeyServiceImpl) generateKey(activateAt time.Time, interval time.Duration) (*model.SigningKey, error) {
+	var (
+		private   crypto.PrivateKey
+		algorithm = ks.Config.Signing.Algorithm
+		err       error
+	)
+
+	switch algorithm {
+	case model.AlgorithmEdDSA:
+		_, private, err = ed25519.GenerateKey(rand.Reader)
+	case model.AlgorithmRS256, "":
+		algorithm = model.AlgorithmRS256
+		private, err = rsa.GenerateKey(rand.Reader, rsaKeyBits)
+	default:
+		return nil, model.NewError(model.Validation, "unsupported signing algorithm "+algorithm)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	der, err := x509.MarshalPKCS8PrivateKey(private)
+	if err != nil {
+		return nil, err
+	}
+
+	kid, err := helper.GenerateToken(keyIDBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	kek, err := ks.keyEncryptionKey()
+	if err != nil {
+		return nil, err
+	}
+
+	// private key only stored encrypted, so database dump alone can't forge tokens
+	privateKey, err := helper.Encrypt(kek, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}))
+	if err != nil {
+		return nil, err
+	}
+
+	// retired key stay published until every token signed by it expired
+	accessExpire := time.Duration(ks.Config.Common.AccessTokenExpire) * time.Minute
+	retireAt := activateAt.Add(interval)
+
+	return &model.SigningKey{
+		KID:        kid,
+		Algorithm:  algorithm,
+		PrivateKey: privateKey,
+		ActivateAt: activateAt,
+		RetireAt:   retireAt,
+		ExpireAt:   retireAt.Add(accessExpire + keyExpireLeeway),
+	}, nil
+}
+
+func (ks *KeyServiceImpl) rotationInterval() time.Duration {
+	interval := ks.Config.Signing.KeyRotationInterval
+	if interval <= 0 {
+		interval = defaultKeyRotationInterval
+	}
+
+	return time.Duration(interval) * time.Hour
+}
+
+// keyEncryptionKey return key encrypting stored private keys, decoded from configured base64
+func (ks *KeyServiceImpl) keyEncryptionKey() ([]byte, error) {
+	kek, err := base64.StdEncoding.DecodeString(ks.Config.Signing.KeyEncryptionKey)
+	if err != nil || len(kek) != keyEncryptionKeyBytes {
+		return nil, model.NewError(model.Internal, "JWT_KEY_ENCRYPTION_KEY must be base64 encoded 32 bytes key")
+	}
+
+	return kek, nil
+}
+
+// parseSigningKey decrypt & parse stored PEM private key of signing key
+func parseSigningKey(kek []byte, key model.SigningKey) (parsedKey, error) {
+	privateKey, err := helper.Decrypt(kek, key.PrivateKey)
+	if err != nil {
+		return parsedKey{}, err
+	}
+
+	block, _ := pem.Decode(privateKey)
+	if block == nil {
+		return parsedKey{}, model.NewError(model.Type, "invalid PEM private key")
+	}
+
+	private, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return parsedKey{}, err
+	}
+
+	switch private := private.(type) {
+	case *rsa.PrivateKey:
+		return parsedKey{SigningKey: key, method: jwt.SigningMethodRS256, private: private, public: &private.PublicKey}, nil
+	case ed25519.PrivateKey:
+		return parsedKey{SigningKey: key, method: jwt.SigningMethodEdDSA, private: private, public: private.Public()}, nil
+	}
+
+	return parsedKey{}, model.NewError(model.Type, "unsupported private key type")
+}
+
+func hasKeyActivateAt(keys []parsedKey, activateAt time.Time) bool {
+	for _, key := range keys {
+		if key.ActivateAt.Equal(activateAt) {
+			return true
+		}
+	}
+
+	return false
+}
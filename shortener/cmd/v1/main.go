@@ -23,6 +23,7 @@ const (
 	httpServerMode  = "http"
 	consumerMode    = "consumer"
 	grpcMode        = "grpc"
+	deadLetterMode  = "dlq"
 )
 
 // @title           Singkatin Revamp API
@@ -102,6 +103,8 @@ func main() {
 			grpcServer = infrastructure.ServeGRPC(app)
 		)
 
+		infrastructure.ServeMetrics(app)
+
 		errC := make(chan error, 1)
 
 		ctx, stop := signal.NotifyContext(context.Background(),
@@ -150,6 +153,16 @@ func main() {
 			go infrastructure.ConsumeMessages(app, q)
 		}
 
+		infrastructure.FlushVisitors(app)
+
+		infrastructure.ServeMetrics(app)
+
 		<-forever
+	case deadLetterMode:
+		// inspect / replay dead lettered messages, e.g. dlq list create-shortener-queue 10
+		if err := infrastructure.ManageDeadLetters(app, args[1:]); err != nil {
+			app.Logger.Error("Failed manage dead letters. Error: ", err)
+			os.Exit(1)
+		}
 	}
 }
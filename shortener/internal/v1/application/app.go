@@ -6,22 +6,32 @@ import (
 	"time"
 
 	"github.com/PickHD/singkatin-revamp/shortener/internal/v1/config"
+	"github.com/PickHD/singkatin-revamp/shortener/internal/v1/helper"
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
+	"github.com/oschwald/geoip2-golang"
 	"github.com/redis/go-redis/v9"
 	"github.com/sirupsen/logrus"
 	"github.com/streadway/amqp"
+	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/exporters/jaeger"
+	"go.opentelemetry.io/otel/propagation"
 	"go.opentelemetry.io/otel/sdk/resource"
 	"go.opentelemetry.io/otel/sdk/trace"
 	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
 	"google.golang.org/grpc"
 )
 
+const (
+	// defaultMaxAttempts & defaultRetryBaseDelay (seconds) used when retry of consumed messages not configured
+	defaultMaxAttempts    = 5
+	defaultRetryBaseDelay = 5
+)
+
 // App ...
 type App struct {
 	Application *echo.Echo
@@ -30,9 +40,10 @@ type App struct {
 	Logger      *logrus.Logger
 	DB          *mongo.Database
 	Redis       *redis.Client
-	RabbitMQ    *amqp.Channel
+	RabbitMQ    *helper.Broker
 	GRPC        *grpc.Server
 	Tracer      *trace.TracerProvider
+	GeoIP       *geoip2.Reader
 }
 
 // SetupApplication configuring dependencies app needed
@@ -61,6 +72,9 @@ func SetupApplication(ctx context.Context) (*App, error) {
 
 	otel.SetTracerProvider(app.Tracer)
 
+	// trace context propagated through queue messages headers as W3C traceparent
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
 	mongoClient, err := mongo.Connect(ctx, options.Client().ApplyURI(fmt.Sprintf("mongodb://%s:%d", app.Config.Database.Host, app.Config.Database.Port)))
 	if err != nil {
 		app.Logger.Error("failed connect mongoDB, error :", err)
@@ -70,6 +84,43 @@ func SetupApplication(ctx context.Context) (*App, error) {
 	db := mongoClient.Database(app.Config.Database.Name)
 	app.DB = db
 
+	// ensure short_url is unique, so collision can be detected on insert
+	_, err = db.Collection(app.Config.Database.ShortenersCollection).Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "short_url", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	})
+	if err != nil {
+		app.Logger.Error("failed create unique index short_url, error :", err)
+		return app, err
+	}
+
+	// supporting dashboard sorting, tag filtering & searching on full_url / short_url
+	_, err = db.Collection(app.Config.Database.ShortenersCollection).Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "created_at", Value: -1}}},
+		{Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "visited", Value: -1}}},
+		{Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "full_url", Value: 1}}},
+		{Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "tags", Value: 1}}},
+		{Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "full_url", Value: "text"}, {Key: "short_url", Value: "text"}}},
+	})
+	if err != nil {
+		app.Logger.Error("failed create list shortener indexes, error :", err)
+		return app, err
+	}
+
+	err = setupClickIndexes(ctx, app.Config, db)
+	if err != nil {
+		app.Logger.Error("failed create clicks indexes, error :", err)
+		return app, err
+	}
+
+	// country of clicks resolved offline, analytics still running without it
+	if app.Config.Analytics.GeoIPDatabasePath != "" {
+		app.GeoIP, err = geoip2.Open(app.Config.Analytics.GeoIPDatabasePath)
+		if err != nil {
+			app.Logger.Warn("failed open GeoIP database, clicks country will be unknown, error :", err)
+		}
+	}
+
 	redisClient := redis.NewClient(&redis.Options{
 		Addr:     fmt.Sprintf("%s:%d", app.Config.Redis.Host, app.Config.Redis.Port),
 		Password: "", // no password set
@@ -77,44 +128,63 @@ func SetupApplication(ctx context.Context) (*App, error) {
 	})
 	app.Redis = redisClient
 
-	amqpConn, err := amqp.Dial(app.Config.RabbitMQ.ConnURL)
-	if err != nil {
-		app.Logger.Error("failed dial RabbitMQ, error :", err)
-		return app, err
+	if app.Config.RabbitMQ.MaxAttempts < 1 {
+		app.Config.RabbitMQ.MaxAttempts = defaultMaxAttempts
 	}
 
-	amqpClient, err := amqpConn.Channel()
-	if err != nil {
-		app.Logger.Error("failed open RabbitMQ Channels, error :", err)
-		return app, err
+	if app.Config.RabbitMQ.RetryBaseDelay < 1 {
+		app.Config.RabbitMQ.RetryBaseDelay = defaultRetryBaseDelay
 	}
 
 	queues := []string{app.Config.RabbitMQ.QueueCreateShortener, app.Config.RabbitMQ.QueueUpdateVisitor, app.Config.RabbitMQ.QueueUpdateShortener, app.Config.RabbitMQ.QueueDeleteShortener}
 
-	for _, q := range queues {
-		_, err = amqpClient.QueueDeclare(
-			q,     // queue name
-			true,  // durable
-			false, // auto delete
-			false, // exclusive
-			false, // no wait
-			nil,   // arguments
-		)
-		if err != nil {
-			app.Logger.Error("failed queue declare Channels, error :", err)
-			return nil, err
+	// topology re-declared every time connection recovered
+	app.RabbitMQ, err = helper.NewBroker(app.Config.RabbitMQ.ConnURL, app.Logger, app.Config.RabbitMQ.ChannelPoolSize, app.Config.RabbitMQ.PrefetchCount, func(ch *amqp.Channel) error {
+		for _, q := range queues {
+			_, err := ch.QueueDeclare(
+				q,     // queue name
+				true,  // durable
+				false, // auto delete
+				false, // exclusive
+				false, // no wait
+				nil,   // arguments
+			)
+			if err != nil {
+				return err
+			}
+
+			err = helper.DeclareRetryTopology(ch, q, app.Config.RabbitMQ.MaxAttempts, time.Duration(app.Config.RabbitMQ.RetryBaseDelay)*time.Second)
+			if err != nil {
+				return err
+			}
 		}
-	}
 
-	app.RabbitMQ = amqpClient
+		return nil
+	})
+	if err != nil {
+		app.Logger.Error("failed connect RabbitMQ, error :", err)
+		return nil, err
+	}
 
 	app.Application = echo.New()
+
+	// client IP used by click analytics & unlock throttling, forwarded headers only honoured from configured proxies
+	app.Application.IPExtractor, err = helper.NewIPExtractor(app.Config.Server.TrustedProxies)
+	if err != nil {
+		app.Logger.Error("failed set trusted proxies, error :", err)
+		return nil, err
+	}
+
 	app.Application.Use(middleware.CORSWithConfig(middleware.CORSConfig{
 		AllowOrigins: []string{"*"},
 		AllowHeaders: []string{echo.HeaderOrigin, echo.HeaderContentType, echo.HeaderAccept, echo.HeaderAuthorization},
 	}))
+	app.Application.Use(helper.MetricsMiddleware)
 
-	app.GRPC = grpc.NewServer()
+	app.GRPC = grpc.NewServer(
+		grpc.UnaryInterceptor(helper.UnaryServerMetrics),
+		grpc.StreamInterceptor(helper.StreamServerMetrics),
+	)
 
 	app.Logger.Info("APP RUN SUCCESSFULLY")
 
@@ -138,6 +208,18 @@ func (a *App) Close(ctx context.Context) {
 			}
 		}
 
+		if a.RabbitMQ != nil {
+			if err := a.RabbitMQ.Close(); err != nil {
+				a.Logger.Error("failed close RabbitMQ, error :", err)
+			}
+		}
+
+		if a.GeoIP != nil {
+			if err := a.GeoIP.Close(); err != nil {
+				panic(err)
+			}
+		}
+
 		ctx, cancel := context.WithTimeout(ctx, time.Second*5)
 		defer cancel()
 		if err := a.Tracer.Shutdown(ctx); err != nil {
@@ -146,6 +228,32 @@ func (a *App) Close(ctx context.Context) {
 	}(ctx)
 }
 
+// setupClickIndexes will ensure raw clicks expired after retention days & daily rollups unique per dimension
+func setupClickIndexes(ctx context.Context, cfg *config.Configuration, db *mongo.Database) error {
+	clickIndexes := []mongo.IndexModel{
+		{Keys: bson.D{{Key: "short_url", Value: 1}, {Key: "clicked_at", Value: -1}}},
+	}
+
+	if cfg.Analytics.RetentionDays > 0 {
+		clickIndexes = append(clickIndexes, mongo.IndexModel{
+			Keys:    bson.D{{Key: "clicked_at", Value: 1}},
+			Options: options.Index().SetExpireAfterSeconds(int32(cfg.Analytics.RetentionDays * 24 * 60 * 60)),
+		})
+	}
+
+	_, err := db.Collection(cfg.Database.ClicksCollection).Indexes().CreateMany(ctx, clickIndexes)
+	if err != nil {
+		return err
+	}
+
+	_, err = db.Collection(cfg.Database.ClickRollupsCollection).Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "short_url", Value: 1}, {Key: "day", Value: 1}, {Key: "dimension", Value: 1}, {Key: "value", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	})
+
+	return err
+}
+
 // initJaegerTracerProvider returns an OpenTelemetry TracerProvider configured to use
 // the Jaeger exporter that will send spans to the provided url. The returned
 // TracerProvider will also use a Resource configured with all the information
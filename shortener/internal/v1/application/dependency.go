@@ -13,8 +13,8 @@ type Dependency struct {
 
 func SetupDependencyInjection(app *App) *Dependency {
 	// repository
-	healthCheckRepoImpl := repository.NewHealthCheckRepository(app.Context, app.Config, app.Logger, app.Tracer, app.DB, app.Redis)
-	shortRepoImpl := repository.NewShortRepository(app.Context, app.Config, app.Logger, app.Tracer, app.DB, app.Redis, app.RabbitMQ)
+	healthCheckRepoImpl := repository.NewHealthCheckRepository(app.Context, app.Config, app.Logger, app.Tracer, app.DB, app.Redis, app.RabbitMQ)
+	shortRepoImpl := repository.NewShortRepository(app.Context, app.Config, app.Logger, app.Tracer, app.DB, app.Redis, app.RabbitMQ, app.GeoIP)
 
 	// service
 	healthCheckSvcImpl := service.NewHealthCheckService(app.Context, app.Config, app.Tracer, healthCheckRepoImpl)
@@ -4,12 +4,17 @@ import "github.com/PickHD/singkatin-revamp/shortener/internal/v1/helper"
 
 type (
 	Configuration struct {
-		Server   *Server
-		Common   *Common
-		Database *Database
-		Redis    *Redis
-		RabbitMQ *RabbitMQ
-		Tracer   *Tracer
+		Server    *Server
+		Common    *Common
+		Database  *Database
+		Redis     *Redis
+		RabbitMQ  *RabbitMQ
+		Tracer    *Tracer
+		Alias     *Alias
+		Unlock    *Unlock
+		Analytics *Analytics
+		Visitor   *Visitor
+		QRCode    *QRCode
 	}
 
 	Common struct {
@@ -17,18 +22,22 @@ type (
 	}
 
 	Server struct {
-		AppPort int
-		AppEnv  string
-		AppName string
-		AppID   string
+		AppPort        int
+		AppEnv         string
+		AppName        string
+		AppID          string
+		MetricsPort    int
+		TrustedProxies []string
 	}
 
 	Database struct {
-		Port                 int
-		Host                 string
-		Name                 string
-		UsersCollection      string
-		ShortenersCollection string
+		Port                   int
+		Host                   string
+		Name                   string
+		UsersCollection        string
+		ShortenersCollection   string
+		ClicksCollection       string
+		ClickRollupsCollection string
 	}
 
 	Redis struct {
@@ -43,11 +52,46 @@ type (
 		QueueUpdateVisitor   string
 		QueueUpdateShortener string
 		QueueDeleteShortener string
+		MaxAttempts          int
+		RetryBaseDelay       int
+		PrefetchCount        int
+		ChannelPoolSize      int
 	}
 
 	Tracer struct {
 		JaegerURL string
 	}
+
+	Alias struct {
+		Charset       string
+		MinLength     int
+		MaxLength     int
+		ReservedWords []string
+	}
+
+	Unlock struct {
+		Secret      string
+		TTL         int
+		MaxAttempts int
+		Lockout     int
+	}
+
+	Analytics struct {
+		GeoIPDatabasePath string
+		RetentionDays     int
+	}
+
+	Visitor struct {
+		FlushInterval  int
+		FlushBatchSize int
+	}
+
+	QRCode struct {
+		BaseURL     string
+		CacheTTL    int
+		DefaultSize int
+		MaxSize     int
+	}
 )
 
 func loadConfiguration() *Configuration {
@@ -56,17 +100,21 @@ func loadConfiguration() *Configuration {
 			GrpcPort: helper.GetEnvInt("GRPC_PORT"),
 		},
 		Server: &Server{
-			AppPort: helper.GetEnvInt("APP_PORT"),
-			AppEnv:  helper.GetEnvString("APP_ENV"),
-			AppName: helper.GetEnvString("APP_NAME"),
-			AppID:   helper.GetEnvString("APP_ID"),
+			AppPort:        helper.GetEnvInt("APP_PORT"),
+			AppEnv:         helper.GetEnvString("APP_ENV"),
+			AppName:        helper.GetEnvString("APP_NAME"),
+			AppID:          helper.GetEnvString("APP_ID"),
+			MetricsPort:    helper.GetEnvInt("METRICS_PORT"),
+			TrustedProxies: helper.GetEnvStringSlice("TRUSTED_PROXIES"),
 		},
 		Database: &Database{
-			Port:                 helper.GetEnvInt("DB_PORT"),
-			Host:                 helper.GetEnvString("DB_HOST"),
-			Name:                 helper.GetEnvString("DB_NAME"),
-			UsersCollection:      helper.GetEnvString("DB_COLLECTION_USERS"),
-			ShortenersCollection: helper.GetEnvString("DB_COLLECTION_SHORTENERS"),
+			Port:                   helper.GetEnvInt("DB_PORT"),
+			Host:                   helper.GetEnvString("DB_HOST"),
+			Name:                   helper.GetEnvString("DB_NAME"),
+			UsersCollection:        helper.GetEnvString("DB_COLLECTION_USERS"),
+			ShortenersCollection:   helper.GetEnvString("DB_COLLECTION_SHORTENERS"),
+			ClicksCollection:       helper.GetEnvString("DB_COLLECTION_CLICKS"),
+			ClickRollupsCollection: helper.GetEnvString("DB_COLLECTION_CLICK_ROLLUPS"),
 		},
 		Redis: &Redis{
 			Host: helper.GetEnvString("REDIS_HOST"),
@@ -79,10 +127,40 @@ func loadConfiguration() *Configuration {
 			QueueUpdateVisitor:   helper.GetEnvString("AMQP_QUEUE_UPDATE_VISITOR_COUNT"),
 			QueueUpdateShortener: helper.GetEnvString("AMQP_QUEUE_UPDATE_SHORTENER"),
 			QueueDeleteShortener: helper.GetEnvString("AMQP_QUEUE_DELETE_SHORTENER"),
+			MaxAttempts:          helper.GetEnvInt("AMQP_MAX_ATTEMPTS"),
+			RetryBaseDelay:       helper.GetEnvInt("AMQP_RETRY_BASE_DELAY"),
+			PrefetchCount:        helper.GetEnvInt("AMQP_PREFETCH_COUNT"),
+			ChannelPoolSize:      helper.GetEnvInt("AMQP_CHANNEL_POOL_SIZE"),
 		},
 		Tracer: &Tracer{
 			JaegerURL: helper.GetEnvString("JAEGER_URL"),
 		},
+		Alias: &Alias{
+			Charset:       helper.GetEnvString("ALIAS_CHARSET"),
+			MinLength:     helper.GetEnvInt("ALIAS_MIN_LENGTH"),
+			MaxLength:     helper.GetEnvInt("ALIAS_MAX_LENGTH"),
+			ReservedWords: helper.GetEnvStringSlice("ALIAS_RESERVED_WORDS"),
+		},
+		Unlock: &Unlock{
+			Secret:      helper.GetEnvString("UNLOCK_SECRET"),
+			TTL:         helper.GetEnvInt("UNLOCK_TTL"),
+			MaxAttempts: helper.GetEnvInt("UNLOCK_MAX_ATTEMPTS"),
+			Lockout:     helper.GetEnvInt("UNLOCK_LOCKOUT"),
+		},
+		Analytics: &Analytics{
+			GeoIPDatabasePath: helper.GetEnvString("GEOIP_DATABASE_PATH"),
+			RetentionDays:     helper.GetEnvInt("CLICK_RETENTION_DAYS"),
+		},
+		Visitor: &Visitor{
+			FlushInterval:  helper.GetEnvInt("VISITOR_FLUSH_INTERVAL"),
+			FlushBatchSize: helper.GetEnvInt("VISITOR_FLUSH_BATCH_SIZE"),
+		},
+		QRCode: &QRCode{
+			BaseURL:     helper.GetEnvString("QR_BASE_URL"),
+			CacheTTL:    helper.GetEnvInt("QR_CACHE_TTL"),
+			DefaultSize: helper.GetEnvInt("QR_DEFAULT_SIZE"),
+			MaxSize:     helper.GetEnvInt("QR_MAX_SIZE"),
+		},
 	}
 }
 
@@ -6,6 +6,7 @@ import (
 
 	"github.com/PickHD/singkatin-revamp/shortener/internal/v1/config"
 	"github.com/PickHD/singkatin-revamp/shortener/internal/v1/helper"
+	"github.com/PickHD/singkatin-revamp/shortener/internal/v1/model"
 	"github.com/PickHD/singkatin-revamp/shortener/internal/v1/service"
 	"github.com/labstack/echo/v4"
 	"go.opentelemetry.io/otel/sdk/trace"
@@ -14,7 +15,9 @@ import (
 type (
 	// HealthCheckController is an interface that has all the function to be implemented inside health check controller
 	HealthCheckController interface {
-		Check(ctx echo.Context) error
+		Live(ctx echo.Context) error
+		Ready(ctx echo.Context) error
+		Readiness() *model.HealthReport
 	}
 
 	// HealthCheckControllerImpl is an app health check struct that consists of all the dependencies needed for health check controller
@@ -36,23 +39,48 @@ func NewHealthCheckController(ctx context.Context, config *config.Configuration,
 	}
 }
 
-// Check godoc
-// @Summary      Checking Health Services
+// Live godoc
+// @Summary      Checking Liveness Services
 // @Tags         Health Check
 // @Accept       json
 // @Produce      json
-// @Success      200  {object}  helper.BaseResponse
-// @Failure      500  {object}  helper.BaseResponse
+// @Success      200  {object}  helper.BaseResponse{data=model.HealthReport}
+// @Router       /live [get]
+func (hc *HealthCheckControllerImpl) Live(ctx echo.Context) error {
+	tr := hc.Tracer.Tracer("Shortener-Live Controller")
+	spanCtx, span := tr.Start(hc.Context, "Start Live")
+	defer span.End()
+
+	return helper.NewResponses[any](ctx, http.StatusOK, "OK", hc.HealthCheckSvc.Live(spanCtx), nil, nil)
+}
+
+// Ready godoc
+// @Summary      Checking Readiness Services & Its Dependencies
+// @Tags         Health Check
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  helper.BaseResponse{data=model.HealthReport}
+// @Failure      503  {object}  helper.BaseResponse{data=model.HealthReport}
+// @Router       /ready [get]
 // @Router       /health-check [get]
-func (hc *HealthCheckControllerImpl) Check(ctx echo.Context) error {
-	tr := hc.Tracer.Tracer("Shortener-Check Controller")
-	_, span := tr.Start(hc.Context, "Start Check")
+func (hc *HealthCheckControllerImpl) Ready(ctx echo.Context) error {
+	tr := hc.Tracer.Tracer("Shortener-Ready Controller")
+	spanCtx, span := tr.Start(hc.Context, "Start Ready")
 	defer span.End()
 
-	ok, err := hc.HealthCheckSvc.Check()
-	if err != nil || !ok {
-		return helper.NewResponses[any](ctx, http.StatusInternalServerError, "not OK", ok, err, nil)
+	report := hc.HealthCheckSvc.Ready(spanCtx)
+	if report.Status != model.HealthUp {
+		return helper.NewResponses[any](ctx, http.StatusServiceUnavailable, "not OK", report, nil, nil)
 	}
 
-	return helper.NewResponses[any](ctx, http.StatusOK, "OK", ok, nil, nil)
+	return helper.NewResponses[any](ctx, http.StatusOK, "OK", report, nil, nil)
+}
+
+// Readiness return readiness report of dependencies for non HTTP probes, e.g. grpc.health.v1 service
+func (hc *HealthCheckControllerImpl) Readiness() *model.HealthReport {
+	tr := hc.Tracer.Tracer("Shortener-Readiness Controller")
+	spanCtx, span := tr.Start(hc.Context, "Start Readiness")
+	defer span.End()
+
+	return hc.HealthCheckSvc.Ready(spanCtx)
 }
@@ -1,9 +1,12 @@
 package controller
 
 import (
+	"bytes"
 	"context"
+	"html/template"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/PickHD/singkatin-revamp/shortener/internal/v1/config"
 	"github.com/PickHD/singkatin-revamp/shortener/internal/v1/helper"
@@ -12,9 +15,8 @@ import (
 	shortenerpb "github.com/PickHD/singkatin-revamp/shortener/pkg/api/v1/proto/shortener"
 	"github.com/labstack/echo/v4"
 	"github.com/sirupsen/logrus"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.opentelemetry.io/otel/sdk/trace"
-	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/status"
 )
 
 type (
@@ -22,15 +24,25 @@ type (
 	ShortController interface {
 		// grpc
 		GetListShortenerByUserID(ctx context.Context, req *shortenerpb.ListShortenerRequest) (*shortenerpb.ListShortenerResponse, error)
+		GetClickStatsByID(ctx context.Context, req *shortenerpb.ClickStatsRequest) (*shortenerpb.ClickStatsResponse, error)
+		ExportShortenerByUserID(req *shortenerpb.ListShortenerRequest, stream shortenerpb.ShortenerService_ExportShortenerByUserIDServer) error
+		CreateShortener(ctx context.Context, req *shortenerpb.CreateShortenerMessage) (*shortenerpb.CreateShortenerReplyMessage, error)
+		UpdateShortener(ctx context.Context, req *shortenerpb.UpdateShortenerMessage) (*shortenerpb.ShortenerReplyMessage, error)
+		DeleteShortener(ctx context.Context, req *shortenerpb.DeleteShortenerMessage) (*shortenerpb.ShortenerReplyMessage, error)
 
 		// http
 		ClickShortener(ctx echo.Context) error
+		UnlockShortener(ctx echo.Context) error
+		QRCodeShortener(ctx echo.Context) error
 
 		// rabbitmq
-		ProcessCreateShortUser(ctx context.Context, msg *shortenerpb.CreateShortenerMessage) error
-		ProcessUpdateVisitorCount(ctx context.Context, msg *shortenerpb.UpdateVisitorCountMessage) error
-		ProcessUpdateShortUser(ctx context.Context, msg *shortenerpb.UpdateShortenerMessage) error
-		ProcessDeleteShortUser(ctx context.Context, msg *shortenerpb.DeleteShortenerMessage) error
+		ProcessCreateShortUser(ctx context.Context, msg *shortenerpb.CreateShortenerMessage) (*shortenerpb.CreateShortenerReplyMessage, error)
+		ProcessClickEvent(ctx context.Context, msg *shortenerpb.ClickEventMessage) error
+		ProcessUpdateShortUser(ctx context.Context, msg *shortenerpb.UpdateShortenerMessage) (*shortenerpb.ShortenerReplyMessage, error)
+		ProcessDeleteShortUser(ctx context.Context, msg *shortenerpb.DeleteShortenerMessage) (*shortenerpb.ShortenerReplyMessage, error)
+
+		// scheduler
+		ProcessFlushVisitorCount(ctx context.Context) error
 	}
 
 	// ShortControllerImpl is an app short struct that consists of all the dependencies needed for short controller
@@ -44,6 +56,20 @@ type (
 	}
 )
 
+// unlockFormTemplate is page served when protected short url visited from browser
+var unlockFormTemplate = template.Must(template.New("unlock").Parse(`<!DOCTYPE html>
+<html>
+<head><title>Protected Link</title></head>
+<body>
+	<h1>This link is protected</h1>
+	{{if .Message}}<p>{{.Message}}</p>{{end}}
+	<form method="POST" action="{{.Action}}">
+		<input type="password" name="password" placeholder="Password" required autofocus>
+		<button type="submit">Unlock</button>
+	</form>
+</body>
+</html>`))
+
 // NewShortController return new instances short controller
 func NewShortController(ctx context.Context, config *config.Configuration, logger *logrus.Logger, tracer *trace.TracerProvider, shortSvc service.ShortService) *ShortControllerImpl {
 	return &ShortControllerImpl{
@@ -60,31 +86,112 @@ func (sc *ShortControllerImpl) GetListShortenerByUserID(ctx context.Context, req
 	_, span := tr.Start(ctx, "Start GetListShortenerByUserID")
 	defer span.End()
 
-	data, err := sc.ShortSvc.GetListShortenerByUserID(ctx, req.GetUserId())
+	data, err := sc.ShortSvc.GetListShortenerByUserID(ctx, &model.ListShortRequest{
+		UserID:    req.GetUserId(),
+		Page:      req.GetPage(),
+		PageSize:  req.GetPageSize(),
+		SortBy:    req.GetSortBy(),
+		SortOrder: req.GetSortOrder(),
+		Search:    req.GetSearch(),
+		Tag:       req.GetTag(),
+	})
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "Failed Get List Shortener By UserID %s", err.Error())
+		return nil, helper.ToStatusError("Get List Shortener By UserID", err)
 	}
 
-	if len(data) < 1 {
-		return &shortenerpb.ListShortenerResponse{}, nil
-	}
+	shorteners := make([]*shortenerpb.Shortener, len(data.Shorts))
 
-	shorteners := make([]*shortenerpb.Shortener, len(data))
-
-	for i, q := range data {
-		shorteners[i] = &shortenerpb.Shortener{
-			Id:       q.ID.Hex(),
-			FullUrl:  q.FullURL,
-			ShortUrl: q.ShortURL,
-			Visited:  q.Visited,
-		}
+	for i := range data.Shorts {
+		shorteners[i] = prepareProtoShortener(&data.Shorts[i])
 	}
 
 	return &shortenerpb.ListShortenerResponse{
 		Shorteners: shorteners,
+		Total:      data.Total,
+		Page:       data.Page,
+		PageSize:   data.PageSize,
+	}, nil
+}
+
+func (sc *ShortControllerImpl) ExportShortenerByUserID(req *shortenerpb.ListShortenerRequest, stream shortenerpb.ShortenerService_ExportShortenerByUserIDServer) error {
+	tr := sc.Tracer.Tracer("Shortener-ExportShortenerByUserID Controller")
+	ctx, span := tr.Start(stream.Context(), "Start ExportShortenerByUserID")
+	defer span.End()
+
+	err := sc.ShortSvc.ExportShortenerByUserID(ctx, req.GetUserId(), func(short *model.Short) error {
+		return stream.Send(prepareProtoShortener(short))
+	})
+	if err != nil {
+		return helper.ToStatusError("Export Shortener By UserID", err)
+	}
+
+	return nil
+}
+
+func (sc *ShortControllerImpl) GetClickStatsByID(ctx context.Context, req *shortenerpb.ClickStatsRequest) (*shortenerpb.ClickStatsResponse, error) {
+	tr := sc.Tracer.Tracer("Shortener-GetClickStatsByID Controller")
+	_, span := tr.Start(ctx, "Start GetClickStatsByID")
+	defer span.End()
+
+	data, err := sc.ShortSvc.GetClickStats(ctx, &model.ClickStatsRequest{
+		ID:     req.GetId(),
+		UserID: req.GetUserId(),
+		From:   helper.UnixToTime(req.GetFrom()),
+		To:     helper.UnixToTime(req.GetTo()),
+	})
+	if err != nil {
+		return nil, helper.ToStatusError("Get Click Stats By ID", err)
+	}
+
+	return &shortenerpb.ClickStatsResponse{
+		ShortUrl:   data.ShortURL,
+		Total:      data.Total,
+		ByDay:      prepareProtoClickStats(data.ByDay),
+		ByReferrer: prepareProtoClickStats(data.ByReferrer),
+		ByCountry:  prepareProtoClickStats(data.ByCountry),
+		ByDevice:   prepareProtoClickStats(data.ByDevice),
 	}, nil
 }
 
+func (sc *ShortControllerImpl) CreateShortener(ctx context.Context, req *shortenerpb.CreateShortenerMessage) (*shortenerpb.CreateShortenerReplyMessage, error) {
+	tr := sc.Tracer.Tracer("Shortener-CreateShortener Controller")
+	ctx, span := tr.Start(ctx, "Start CreateShortener")
+	defer span.End()
+
+	reply, err := sc.ProcessCreateShortUser(ctx, req)
+	if err != nil {
+		return nil, helper.ToStatusError("Create Shortener", err)
+	}
+
+	return reply, nil
+}
+
+func (sc *ShortControllerImpl) UpdateShortener(ctx context.Context, req *shortenerpb.UpdateShortenerMessage) (*shortenerpb.ShortenerReplyMessage, error) {
+	tr := sc.Tracer.Tracer("Shortener-UpdateShortener Controller")
+	ctx, span := tr.Start(ctx, "Start UpdateShortener")
+	defer span.End()
+
+	reply, err := sc.ProcessUpdateShortUser(ctx, req)
+	if err != nil {
+		return nil, helper.ToStatusError("Update Shortener", err)
+	}
+
+	return reply, nil
+}
+
+func (sc *ShortControllerImpl) DeleteShortener(ctx context.Context, req *shortenerpb.DeleteShortenerMessage) (*shortenerpb.ShortenerReplyMessage, error) {
+	tr := sc.Tracer.Tracer("Shortener-DeleteShortener Controller")
+	ctx, span := tr.Start(ctx, "Start DeleteShortener")
+	defer span.End()
+
+	reply, err := sc.ProcessDeleteShortUser(ctx, req)
+	if err != nil {
+		return nil, helper.ToStatusError("Delete Shortener", err)
+	}
+
+	return reply, nil
+}
+
 // Check godoc
 // @Summary      Click Shorteners URL
 // @Tags         Shortener
@@ -93,97 +200,349 @@ func (sc *ShortControllerImpl) GetListShortenerByUserID(ctx context.Context, req
 // @Param        short_url   path string  true  "short urls"
 // @Success      301  {object}  helper.BaseResponse
 // @Failure      400  {object}  helper.BaseResponse
+// @Failure      401  {object}  helper.BaseResponse
 // @Failure      404  {object}  helper.BaseResponse
+// @Failure      410  {object}  helper.BaseResponse
 // @Failure      500  {object}  helper.BaseResponse
 // @Router       /{short_url} [get]
 func (sc *ShortControllerImpl) ClickShortener(ctx echo.Context) error {
 	tr := sc.Tracer.Tracer("Shortener-ClickShortener Controller")
-	_, span := tr.Start(sc.Context, "Start ClickShortener")
+	spanCtx, span := tr.Start(sc.Context, "Start ClickShortener")
 	defer span.End()
 
-	data, err := sc.ShortSvc.ClickShort(ctx.Param("short_url"))
+	req := &model.ClickShortRequest{
+		ShortURL:       ctx.Param("short_url"),
+		Referrer:       ctx.Request().Referer(),
+		UserAgent:      ctx.Request().UserAgent(),
+		IP:             ctx.RealIP(),
+		AcceptLanguage: ctx.Request().Header.Get("Accept-Language"),
+	}
+
+	if cookie, err := ctx.Cookie(model.KeyUnlockCookie); err == nil {
+		req.UnlockToken = cookie.Value
+	}
+
+	// trace continued by consumer recording the click event
+	data, err := sc.ShortSvc.ClickShort(spanCtx, req)
 	if err != nil {
-		if strings.Contains(err.Error(), string(model.Validation)) {
-			return helper.NewResponses[any](ctx, http.StatusBadRequest, err.Error(), ctx.Param("short_url"), err, nil)
+		if model.IsKind(err, model.Protected) {
+			return sc.unlockChallenge(ctx, ctx.Request().URL.Path, "", err)
 		}
 
-		if strings.Contains(err.Error(), string(model.NotFound)) {
-			return helper.NewResponses[any](ctx, http.StatusNotFound, err.Error(), ctx.Param("short_url"), err, nil)
+		return helper.NewErrorResponses[any](ctx, err, "failed click shortener", ctx.Param("short_url"))
+	}
+
+	return ctx.Redirect(http.StatusTemporaryRedirect, data.FullURL)
+}
+
+// Check godoc
+// @Summary      Unlock Protected Shorteners URL
+// @Tags         Shortener
+// @Accept       json,x-www-form-urlencoded
+// @Produce      json,html
+// @Param        short_url   path string  true  "short urls"
+// @Param        unlock body model.UnlockShortRequest true "unlock short"
+// @Success      200  {object}  helper.BaseResponse
+// @Success      303  {object}  helper.BaseResponse
+// @Failure      400  {object}  helper.BaseResponse
+// @Failure      401  {object}  helper.BaseResponse
+// @Failure      404  {object}  helper.BaseResponse
+// @Failure      410  {object}  helper.BaseResponse
+// @Failure      429  {object}  helper.BaseResponse
+// @Failure      500  {object}  helper.BaseResponse
+// @Router       /{short_url}/unlock [post]
+func (sc *ShortControllerImpl) UnlockShortener(ctx echo.Context) error {
+	tr := sc.Tracer.Tracer("Shortener-UnlockShortener Controller")
+	_, span := tr.Start(sc.Context, "Start UnlockShortener")
+	defer span.End()
+
+	var req model.UnlockShortRequest
+
+	if err := ctx.Bind(&req); err != nil {
+		return helper.NewResponses[any](ctx, http.StatusBadRequest, err.Error(), ctx.Param("short_url"), err, nil)
+	}
+
+	req.ShortURL = ctx.Param("short_url")
+	req.ClientIP = ctx.RealIP()
+
+	// unlock token only sent back when visiting the short url itself
+	shortPath := strings.TrimSuffix(ctx.Request().URL.Path, "/unlock")
+
+	data, err := sc.ShortSvc.UnlockShort(sc.Context, &req)
+	if err != nil {
+		if model.IsKind(err, model.Protected) {
+			return sc.unlockChallenge(ctx, shortPath, "Invalid password, please try again.", err)
 		}
 
-		return helper.NewResponses[any](ctx, http.StatusInternalServerError, "failed click shortener", ctx.Param("short_url"), err, nil)
+		return helper.NewErrorResponses[any](ctx, err, "failed unlock shortener", req.ShortURL)
 	}
 
-	return ctx.Redirect(http.StatusTemporaryRedirect, data.FullURL)
+	ctx.SetCookie(&http.Cookie{
+		Name:     model.KeyUnlockCookie,
+		Value:    data.Token,
+		Path:     shortPath,
+		Expires:  data.ExpiresAt,
+		HttpOnly: true,
+		Secure:   true,
+		SameSite: http.SameSiteLaxMode,
+	})
+
+	// browser submitting unlock form, send it back to the short url
+	if strings.HasPrefix(ctx.Request().Header.Get(echo.HeaderContentType), echo.MIMEApplicationForm) {
+		return ctx.Redirect(http.StatusSeeOther, shortPath)
+	}
+
+	return helper.NewResponses[any](ctx, http.StatusOK, "Success unlock shortener", data, nil, nil)
 }
 
-func (sc *ShortControllerImpl) ProcessCreateShortUser(ctx context.Context, msg *shortenerpb.CreateShortenerMessage) error {
+// Check godoc
+// @Summary      Get QR Code of Shorteners URL
+// @Tags         Shortener
+// @Accept       json
+// @Produce      png,image/svg+xml
+// @Param        short_url   path string  true  "short urls"
+// @Param        format query string false "image format, png (default) or svg"
+// @Param        size   query int    false "image size in pixels"
+// @Param        level  query string false "error correction level, L / M (default) / Q / H"
+// @Param        margin query int    false "quiet zone in modules, default 4"
+// @Param        fg     query string false "foreground color hex (RRGGBB), default 000000"
+// @Param        bg     query string false "background color hex (RRGGBB), default ffffff"
+// @Success      200  {file}    binary
+// @Failure      400  {object}  helper.BaseResponse
+// @Failure      404  {object}  helper.BaseResponse
+// @Failure      500  {object}  helper.BaseResponse
+// @Router       /{short_url}/qr [get]
+func (sc *ShortControllerImpl) QRCodeShortener(ctx echo.Context) error {
+	tr := sc.Tracer.Tracer("Shortener-QRCodeShortener Controller")
+	_, span := tr.Start(sc.Context, "Start QRCodeShortener")
+	defer span.End()
+
+	var req model.QRCodeRequest
+
+	if err := ctx.Bind(&req); err != nil {
+		return helper.NewResponses[any](ctx, http.StatusBadRequest, err.Error(), ctx.Param("short_url"), err, nil)
+	}
+
+	req.ShortURL = ctx.Param("short_url")
+
+	data, err := sc.ShortSvc.GetQRCode(sc.Context, &req)
+	if err != nil {
+		return helper.NewErrorResponses[any](ctx, err, "failed generate QR code", req.ShortURL)
+	}
+
+	return ctx.Blob(http.StatusOK, data.ContentType, data.Image)
+}
+
+func (sc *ShortControllerImpl) ProcessCreateShortUser(ctx context.Context, msg *shortenerpb.CreateShortenerMessage) (*shortenerpb.CreateShortenerReplyMessage, error) {
 	tr := sc.Tracer.Tracer("Shortener-ProcessCreateShortUser Controller")
-	_, span := tr.Start(sc.Context, "Start ProcessCreateShortUser")
+	ctx, span := tr.Start(ctx, "Start ProcessCreateShortUser")
 	defer span.End()
 
 	req := &model.CreateShortRequest{
-		UserID:   msg.GetUserId(),
-		FullURL:  msg.GetFullUrl(),
-		ShortURL: msg.GetShortUrl(),
+		UserID:       msg.GetUserId(),
+		FullURL:      msg.GetFullUrl(),
+		ShortURL:     msg.GetShortUrl(),
+		IsCustom:     msg.GetIsCustom(),
+		ExpiresAt:    helper.UnixToTime(msg.GetExpiresAt()),
+		MaxClicks:    msg.GetMaxClicks(),
+		Password:     msg.GetPassword(),
+		PasswordHash: msg.GetPasswordHash(),
+		Tags:         msg.GetTags(),
 	}
 
-	err := sc.ShortSvc.CreateShort(ctx, req)
+	data, err := sc.ShortSvc.CreateShort(ctx, req)
 	if err != nil {
-		return model.NewError(model.Internal, err.Error())
+		return &shortenerpb.CreateShortenerReplyMessage{Error: err.Error(), ErrorDetail: prepareProtoErrorDetail(err)}, err
 	}
 
-	return nil
+	return &shortenerpb.CreateShortenerReplyMessage{
+		ShortUrl: data.ShortURL,
+	}, nil
 }
 
-func (sc *ShortControllerImpl) ProcessUpdateVisitorCount(ctx context.Context, msg *shortenerpb.UpdateVisitorCountMessage) error {
-	tr := sc.Tracer.Tracer("Shortener-ProcessUpdateVisitorCount Controller")
-	_, span := tr.Start(sc.Context, "Start ProcessUpdateVisitorCount")
+func (sc *ShortControllerImpl) ProcessClickEvent(ctx context.Context, msg *shortenerpb.ClickEventMessage) error {
+	tr := sc.Tracer.Tracer("Shortener-ProcessClickEvent Controller")
+	ctx, span := tr.Start(ctx, "Start ProcessClickEvent")
 	defer span.End()
 
-	req := &model.UpdateVisitorRequest{
-		ShortURL: msg.GetShortUrl(),
+	var err error
+
+	req := &model.ClickEvent{
+		ShortURL:       msg.GetShortUrl(),
+		ClickedAt:      time.Unix(msg.GetClickedAt(), 0),
+		Referrer:       msg.GetReferrer(),
+		UserAgent:      msg.GetUserAgent(),
+		Device:         msg.GetDevice(),
+		Country:        msg.GetCountry(),
+		AcceptLanguage: msg.GetAcceptLanguage(),
 	}
 
-	err := sc.ShortSvc.UpdateVisitorShort(ctx, req)
+	// message published before click analytics only have short url
+	if msg.GetClickedAt() == 0 {
+		req.ClickedAt = time.Now()
+	}
+
+	// message published before click id introduced recorded as new click
+	req.ID, err = primitive.ObjectIDFromHex(msg.GetEventId())
+	if err != nil {
+		req.ID = primitive.NewObjectID()
+	}
+
+	if req.Device == "" {
+		req.Device = model.ClickUnknown
+	}
+
+	if req.Country == "" {
+		req.Country = model.ClickUnknown
+	}
+
+	err = sc.ShortSvc.RecordClick(ctx, req)
 	if err != nil {
-		return model.NewError(model.Internal, err.Error())
+		return model.WrapError(model.Internal, "failed record click", err)
 	}
 
 	return nil
 }
 
-func (sc *ShortControllerImpl) ProcessUpdateShortUser(ctx context.Context, msg *shortenerpb.UpdateShortenerMessage) error {
+func (sc *ShortControllerImpl) ProcessUpdateShortUser(ctx context.Context, msg *shortenerpb.UpdateShortenerMessage) (*shortenerpb.ShortenerReplyMessage, error) {
 	tr := sc.Tracer.Tracer("Shortener-ProcessUpdateShortUser Controller")
-	_, span := tr.Start(sc.Context, "Start ProcessUpdateShortUser")
+	ctx, span := tr.Start(ctx, "Start ProcessUpdateShortUser")
 	defer span.End()
 
+	err := sc.ShortSvc.UpdateShort(ctx, prepareUpdateShortRequest(msg))
+	if err != nil {
+		return &shortenerpb.ShortenerReplyMessage{Error: err.Error(), ErrorDetail: prepareProtoErrorDetail(err)}, err
+	}
+
+	return &shortenerpb.ShortenerReplyMessage{}, nil
+}
+
+// prepareUpdateShortRequest will mapping present fields of message into update request, present zero value means
+// the field removed
+func prepareUpdateShortRequest(msg *shortenerpb.UpdateShortenerMessage) *model.UpdateShortRequest {
 	req := &model.UpdateShortRequest{
 		ID:      msg.GetId(),
-		FullURL: msg.GetFullUrl(),
+		UserID:  msg.GetUserId(),
+		FullURL: msg.FullUrl,
 	}
 
-	err := sc.ShortSvc.UpdateShort(ctx, req)
-	if err != nil {
-		return model.NewError(model.Internal, err.Error())
+	if msg.ExpiresAt != nil {
+		if req.ExpiresAt = helper.UnixToTime(msg.GetExpiresAt()); req.ExpiresAt == nil {
+			req.Clear = append(req.Clear, model.ShortFieldExpiresAt)
+		}
 	}
 
-	return nil
+	if msg.MaxClicks != nil {
+		if msg.GetMaxClicks() == 0 {
+			req.Clear = append(req.Clear, model.ShortFieldMaxClicks)
+		} else {
+			req.MaxClicks = msg.MaxClicks
+		}
+	}
+
+	if msg.Password != nil || msg.PasswordHash != nil {
+		if msg.GetPassword() == "" && msg.GetPasswordHash() == "" {
+			req.Clear = append(req.Clear, model.ShortFieldPassword)
+		} else {
+			req.Password = msg.Password
+			req.PasswordHash = msg.GetPasswordHash()
+		}
+	}
+
+	return req
+}
+
+// unlockChallenge will asking password of protected short url, as form for browser & as JSON for api clients
+func (sc *ShortControllerImpl) unlockChallenge(ctx echo.Context, shortPath string, message string, err error) error {
+	if !strings.Contains(ctx.Request().Header.Get(echo.HeaderAccept), echo.MIMETextHTML) {
+		return helper.NewResponses[any](ctx, http.StatusUnauthorized, err.Error(), map[string]string{
+			"short_url":  ctx.Param("short_url"),
+			"unlock_url": shortPath + "/unlock",
+		}, err, nil)
+	}
+
+	var page bytes.Buffer
+
+	if err := unlockFormTemplate.Execute(&page, map[string]string{
+		"Action":  shortPath + "/unlock",
+		"Message": message,
+	}); err != nil {
+		return helper.NewResponses[any](ctx, http.StatusInternalServerError, "failed render unlock form", ctx.Param("short_url"), err, nil)
+	}
+
+	return ctx.HTML(http.StatusUnauthorized, page.String())
 }
 
-func (sc *ShortControllerImpl) ProcessDeleteShortUser(ctx context.Context, msg *shortenerpb.DeleteShortenerMessage) error {
+func (sc *ShortControllerImpl) ProcessDeleteShortUser(ctx context.Context, msg *shortenerpb.DeleteShortenerMessage) (*shortenerpb.ShortenerReplyMessage, error) {
 	tr := sc.Tracer.Tracer("Shortener-ProcessDeleteShortUser Controller")
-	_, span := tr.Start(sc.Context, "Start ProcessDeleteShortUser")
+	ctx, span := tr.Start(ctx, "Start ProcessDeleteShortUser")
 	defer span.End()
 
 	req := &model.DeleteShortRequest{
-		ID: msg.GetId(),
+		ID:     msg.GetId(),
+		UserID: msg.GetUserId(),
 	}
 
 	err := sc.ShortSvc.DeleteShort(ctx, req)
 	if err != nil {
-		return model.NewError(model.Internal, err.Error())
+		return &shortenerpb.ShortenerReplyMessage{Error: err.Error(), ErrorDetail: prepareProtoErrorDetail(err)}, err
+	}
+
+	return &shortenerpb.ShortenerReplyMessage{}, nil
+}
+
+func (sc *ShortControllerImpl) ProcessFlushVisitorCount(ctx context.Context) error {
+	tr := sc.Tracer.Tracer("Shortener-ProcessFlushVisitorCount Controller")
+	ctx, span := tr.Start(ctx, "Start ProcessFlushVisitorCount")
+	defer span.End()
+
+	flushed, err := sc.ShortSvc.FlushVisitorShort(ctx)
+	if err != nil {
+		return model.WrapError(model.Internal, "failed flush visitor count", err)
+	}
+
+	if flushed > 0 {
+		sc.Logger.Info("Success Flush Visitor Count of Short URL: ", flushed)
 	}
 
 	return nil
 }
+
+func prepareProtoShortener(short *model.Short) *shortenerpb.Shortener {
+	return &shortenerpb.Shortener{
+		Id:          short.ID.Hex(),
+		FullUrl:     short.FullURL,
+		ShortUrl:    short.ShortURL,
+		Visited:     short.Visited,
+		ExpiresAt:   helper.TimeToUnix(short.ExpiresAt),
+		MaxClicks:   short.MaxClicks,
+		IsProtected: short.Password != "",
+		Tags:        short.Tags,
+		CreatedAt:   short.CreatedAt.Unix(),
+	}
+}
+
+func prepareProtoClickStats(stats []model.ClickStat) []*shortenerpb.ClickStat {
+	result := make([]*shortenerpb.ClickStat, len(stats))
+
+	for i, q := range stats {
+		result[i] = &shortenerpb.ClickStat{
+			Key:   q.Key,
+			Count: q.Count,
+		}
+	}
+
+	return result
+}
+
+// prepareProtoErrorDetail will transform typed error into reply message error so publisher able decoding it
+func prepareProtoErrorDetail(err error) *shortenerpb.ErrorDetail {
+	e := model.AsError(err)
+
+	return &shortenerpb.ErrorDetail{
+		Kind:    string(e.Kind),
+		Code:    e.Code,
+		Message: e.Message,
+		Details: e.Details,
+	}
+}
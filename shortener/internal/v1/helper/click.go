@@ -0,0 +1,55 @@
+package helper
+
+import (
+	"net/url"
+	"strings"
+)
+
+var (
+	botKeywords    = []string{"bot", "crawler", "spider", "slurp", "curl", "wget", "headless", "preview"}
+	tabletKeywords = []string{"ipad", "tablet", "kindle", "silk", "playbook"}
+	mobileKeywords = []string{"mobi", "iphone", "ipod", "android", "windows phone", "blackberry", "opera mini"}
+)
+
+// ParseDevice will classifying user agent into bot, tablet, mobile or desktop
+func ParseDevice(userAgent string) string {
+	ua := strings.ToLower(userAgent)
+	if ua == "" {
+		return "unknown"
+	}
+
+	if containsAny(ua, botKeywords) {
+		return "bot"
+	}
+
+	// android without "mobile" token is tablet
+	if containsAny(ua, tabletKeywords) || (strings.Contains(ua, "android") && !strings.Contains(ua, "mobile")) {
+		return "tablet"
+	}
+
+	if containsAny(ua, mobileKeywords) {
+		return "mobile"
+	}
+
+	return "desktop"
+}
+
+// ParseReferrerHost return host of referrer url, empty when referrer not a valid url
+func ParseReferrerHost(referrer string) string {
+	u, err := url.Parse(referrer)
+	if err != nil {
+		return ""
+	}
+
+	return strings.TrimPrefix(strings.ToLower(u.Hostname()), "www.")
+}
+
+func containsAny(s string, keywords []string) bool {
+	for _, k := range keywords {
+		if strings.Contains(s, k) {
+			return true
+		}
+	}
+
+	return false
+}
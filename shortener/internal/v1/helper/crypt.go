@@ -0,0 +1,61 @@
+package helper
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// HashPassword will transform from plain password into hashed password
+func HashPassword(password string) (string, error) {
+	bytes, err := bcrypt.GenerateFromPassword([]byte(password), 12)
+	return string(bytes), err
+}
+
+// CheckPasswordHash will compare current password with hashed password in database
+func CheckPasswordHash(hash, password string) bool {
+	err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(password))
+	return err == nil
+}
+
+// IsPasswordHash will checking hash is valid bcrypt hash, so pre hashed password can be stored as is
+func IsPasswordHash(hash string) bool {
+	_, err := bcrypt.Cost([]byte(hash))
+	return err == nil
+}
+
+// SignUnlockToken will generating signed token proving short url already unlocked until expiredAt,
+// password hash included in signature so changing password invalidate issued tokens
+func SignUnlockToken(secret, shortURL, passwordHash string, expiredAt time.Time) string {
+	exp := strconv.FormatInt(expiredAt.Unix(), 10)
+
+	return fmt.Sprintf("%s.%s", exp, unlockSignature(secret, shortURL, passwordHash, exp))
+}
+
+// VerifyUnlockToken will validating signature & expiration of unlock token
+func VerifyUnlockToken(secret, shortURL, passwordHash, token string) bool {
+	exp, sig, ok := strings.Cut(token, ".")
+	if !ok {
+		return false
+	}
+
+	expUnix, err := strconv.ParseInt(exp, 10, 64)
+	if err != nil || time.Now().Unix() > expUnix {
+		return false
+	}
+
+	return hmac.Equal([]byte(sig), []byte(unlockSignature(secret, shortURL, passwordHash, exp)))
+}
+
+func unlockSignature(secret, shortURL, passwordHash, exp string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(fmt.Sprintf("%s:%s:%s", shortURL, exp, passwordHash)))
+
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,61 @@
+package helper
+
+import (
+	"fmt"
+
+	"github.com/PickHD/singkatin-revamp/shortener/internal/v1/model"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// ErrorDomain is domain of error info attached into grpc status replied by shortener
+	ErrorDomain = "shortener.singkatin"
+
+	// ErrorMetadataKind & ErrorMetadataMessage are keys of error info metadata carrying kind & message of typed error
+	ErrorMetadataKind    = "kind"
+	ErrorMetadataMessage = "message"
+)
+
+// grpcCodes is grpc status code replied for every kind of errors, kinds not listed replied as internal
+var grpcCodes = map[model.ErrorKind]codes.Code{
+	model.Validation:      codes.InvalidArgument,
+	model.Type:            codes.InvalidArgument,
+	model.NotFound:        codes.NotFound,
+	model.Forbidden:       codes.PermissionDenied,
+	model.Conflict:        codes.AlreadyExists,
+	model.Expired:         codes.FailedPrecondition,
+	model.Protected:       codes.Unauthenticated,
+	model.TooManyRequests: codes.ResourceExhausted,
+}
+
+// ToStatusError will mapping typed error into grpc status, kind, code, message & details
+// attached as error info so client able decoding it back into typed error
+func ToStatusError(op string, err error) error {
+	e := model.AsError(err)
+
+	code, ok := grpcCodes[e.Kind]
+	if !ok {
+		code = codes.Internal
+	}
+
+	metadata := make(map[string]string, len(e.Details)+2)
+	for k, v := range e.Details {
+		metadata[k] = v
+	}
+
+	metadata[ErrorMetadataKind] = string(e.Kind)
+	metadata[ErrorMetadataMessage] = e.Message
+
+	st, errDetail := status.New(code, fmt.Sprintf("Failed %s %s", op, e.Error())).WithDetails(&errdetails.ErrorInfo{
+		Reason:   e.Code,
+		Domain:   ErrorDomain,
+		Metadata: metadata,
+	})
+	if errDetail != nil {
+		return status.Errorf(code, "Failed %s %s", op, e.Error())
+	}
+
+	return st.Err()
+}
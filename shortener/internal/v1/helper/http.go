@@ -1,6 +1,12 @@
 package helper
 
 import (
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/PickHD/singkatin-revamp/shortener/internal/v1/model"
 	"github.com/labstack/echo/v4"
 )
 
@@ -39,5 +45,56 @@ func NewResponses[T any](ctx echo.Context, statusCode int, message string, data
 	})
 }
 
+// NewErrorResponses return JSON responses of err with HTTP status mapped from its kind,
+// unexpected errors responded with message instead of leaking their cause
+func NewErrorResponses[T any](ctx echo.Context, err error, message string, data T) error {
+	statusCode := model.HTTPStatus(err)
+	if statusCode == http.StatusInternalServerError {
+		return NewResponses[T](ctx, statusCode, message, data, model.NewError(model.Internal, message), nil)
+	}
+
+	typedErr := model.AsError(err)
+	if retryAfter, ok := typedErr.Details[model.DetailRetryAfter]; ok {
+		ctx.Response().Header().Set("Retry-After", retryAfter)
+	}
+
+	return NewResponses[T](ctx, statusCode, err.Error(), data, typedErr, nil)
+}
+
+// NewIPExtractor return extractor of client IP taken from X-Forwarded-For only when request came through
+// one of trusted proxies (IPs or CIDRs), otherwise from the connection itself
+func NewIPExtractor(trustedProxies []string) (echo.IPExtractor, error) {
+	options := []echo.TrustOption{
+		echo.TrustLoopback(false),
+		echo.TrustLinkLocal(false),
+		echo.TrustPrivateNet(false),
+	}
+
+	for _, proxy := range trustedProxies {
+		if !strings.Contains(proxy, "/") {
+			ip := net.ParseIP(proxy)
+			if ip == nil {
+				return nil, fmt.Errorf("invalid trusted proxy %q", proxy)
+			}
+
+			bits := 8 * net.IPv6len
+			if ip.To4() != nil {
+				bits = 8 * net.IPv4len
+			}
+
+			proxy = fmt.Sprintf("%s/%d", proxy, bits)
+		}
+
+		_, ipRange, err := net.ParseCIDR(proxy)
+		if err != nil {
+			return nil, fmt.Errorf("invalid trusted proxy %q: %w", proxy, err)
+		}
+
+		options = append(options, echo.TrustIPRange(ipRange))
+	}
+
+	return echo.ExtractIPFromXFFHeader(options...), nil
+}
+
 // OptionsHandler will handing preflight requests
 func OptionsHandler(ctx echo.Context) error { return nil }
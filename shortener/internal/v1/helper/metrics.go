@@ -0,0 +1,140 @@
+package helper
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// CacheHit & CacheMiss are result label of redirect cache lookups
+	CacheHit  = "hit"
+	CacheMiss = "miss"
+)
+
+var (
+	// HTTPRequestDuration observe latency of HTTP requests by method, route & status
+	HTTPRequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "http_request_duration_seconds",
+		Help:    "Latency of HTTP requests by method, route & status.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"method", "route", "status"})
+
+	// GRPCServerHandlingDuration observe latency of handled gRPC calls by method & status code
+	GRPCServerHandlingDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "grpc_server_handling_seconds",
+		Help:    "Latency of gRPC calls handled by server by method & status code.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"method", "code"})
+
+	// QueuePublished count messages published by queue
+	QueuePublished = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "amqp_messages_published_total",
+		Help: "Total messages published by queue.",
+	}, []string{"queue"})
+
+	// QueuePublishFailures count messages failed to publish by queue
+	QueuePublishFailures = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "amqp_publish_failures_total",
+		Help: "Total messages failed to publish by queue.",
+	}, []string{"queue"})
+
+	// QueueConsumed count messages consumed by queue
+	QueueConsumed = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "amqp_messages_consumed_total",
+		Help: "Total messages consumed by queue.",
+	}, []string{"queue"})
+
+	// QueueConsumeFailures count consumed messages failed to process by queue
+	QueueConsumeFailures = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "amqp_consume_failures_total",
+		Help: "Total consumed messages failed to process by queue.",
+	}, []string{"queue"})
+
+	// RedirectCache count redirect lookups by cache result, hit ratio is hit / (hit + miss)
+	RedirectCache = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "shortener_redirect_cache_total",
+		Help: "Total redirect lookups by cache result.",
+	}, []string{"result"})
+
+	// LinksCreated count short links created
+	LinksCreated = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "shortener_links_created_total",
+		Help: "Total short links created.",
+	})
+
+	// Clicks count clicks redirected into full url
+	Clicks = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "shortener_clicks_total",
+		Help: "Total clicks redirected into full url.",
+	})
+)
+
+// MetricsMiddleware will observing latency & status of every request
+func MetricsMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		start := time.Now()
+
+		err := next(c)
+
+		// response not written yet when handler returning error, status taken from the error
+		code := c.Response().Status
+		if err != nil {
+			code = http.StatusInternalServerError
+
+			var he *echo.HTTPError
+			if errors.As(err, &he) {
+				code = he.Code
+			}
+		}
+
+		route := c.Path()
+		if route == "" {
+			route = "unmatched"
+		}
+
+		HTTPRequestDuration.WithLabelValues(c.Request().Method, route, strconv.Itoa(code)).Observe(time.Since(start).Seconds())
+
+		return err
+	}
+}
+
+// UnaryServerMetrics is gRPC interceptor observing latency & status code of every unary call
+func UnaryServerMetrics(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	start := time.Now()
+
+	resp, err := handler(ctx, req)
+
+	GRPCServerHandlingDuration.WithLabelValues(info.FullMethod, status.Code(err).String()).Observe(time.Since(start).Seconds())
+
+	return resp, err
+}
+
+// StreamServerMetrics is gRPC interceptor observing latency & status code of every streaming call
+func StreamServerMetrics(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	start := time.Now()
+
+	err := handler(srv, ss)
+
+	GRPCServerHandlingDuration.WithLabelValues(info.FullMethod, status.Code(err).String()).Observe(time.Since(start).Seconds())
+
+	return err
+}
+
+// ServeMetrics will exposing metrics on /metrics for modes without HTTP server
+func ServeMetrics(port int) error {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.Handler())
+
+	return http.ListenAndServe(fmt.Sprintf(":%d", port), mux)
+}
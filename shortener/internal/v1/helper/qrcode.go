@@ -0,0 +1,86 @@
+package helper
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"strings"
+)
+
+// ParseHexColor will transform hex color (RRGGBB / #RRGGBB) into color
+func ParseHexColor(s string) (color.RGBA, error) {
+	b, err := hex.DecodeString(strings.TrimPrefix(s, "#"))
+	if err != nil || len(b) != 3 {
+		return color.RGBA{}, errors.New("color must be hex formatted as RRGGBB")
+	}
+
+	return color.RGBA{R: b[0], G: b[1], B: b[2], A: 0xff}, nil
+}
+
+// RenderQRCodePNG will drawing QR code modules into size x size PNG, surrounded by margin modules
+func RenderQRCodePNG(bitmap [][]bool, size, margin int, fg, bg color.Color) ([]byte, error) {
+	modules := len(bitmap) + 2*margin
+
+	scale := size / modules
+	if scale < 1 {
+		return nil, fmt.Errorf("size must be at least %d pixels", modules)
+	}
+
+	// center the code when size not divisible by modules
+	offset := (size-modules*scale)/2 + margin*scale
+
+	img := image.NewPaletted(image.Rect(0, 0, size, size), color.Palette{bg, fg})
+
+	for y, row := range bitmap {
+		for x, dark := range row {
+			if !dark {
+				continue
+			}
+
+			for dy := 0; dy < scale; dy++ {
+				for dx := 0; dx < scale; dx++ {
+					img.SetColorIndex(offset+x*scale+dx, offset+y*scale+dy, 1)
+				}
+			}
+		}
+	}
+
+	var buf bytes.Buffer
+
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// RenderQRCodeSVG will drawing QR code modules into scalable SVG, surrounded by margin modules
+func RenderQRCodeSVG(bitmap [][]bool, size, margin int, fg, bg color.RGBA) []byte {
+	modules := len(bitmap) + 2*margin
+
+	var buf bytes.Buffer
+
+	fmt.Fprintf(&buf, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" shape-rendering="crispEdges">`, size, size, modules, modules)
+	fmt.Fprintf(&buf, `<rect width="100%%" height="100%%" fill="%s"/>`, hexColor(bg))
+	fmt.Fprintf(&buf, `<path fill="%s" d="`, hexColor(fg))
+
+	for y, row := range bitmap {
+		for x, dark := range row {
+			if dark {
+				fmt.Fprintf(&buf, "M%d,%dh1v1h-1z", x+margin, y+margin)
+			}
+		}
+	}
+
+	buf.WriteString(`"/></svg>`)
+
+	return buf.Bytes()
+}
+
+func hexColor(c color.RGBA) string {
+	return fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B)
+}
@@ -0,0 +1,23 @@
+package helper
+
+import "time"
+
+// TimeToUnix will transform optional time into unix seconds, zero when not set
+func TimeToUnix(t *time.Time) int64 {
+	if t == nil {
+		return 0
+	}
+
+	return t.Unix()
+}
+
+// UnixToTime will transform unix seconds into optional time, nil when zero
+func UnixToTime(sec int64) *time.Time {
+	if sec == 0 {
+		return nil
+	}
+
+	t := time.Unix(sec, 0)
+
+	return &t
+}
@@ -1,12 +1,20 @@
 package infrastructure
 
 import (
+	"time"
+
 	"github.com/PickHD/singkatin-revamp/shortener/internal/v1/application"
+	"github.com/PickHD/singkatin-revamp/shortener/internal/v1/model"
 	shortenerpb "github.com/PickHD/singkatin-revamp/shortener/pkg/api/v1/proto/shortener"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/reflection"
 )
 
+// healthProbeInterval is interval of refreshing serving status of grpc.health.v1 service by readiness of dependencies
+const healthProbeInterval = 10 * time.Second
+
 func ServeGRPC(app *application.App) *grpc.Server {
 	// call register
 	return register(app)
@@ -19,5 +27,30 @@ func register(app *application.App) *grpc.Server {
 
 	shortenerpb.RegisterShortenerServiceServer(app.GRPC, dep.ShortController)
 
+	healthServer := health.NewServer()
+	healthpb.RegisterHealthServer(app.GRPC, healthServer)
+
+	go probeHealth(app, dep, healthServer)
+
 	return app.GRPC
 }
+
+// probeHealth will keeping serving status of overall server & shortener service in sync with readiness of dependencies
+func probeHealth(app *application.App, dep *application.Dependency, healthServer *health.Server) {
+	ticker := time.NewTicker(healthProbeInterval)
+	defer ticker.Stop()
+
+	for {
+		status := healthpb.HealthCheckResponse_SERVING
+		if report := dep.HealthCheckController.Readiness(); report.Status != model.HealthUp {
+			app.Logger.Error("Shortener not ready, dependencies : ", report.Dependencies)
+
+			status = healthpb.HealthCheckResponse_NOT_SERVING
+		}
+
+		healthServer.SetServingStatus("", status)
+		healthServer.SetServingStatus(shortenerpb.ShortenerService_ServiceDesc.ServiceName, status)
+
+		<-ticker.C
+	}
+}
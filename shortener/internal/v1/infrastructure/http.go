@@ -3,6 +3,7 @@ package infrastructure
 import (
 	"github.com/PickHD/singkatin-revamp/shortener/internal/v1/application"
 	"github.com/labstack/echo/v4"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 
 	echoSwagger "github.com/swaggo/echo-swagger"
 )
@@ -19,13 +20,23 @@ func ServeHTTP(app *application.App) *echo.Echo {
 func setupRouter(app *application.App) {
 	var dep = application.SetupDependencyInjection(app)
 
+	app.Application.GET("/metrics", echo.WrapHandler(promhttp.Handler()))
+
 	v1 := app.Application.Group("/v1")
 	{
 		v1.GET("/swagger/*any", echoSwagger.WrapHandler)
 
-		v1.GET("/health-check", dep.HealthCheckController.Check)
+		v1.GET("/live", dep.HealthCheckController.Live)
+
+		v1.GET("/ready", dep.HealthCheckController.Ready)
+
+		v1.GET("/health-check", dep.HealthCheckController.Ready)
 
 		v1.GET("/:short_url", dep.ShortController.ClickShortener)
+
+		v1.POST("/:short_url/unlock", dep.ShortController.UnlockShortener)
+
+		v1.GET("/:short_url/qr", dep.ShortController.QRCodeShortener)
 	}
 
 }
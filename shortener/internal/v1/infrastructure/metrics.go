@@ -0,0 +1,17 @@
+package infrastructure
+
+import (
+	"github.com/PickHD/singkatin-revamp/shortener/internal/v1/application"
+	"github.com/PickHD/singkatin-revamp/shortener/internal/v1/helper"
+)
+
+// ServeMetrics will exposing metrics in background for modes without HTTP server
+func ServeMetrics(app *application.App) {
+	go func() {
+		app.Logger.Info("Serving metrics on port ", app.Config.Server.MetricsPort)
+
+		if err := helper.ServeMetrics(app.Config.Server.MetricsPort); err != nil {
+			app.Logger.Error("Failed serve metrics. Error: ", err)
+		}
+	}()
+}
@@ -2,100 +2,273 @@ package infrastructure
 
 import (
 	"fmt"
+	"strconv"
 
 	"github.com/PickHD/singkatin-revamp/shortener/internal/v1/application"
+	"github.com/PickHD/singkatin-revamp/shortener/internal/v1/helper"
+	"github.com/PickHD/singkatin-revamp/shortener/internal/v1/model"
 	shortenerpb "github.com/PickHD/singkatin-revamp/shortener/pkg/api/v1/proto/shortener"
+	"github.com/sirupsen/logrus"
+	"github.com/streadway/amqp"
+	"google.golang.org/protobuf/encoding/protojson"
 	"google.golang.org/protobuf/proto"
 )
 
+// defaultDeadLetterLimit is number of dead lettered messages listed / replayed when limit not defined
+const defaultDeadLetterLimit = 100
+
 // ConsumeMessages generic function to consume message from defined param queues
 func ConsumeMessages(app *application.App, queueName string) {
 	dep := application.SetupDependencyInjection(app)
 
-	// Subscribing to queues for getting messages.
-	messages, err := app.RabbitMQ.Consume(
-		queueName, // queue name
-		"",        // consumer
-		true,      // auto-ack
-		false,     // exclusive
-		false,     // no local
-		false,     // no wait
-		nil,       // arguments
-	)
-	if err != nil {
-		app.Logger.Error("Failed consume message in queue", queueName)
-	}
-
 	app.Logger.Info("Waiting Message in Queues ", queueName, ".....")
 
-	go func() {
-		for msg := range messages {
-			switch queueName {
-			case app.Config.RabbitMQ.QueueCreateShortener:
-				req := &shortenerpb.CreateShortenerMessage{}
+	// Subscribing to queues for getting messages, resumed when connection recovered.
+	app.RabbitMQ.Consume(queueName, false, func(msg amqp.Delivery) {
+		// continue trace of publisher carried by message headers
+		ctx := helper.ExtractTraceContext(app.Context, msg.Headers)
 
-				err := proto.Unmarshal(msg.Body, req)
-				if err != nil {
-					app.Logger.Error("Unmarshal proto CreateShortenerMessage ERROR, ", err)
-				}
+		switch queueName {
+		case app.Config.RabbitMQ.QueueCreateShortener:
+			req := &shortenerpb.CreateShortenerMessage{}
 
-				app.Logger.Info(fmt.Sprintf("[%s] Success Consume Message :", queueName), req)
+			err := proto.Unmarshal(msg.Body, req)
+			if err != nil {
+				app.Logger.Error("Unmarshal proto CreateShortenerMessage ERROR, ", err)
+				deadLetterMessage(app, msg, queueName, err)
+				return
+			}
 
-				err = dep.ShortController.ProcessCreateShortUser(app.Context, req)
-				if err != nil {
-					app.Logger.Error("ProcessCreateShortUser ERROR, ", err)
-				}
+			app.Logger.Info(fmt.Sprintf("[%s] Success Consume Message :", queueName), req)
 
-				app.Logger.Info(fmt.Sprintf("[%s] Success Process Message :", queueName), req)
-			case app.Config.RabbitMQ.QueueUpdateVisitor:
-				req := &shortenerpb.UpdateVisitorCountMessage{}
+			reply, err := dep.ShortController.ProcessCreateShortUser(ctx, req)
+			if err != nil {
+				app.Logger.Error("ProcessCreateShortUser ERROR, ", err)
+			}
 
-				err := proto.Unmarshal(msg.Body, req)
-				if err != nil {
-					app.Logger.Error("Unmarshal proto UpdateVisitorCountMessage ERROR, ", err)
-				}
+			// send back final short url to the publisher if it waiting for reply
+			if settleMessage(app, msg, queueName, err) && msg.ReplyTo != "" {
+				replyMessage(app, msg, reply)
+			}
 
-				app.Logger.Info(fmt.Sprintf("[%s] Success Consume Message :", queueName), req)
+			app.Logger.Info(fmt.Sprintf("[%s] Success Process Message :", queueName), req)
+		case app.Config.RabbitMQ.QueueUpdateVisitor:
+			req := &shortenerpb.ClickEventMessage{}
 
-				err = dep.ShortController.ProcessUpdateVisitorCount(app.Context, req)
-				if err != nil {
-					app.Logger.Error("ProcessUpdateVisitorCount ERROR, ", err)
-				}
+			err := proto.Unmarshal(msg.Body, req)
+			if err != nil {
+				app.Logger.Error("Unmarshal proto ClickEventMessage ERROR, ", err)
+				deadLetterMessage(app, msg, queueName, err)
+				return
+			}
 
-				app.Logger.Info(fmt.Sprintf("[%s] Success Process Message :", queueName), req)
-			case app.Config.RabbitMQ.QueueUpdateShortener:
-				req := &shortenerpb.UpdateShortenerMessage{}
+			app.Logger.Info(fmt.Sprintf("[%s] Success Consume Message :", queueName), req)
 
-				err := proto.Unmarshal(msg.Body, req)
-				if err != nil {
-					app.Logger.Error("Unmarshal proto UpdateShortenerMessage ERROR, ", err)
-				}
+			err = dep.ShortController.ProcessClickEvent(ctx, req)
+			if err != nil {
+				app.Logger.Error("ProcessClickEvent ERROR, ", err)
+			}
 
-				app.Logger.Info(fmt.Sprintf("[%s] Success Consume Message :", queueName), req)
+			settleMessage(app, msg, queueName, err)
 
-				err = dep.ShortController.ProcessUpdateShortUser(app.Context, req)
-				if err != nil {
-					app.Logger.Error("ProcessUpdateShortUser ERROR, ", err)
-				}
+			app.Logger.Info(fmt.Sprintf("[%s] Success Process Message :", queueName), req)
+		case app.Config.RabbitMQ.QueueUpdateShortener:
+			req := &shortenerpb.UpdateShortenerMessage{}
 
-				app.Logger.Info(fmt.Sprintf("[%s] Success Process Message :", queueName), req)
-			case app.Config.RabbitMQ.QueueDeleteShortener:
-				req := &shortenerpb.DeleteShortenerMessage{}
+			err := proto.Unmarshal(msg.Body, req)
+			if err != nil {
+				app.Logger.Error("Unmarshal proto UpdateShortenerMessage ERROR, ", err)
+				deadLetterMessage(app, msg, queueName, err)
+				return
+			}
 
-				err := proto.Unmarshal(msg.Body, req)
-				if err != nil {
-					app.Logger.Error("Unmarshal proto DeleteShortenerMessage ERROR, ", err)
-				}
+			app.Logger.Info(fmt.Sprintf("[%s] Success Consume Message :", queueName), req)
 
-				app.Logger.Info(fmt.Sprintf("[%s] Success Consume Message :", queueName), req)
+			reply, err := dep.ShortController.ProcessUpdateShortUser(ctx, req)
+			if err != nil {
+				app.Logger.Error("ProcessUpdateShortUser ERROR, ", err)
+			}
+
+			// send back outcome to the publisher if it waiting for reply
+			if settleMessage(app, msg, queueName, err) && msg.ReplyTo != "" {
+				replyMessage(app, msg, reply)
+			}
 
-				err = dep.ShortController.ProcessDeleteShortUser(app.Context, req)
-				if err != nil {
-					app.Logger.Error("ProcessDeleteShortUser ERROR, ", err)
-				}
+			app.Logger.Info(fmt.Sprintf("[%s] Success Process Message :", queueName), req)
+		case app.Config.RabbitMQ.QueueDeleteShortener:
+			req := &shortenerpb.DeleteShortenerMessage{}
 
-				app.Logger.Info(fmt.Sprintf("[%s] Success Process Message :", queueName), req)
+			err := proto.Unmarshal(msg.Body, req)
+			if err != nil {
+				app.Logger.Error("Unmarshal proto DeleteShortenerMessage ERROR, ", err)
+				deadLetterMessage(app, msg, queueName, err)
+				return
 			}
+
+			app.Logger.Info(fmt.Sprintf("[%s] Success Consume Message :", queueName), req)
+
+			reply, err := dep.ShortController.ProcessDeleteShortUser(ctx, req)
+			if err != nil {
+				app.Logger.Error("ProcessDeleteShortUser ERROR, ", err)
+			}
+
+			// send back outcome to the publisher if it waiting for reply
+			if settleMessage(app, msg, queueName, err) && msg.ReplyTo != "" {
+				replyMessage(app, msg, reply)
+			}
+
+			app.Logger.Info(fmt.Sprintf("[%s] Success Process Message :", queueName), req)
 		}
-	}()
+	})
+}
+
+// ManageDeadLetters will listing or replaying dead lettered messages of queue,
+// args is action (list / replay), queue name & optional limit
+func ManageDeadLetters(app *application.App, args []string) error {
+	if len(args) < 2 {
+		return model.NewError(model.Validation, "usage: dlq <list|replay> <queue> [limit]")
+	}
+
+	action, queueName, limit := args[0], args[1], defaultDeadLetterLimit
+
+	if len(args) > 2 {
+		n, err := strconv.Atoi(args[2])
+		if err != nil || n < 1 {
+			return model.NewError(model.Validation, "limit must be positive number")
+		}
+
+		limit = n
+	}
+
+	ch, err := app.RabbitMQ.Channel()
+	if err != nil {
+		return err
+	}
+	defer ch.Close()
+
+	switch action {
+	case "list":
+		messages, err := helper.PeekDeadLetters(ch, queueName, limit)
+		if err != nil {
+			return err
+		}
+
+		for _, msg := range messages {
+			app.Logger.WithFields(logrus.Fields{
+				"queue":          queueName,
+				"attempts":       helper.Attempts(msg),
+				"last_error":     msg.Headers[helper.HeaderLastError],
+				"correlation_id": msg.CorrelationId,
+				"timestamp":      msg.Timestamp,
+				"body":           decodeMessage(app, queueName, msg.Body),
+			}).Info("Dead Lettered Message")
+		}
+
+		app.Logger.Info(fmt.Sprintf("[%s] Total Dead Lettered Message Listed : %d", queueName, len(messages)))
+	case "replay":
+		replayed, err := helper.ReplayDeadLetters(ch, queueName, limit)
+		if err != nil {
+			return err
+		}
+
+		app.Logger.Info(fmt.Sprintf("[%s] Total Dead Lettered Message Replayed : %d", queueName, replayed))
+	default:
+		return model.NewError(model.Validation, "action must be list or replay")
+	}
+
+	return nil
+}
+
+// settleMessage will acking processed message, or retrying / dead lettering it when processing failed by transient error.
+// returning true when message will not be redelivered anymore
+func settleMessage(app *application.App, msg amqp.Delivery, queueName string, err error) bool {
+	if err != nil {
+		helper.QueueConsumeFailures.WithLabelValues(queueName).Inc()
+	}
+
+	if err == nil || !isRetryable(err) {
+		if errAck := msg.Ack(false); errAck != nil {
+			app.Logger.Error("Ack message ERROR, ", errAck)
+		}
+
+		return true
+	}
+
+	deadLettered, errRetry := helper.RetryOrDeadLetter(app.RabbitMQ, msg, queueName, app.Config.RabbitMQ.MaxAttempts, err)
+	if errRetry != nil {
+		app.Logger.Error("Retry message ERROR, ", errRetry)
+		return false
+	}
+
+	if deadLettered {
+		app.Logger.Error(fmt.Sprintf("[%s] Message Dead Lettered after %d attempts, ", queueName, app.Config.RabbitMQ.MaxAttempts), err)
+	}
+
+	return deadLettered
+}
+
+// deadLetterMessage will moving message which never can be processed straight into dead letter exchange
+func deadLetterMessage(app *application.App, msg amqp.Delivery, queueName string, err error) {
+	helper.QueueConsumeFailures.WithLabelValues(queueName).Inc()
+
+	if errDLQ := helper.DeadLetter(app.RabbitMQ, msg, queueName, err); errDLQ != nil {
+		app.Logger.Error("Dead letter message ERROR, ", errDLQ)
+	}
+}
+
+// isRetryable will checking processing error caused by infrastructure, not by the message itself
+func isRetryable(err error) bool {
+	switch model.AsError(err).Kind {
+	case model.Internal, model.Unknown:
+		return true
+	}
+
+	return false
+}
+
+// decodeMessage will transform proto body of queue message into JSON for inspection
+func decodeMessage(app *application.App, queueName string, body []byte) string {
+	var msg proto.Message
+
+	switch queueName {
+	case app.Config.RabbitMQ.QueueCreateShortener:
+		msg = &shortenerpb.CreateShortenerMessage{}
+	case app.Config.RabbitMQ.QueueUpdateVisitor:
+		msg = &shortenerpb.ClickEventMessage{}
+	case app.Config.RabbitMQ.QueueUpdateShortener:
+		msg = &shortenerpb.UpdateShortenerMessage{}
+	case app.Config.RabbitMQ.QueueDeleteShortener:
+		msg = &shortenerpb.DeleteShortenerMessage{}
+	default:
+		return string(body)
+	}
+
+	if err := proto.Unmarshal(body, msg); err != nil {
+		return fmt.Sprintf("undecodable message (%s)", err.Error())
+	}
+
+	return protojson.Format(msg)
+}
+
+// replyMessage publish reply of processed message into queue defined on reply_to properties
+func replyMessage(app *application.App, msg amqp.Delivery, reply proto.Message) {
+	b, err := proto.Marshal(reply)
+	if err != nil {
+		app.Logger.Error("Marshal proto reply message ERROR, ", err)
+		return
+	}
+
+	if err := app.RabbitMQ.Publish(
+		"",          // exchange
+		msg.ReplyTo, // queue name
+		false,       // mandatory
+		false,       // immediate
+		amqp.Publishing{
+			ContentType:   "text/plain",
+			CorrelationId: msg.CorrelationId,
+			Body:          b,
+		}, // message to publish
+	); err != nil {
+		app.Logger.Error("Publish reply message ERROR, ", err)
+	}
 }
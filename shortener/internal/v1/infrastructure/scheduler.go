@@ -0,0 +1,32 @@
+package infrastructure
+
+import (
+	"time"
+
+	"github.com/PickHD/singkatin-revamp/shortener/internal/v1/application"
+)
+
+// FlushVisitors periodically writing buffered visitor count into database
+func FlushVisitors(app *application.App) {
+	if app.Config.Visitor.FlushInterval <= 0 {
+		app.Logger.Info("Visitor Flusher disabled, visitor count written directly")
+		return
+	}
+
+	dep := application.SetupDependencyInjection(app)
+
+	ticker := time.NewTicker(time.Second * time.Duration(app.Config.Visitor.FlushInterval))
+
+	app.Logger.Info("Flushing Visitor Count every ", app.Config.Visitor.FlushInterval, " seconds.....")
+
+	go func() {
+		defer ticker.Stop()
+
+		for range ticker.C {
+			err := dep.ShortController.ProcessFlushVisitorCount(app.Context)
+			if err != nil {
+				app.Logger.Error("ProcessFlushVisitorCount ERROR, ", err)
+			}
+		}
+	}()
+}
@@ -0,0 +1,68 @@
+package model
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	// ClickDimensionDay etc. are dimensions used for aggregating clicks into daily rollups
+	ClickDimensionDay      = "day"
+	ClickDimensionReferrer = "referrer"
+	ClickDimensionCountry  = "country"
+	ClickDimensionDevice   = "device"
+
+	// ClickUnknown used when country / device of clicks cannot be resolved
+	ClickUnknown = "unknown"
+	// ClickDirect used as referrer when clicks not coming from another page
+	ClickDirect = "direct"
+
+	// ClickStepVisitor & ClickStepRollups are counters applied by click, recorded on the click so redelivered
+	// click never counted twice
+	ClickStepVisitor = "visitor"
+	ClickStepRollups = "rollups"
+)
+
+type (
+	// ClickEvent consist data of single click on short url
+	ClickEvent struct {
+		ID             primitive.ObjectID `bson:"_id"`
+		ShortURL       string             `bson:"short_url"`
+		ClickedAt      time.Time          `bson:"clicked_at"`
+		Referrer       string             `bson:"referrer"`
+		UserAgent      string             `bson:"user_agent"`
+		Device         string             `bson:"device"`
+		Country        string             `bson:"country"`
+		AcceptLanguage string             `bson:"accept_language"`
+		Applied        []string           `bson:"applied,omitempty"`
+	}
+
+	// ClickRollup consist count of clicks for a single value of dimension
+	ClickRollup struct {
+		Dimension string `bson:"dimension"`
+		Value     string `bson:"value"`
+		Count     int64  `bson:"count"`
+	}
+
+	ClickStatsRequest struct {
+		ID     string     `json:"id"`
+		UserID string     `json:"user_id"`
+		From   *time.Time `json:"from"`
+		To     *time.Time `json:"to"`
+	}
+
+	ClickStat struct {
+		Key   string `json:"key"`
+		Count int64  `json:"count"`
+	}
+
+	ClickStatsResponse struct {
+		ShortURL   string      `json:"short_url"`
+		Total      int64       `json:"total"`
+		ByDay      []ClickStat `json:"by_day"`
+		ByReferrer []ClickStat `json:"by_referrer"`
+		ByCountry  []ClickStat `json:"by_country"`
+		ByDevice   []ClickStat `json:"by_device"`
+	}
+)
@@ -1,5 +1,18 @@
 package model
 
 const (
-	KeyShortURL = "short_url:%s"
+	KeyShortURL       = "short_url:%s"
+	KeyShortURLClicks = "short_url_clicks:%s"
+	KeyUnlockCookie   = "unlock_token"
+
+	// KeyShortURLUnlockAttempts counting failed unlock attempts of short url per client IP until locked out
+	KeyShortURLUnlockAttempts = "short_url_unlock_attempts:%s:%s"
+
+	// KeyShortURLVisited buffering visitor count of short url until flushed into database,
+	// KeyShortURLVisitedPending is set of short url having buffered visitor count
+	KeyShortURLVisited        = "short_url_visited:%s"
+	KeyShortURLVisitedPending = "short_url_visited_pending"
+
+	// KeyShortURLQRCode caching rendered QR code per short url & render options
+	KeyShortURLQRCode = "short_url_qr:%s:%s"
 )
@@ -6,6 +6,13 @@ import (
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
+const (
+	// ShortFieldExpiresAt etc. are limits of short able to be removed when updating short
+	ShortFieldExpiresAt = "expires_at"
+	ShortFieldMaxClicks = "max_clicks"
+	ShortFieldPassword  = "password"
+)
+
 type (
 	Short struct {
 		ID        primitive.ObjectID `bson:"_id"`
@@ -13,16 +20,64 @@ type (
 		FullURL   string             `bson:"full_url"`
 		ShortURL  string             `bson:"short_url"`
 		Visited   int64              `bson:"visited"`
+		ExpiresAt *time.Time         `bson:"expires_at"`
+		MaxClicks int64              `bson:"max_clicks"`
+		Password  string             `bson:"password,omitempty"`
+		Tags      []string           `bson:"tags,omitempty"`
 		CreatedAt time.Time          `bson:"created_at"`
 		UpdatedAt *time.Time         `bson:"updated_at"`
 	}
 
+	// CachedShort consist data of short stored in cache for redirecting
+	CachedShort struct {
+		FullURL   string     `json:"full_url"`
+		ExpiresAt *time.Time `json:"expires_at,omitempty"`
+		MaxClicks int64      `json:"max_clicks,omitempty"`
+		Password  string     `json:"password,omitempty"`
+	}
+
+	ListShortRequest struct {
+		UserID    string `json:"user_id"`
+		Page      int64  `json:"page"`
+		PageSize  int64  `json:"page_size"`
+		SortBy    string `json:"sort_by"`
+		SortOrder string `json:"sort_order"`
+		Search    string `json:"search"`
+		Tag       string `json:"tag"`
+	}
+
+	ListShortResponse struct {
+		Shorts   []Short `json:"shorts"`
+		Total    int64   `json:"total"`
+		Page     int64   `json:"page"`
+		PageSize int64   `json:"page_size"`
+	}
+
 	CreateShortRequest struct {
-		UserID   string `json:"user_id"`
-		FullURL  string `json:"full_url"`
+		UserID       string     `json:"user_id"`
+		FullURL      string     `json:"full_url"`
+		ShortURL     string     `json:"short_url"`
+		IsCustom     bool       `json:"is_custom"`
+		ExpiresAt    *time.Time `json:"expires_at"`
+		MaxClicks    int64      `json:"max_clicks"`
+		Password     string     `json:"password"`
+		PasswordHash string     `json:"-"`
+		Tags         []string   `json:"tags"`
+	}
+
+	CreateShortResponse struct {
 		ShortURL string `json:"short_url"`
 	}
 
+	ClickShortRequest struct {
+		ShortURL       string `json:"short_url"`
+		UnlockToken    string `json:"unlock_token"`
+		Referrer       string `json:"referrer"`
+		UserAgent      string `json:"user_agent"`
+		IP             string `json:"ip"`
+		AcceptLanguage string `json:"accept_language"`
+	}
+
 	ClickShortResponse struct {
 		FullURL string `json:"full_url"`
 	}
@@ -31,12 +86,46 @@ type (
 		ShortURL string `json:"short_url"`
 	}
 
+	// UpdateShortRequest only updates non nil fields, fields listed on Clear removed from short
 	UpdateShortRequest struct {
-		ID      string `json:"id"`
-		FullURL string `json:"full_url"`
+		ID           string     `json:"id"`
+		UserID       string     `json:"user_id"`
+		FullURL      *string    `json:"full_url"`
+		ExpiresAt    *time.Time `json:"expires_at"`
+		MaxClicks    *int64     `json:"max_clicks"`
+		Password     *string    `json:"password"`
+		PasswordHash string     `json:"-"`
+		Clear        []string   `json:"clear"`
 	}
 
 	DeleteShortRequest struct {
-		ID string `json:"id"`
+		ID     string `json:"id"`
+		UserID string `json:"user_id"`
+	}
+
+	UnlockShortRequest struct {
+		ShortURL string `json:"-"`
+		ClientIP string `json:"-"`
+		Password string `json:"password" form:"password"`
+	}
+
+	UnlockShortResponse struct {
+		Token     string    `json:"token"`
+		ExpiresAt time.Time `json:"expires_at"`
+	}
+
+	QRCodeRequest struct {
+		ShortURL   string `json:"-"`
+		Format     string `query:"format"`
+		Size       int    `query:"size"`
+		Level      string `query:"level"`
+		Margin     *int   `query:"margin"`
+		Foreground string `query:"fg"`
+		Background string `query:"bg"`
+	}
+
+	QRCodeResponse struct {
+		ContentType string
+		Image       []byte
 	}
 )
@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/PickHD/singkatin-revamp/shortener/internal/v1/config"
+	"github.com/PickHD/singkatin-revamp/shortener/internal/v1/helper"
 	"github.com/redis/go-redis/v9"
 	"github.com/sirupsen/logrus"
 	"go.mongodb.org/mongo-driver/mongo"
@@ -13,46 +14,71 @@ import (
 type (
 	// HealthCheckRepository is an interface that has all the function to be implemented inside health check repository
 	HealthCheckRepository interface {
-		Check() (bool, error)
+		PingDB(ctx context.Context) error
+		PingRedis(ctx context.Context) error
+		PingRabbitMQ(ctx context.Context) error
 	}
 
 	// HealthCheckRepositoryImpl is an app health check struct that consists of all the dependencies needed for health check repository
 	HealthCheckRepositoryImpl struct {
-		Context context.Context
-		Config  *config.Configuration
-		Logger  *logrus.Logger
-		Tracer  *trace.TracerProvider
-		DB      *mongo.Database
-		Redis   *redis.Client
+		Context  context.Context
+		Config   *config.Configuration
+		Logger   *logrus.Logger
+		Tracer   *trace.TracerProvider
+		DB       *mongo.Database
+		Redis    *redis.Client
+		RabbitMQ *helper.Broker
 	}
 )
 
 // NewHealthCheckRepository return new instances health check repository
-func NewHealthCheckRepository(ctx context.Context, config *config.Configuration, logger *logrus.Logger, tracer *trace.TracerProvider, db *mongo.Database, redis *redis.Client) *HealthCheckRepositoryImpl {
+func NewHealthCheckRepository(ctx context.Context, config *config.Configuration, logger *logrus.Logger, tracer *trace.TracerProvider, db *mongo.Database, redis *redis.Client, amqp *helper.Broker) *HealthCheckRepositoryImpl {
 	return &HealthCheckRepositoryImpl{
-		Context: ctx,
-		Config:  config,
-		Logger:  logger,
-		Tracer:  tracer,
-		DB:      db,
-		Redis:   redis,
+		Context:  ctx,
+		Config:   config,
+		Logger:   logger,
+		Tracer:   tracer,
+		DB:       db,
+		Redis:    redis,
+		RabbitMQ: amqp,
 	}
 }
 
-func (hr *HealthCheckRepositoryImpl) Check() (bool, error) {
-	tr := hr.Tracer.Tracer("Shortener-Check Repository")
-	_, span := tr.Start(hr.Context, "Start Check")
+func (hr *HealthCheckRepositoryImpl) PingDB(ctx context.Context) error {
+	tr := hr.Tracer.Tracer("Shortener-PingDB Repository")
+	ctx, span := tr.Start(ctx, "Start PingDB")
 	defer span.End()
 
-	if err := hr.DB.Client().Ping(hr.Context, nil); err != nil {
-		hr.Logger.Error("HealthCheckRepositoryImpl.Check() Ping DB ERROR, ", err)
-		return false, nil
+	if err := hr.DB.Client().Ping(ctx, nil); err != nil {
+		hr.Logger.Error("HealthCheckRepositoryImpl.PingDB ERROR, ", err)
+		return err
 	}
 
-	if err := hr.Redis.Ping(hr.Context).Err(); err != nil {
-		hr.Logger.Error("HealthCheckRepositoryImpl.Check() Ping Redis ERROR, ", err)
-		return false, nil
+	return nil
+}
+
+func (hr *HealthCheckRepositoryImpl) PingRedis(ctx context.Context) error {
+	tr := hr.Tracer.Tracer("Shortener-PingRedis Repository")
+	ctx, span := tr.Start(ctx, "Start PingRedis")
+	defer span.End()
+
+	if err := hr.Redis.Ping(ctx).Err(); err != nil {
+		hr.Logger.Error("HealthCheckRepositoryImpl.PingRedis ERROR, ", err)
+		return err
+	}
+
+	return nil
+}
+
+func (hr *HealthCheckRepositoryImpl) PingRabbitMQ(ctx context.Context) error {
+	tr := hr.Tracer.Tracer("Shortener-PingRabbitMQ Repository")
+	_, span := tr.Start(ctx, "Start PingRabbitMQ")
+	defer span.End()
+
+	if err := hr.RabbitMQ.Ping(); err != nil {
+		hr.Logger.Error("HealthCheckRepositoryImpl.PingRabbitMQ ERROR, ", err)
+		return err
 	}
 
-	return true, nil
+	return nil
 }
@@ -2,12 +2,16 @@ package repository
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"net"
 	"time"
 
 	"github.com/PickHD/singkatin-revamp/shortener/internal/v1/config"
+	"github.com/PickHD/singkatin-revamp/shortener/internal/v1/helper"
 	"github.com/PickHD/singkatin-revamp/shortener/internal/v1/model"
 	shortenerpb "github.com/PickHD/singkatin-revamp/shortener/pkg/api/v1/proto/shortener"
+	"github.com/oschwald/geoip2-golang"
 	"github.com/redis/go-redis/v9"
 	"github.com/sirupsen/logrus"
 	"github.com/streadway/amqp"
@@ -22,17 +26,37 @@ import (
 type (
 	// ShortRepository is an interface that has all the function to be implemented inside short repository
 	ShortRepository interface {
-		GetListShortenerByUserID(ctx context.Context, userID string) ([]model.Short, error)
+		GetListShortenerByUserID(ctx context.Context, req *model.ListShortRequest) ([]model.Short, int64, error)
+		IterateShortenerByUserID(ctx context.Context, userID string, fn func(short *model.Short) error) error
 		Create(ctx context.Context, req *model.Short) error
 		GetByShortURL(ctx context.Context, shortURL string) (*model.Short, error)
-		GetFullURLByKey(ctx context.Context, shortURL string) (string, error)
+		GetCachedShortByKey(ctx context.Context, shortURL string) (*model.CachedShort, error)
 		GetByID(ctx context.Context, ID string) (*model.Short, error)
-		SetFullURLByKey(ctx context.Context, shortURL string, fullURL string, duration time.Duration) error
-		PublishUpdateVisitorCount(ctx context.Context, req *model.UpdateVisitorRequest) error
-		UpdateVisitorByShortURL(ctx context.Context, req *model.UpdateVisitorRequest, lastVisitedCount int64) error
+		SetCachedShortByKey(ctx context.Context, shortURL string, data *model.CachedShort, duration time.Duration) error
+		SetClicksByKey(ctx context.Context, shortURL string, clicks int64, duration time.Duration) error
+		IncrClicksByKey(ctx context.Context, shortURL string) (int64, error)
+		PublishClickEvent(ctx context.Context, req *model.ClickEvent) error
+		IncrVisitorByShortURLs(ctx context.Context, visitors map[string]int64) error
+		IncrVisitorByKey(ctx context.Context, shortURL string) error
+		GetVisitorByKey(ctx context.Context, shortURL string) (int64, error)
+		PopVisitorsByKey(ctx context.Context, count int) (map[string]int64, int, error)
+		RestoreVisitorsByKey(ctx context.Context, visitors map[string]int64) error
 		UpdateFullURLByID(ctx context.Context, req *model.UpdateShortRequest) error
 		DeleteByID(ctx context.Context, req *model.DeleteShortRequest) error
-		DeleteFullURLByKey(ctx context.Context, shortURL string) error
+		DeleteCachedShortByKey(ctx context.Context, shortURL string) error
+		DeleteClicksByKey(ctx context.Context, shortURL string) error
+		GetCountryByIP(ctx context.Context, ip string) (string, error)
+		CreateClick(ctx context.Context, req *model.ClickEvent) (*model.ClickEvent, error)
+		MarkClickAppliedByID(ctx context.Context, clickID primitive.ObjectID, step string) error
+		IncrClickRollups(ctx context.Context, shortURL string, day time.Time, rollups []model.ClickRollup) error
+		AggregateClickRollups(ctx context.Context, shortURL string, from, to time.Time) ([]model.ClickRollup, error)
+		DeleteClicksByShortURL(ctx context.Context, shortURL string) error
+		GetCachedQRCodeByKey(ctx context.Context, shortURL string, options string) ([]byte, error)
+		SetCachedQRCodeByKey(ctx context.Context, shortURL string, options string, image []byte, duration time.Duration) error
+		DeleteCachedQRCodeByKey(ctx context.Context, shortURL string) error
+		GetUnlockAttemptsByKey(ctx context.Context, shortURL string, clientIP string) (int64, time.Duration, error)
+		IncrUnlockAttemptsByKey(ctx context.Context, shortURL string, clientIP string, lockout time.Duration) (int64, error)
+		DeleteUnlockAttemptsByKey(ctx context.Context, shortURL string, clientIP string) error
 	}
 
 	// ShortRepositoryImpl is an app short struct that consists of all the dependencies needed for short repository
@@ -43,12 +67,16 @@ type (
 		Tracer   *trace.TracerProvider
 		DB       *mongo.Database
 		Redis    *redis.Client
-		RabbitMQ *amqp.Channel
+		RabbitMQ *helper.Broker
+		GeoIP    *geoip2.Reader
 	}
 )
 
+// iterateBatchSize is documents fetched per round trip when iterating shorteners
+const iterateBatchSize = 500
+
 // NewShortRepository return new instances short repository
-func NewShortRepository(ctx context.Context, config *config.Configuration, logger *logrus.Logger, tracer *trace.TracerProvider, db *mongo.Database, rds *redis.Client, amqp *amqp.Channel) *ShortRepositoryImpl {
+func NewShortRepository(ctx context.Context, config *config.Configuration, logger *logrus.Logger, tracer *trace.TracerProvider, db *mongo.Database, rds *redis.Client, amqp *helper.Broker, geoIP *geoip2.Reader) *ShortRepositoryImpl {
 	return &ShortRepositoryImpl{
 		Context:  ctx,
 		Config:   config,
@@ -57,22 +85,47 @@ func NewShortRepository(ctx context.Context, config *config.Configuration, logge
 		DB:       db,
 		Redis:    rds,
 		RabbitMQ: amqp,
+		GeoIP:    geoIP,
 	}
 }
 
-func (sr *ShortRepositoryImpl) GetListShortenerByUserID(ctx context.Context, userID string) ([]model.Short, error) {
+func (sr *ShortRepositoryImpl) GetListShortenerByUserID(ctx context.Context, req *model.ListShortRequest) ([]model.Short, int64, error) {
 	tr := sr.Tracer.Tracer("Shortener-GetListShortenerByUserID Repository")
 	ctx, span := tr.Start(ctx, "Start GetListShortenerByUserID")
 	defer span.End()
 
 	shorts := []model.Short{}
 
-	cur, err := sr.DB.Collection(sr.Config.Database.ShortenersCollection).Find(ctx,
-		bson.D{{Key: "user_id", Value: userID}},
-		options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}, {Key: "_id", Value: -1}}))
+	filter := bson.D{{Key: "user_id", Value: req.UserID}}
+
+	if req.Tag != "" {
+		filter = append(filter, bson.E{Key: "tags", Value: req.Tag})
+	}
+
+	// backed by text index of full_url & short_url
+	if req.Search != "" {
+		filter = append(filter, bson.E{Key: "$text", Value: bson.D{{Key: "$search", Value: req.Search}}})
+	}
+
+	total, err := sr.DB.Collection(sr.Config.Database.ShortenersCollection).CountDocuments(ctx, filter)
+	if err != nil {
+		sr.Logger.Error("ShortRepositoryImpl.GetListShortenerByUserID CountDocuments ERROR, ", err)
+		return nil, 0, err
+	}
+
+	order := -1
+	if req.SortOrder == "asc" {
+		order = 1
+	}
+
+	cur, err := sr.DB.Collection(sr.Config.Database.ShortenersCollection).Find(ctx, filter,
+		options.Find().
+			SetSort(bson.D{{Key: req.SortBy, Value: order}, {Key: "_id", Value: order}}).
+			SetSkip((req.Page-1)*req.PageSize).
+			SetLimit(req.PageSize))
 	if err != nil {
 		sr.Logger.Error("ShortRepositoryImpl.GetListShortenerByUserID Find ERROR, ", err)
-		return nil, err
+		return nil, 0, err
 	}
 
 	for cur.Next(ctx) {
@@ -88,10 +141,48 @@ func (sr *ShortRepositoryImpl) GetListShortenerByUserID(ctx context.Context, use
 
 	if err := cur.Err(); err != nil {
 		sr.Logger.Error("ShortRepositoryImpl.GetListShortenerByUserID Cursors ERROR, ", err)
-		return nil, err
+		return nil, 0, err
+	}
+
+	return shorts, total, nil
+}
+
+func (sr *ShortRepositoryImpl) IterateShortenerByUserID(ctx context.Context, userID string, fn func(short *model.Short) error) error {
+	tr := sr.Tracer.Tracer("Shortener-IterateShortenerByUserID Repository")
+	ctx, span := tr.Start(ctx, "Start IterateShortenerByUserID")
+	defer span.End()
+
+	// documents fetched batch by batch, so never loaded entirely into memory
+	cur, err := sr.DB.Collection(sr.Config.Database.ShortenersCollection).Find(ctx,
+		bson.D{{Key: "user_id", Value: userID}},
+		options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}, {Key: "_id", Value: -1}}).SetBatchSize(iterateBatchSize))
+	if err != nil {
+		sr.Logger.Error("ShortRepositoryImpl.IterateShortenerByUserID Find ERROR, ", err)
+		return err
+	}
+	defer cur.Close(ctx)
+
+	for cur.Next(ctx) {
+		var short model.Short
+
+		err := cur.Decode(&short)
+		if err != nil {
+			sr.Logger.Error("ShortRepositoryImpl.IterateShortenerByUserID Decode ERROR, ", err)
+			return err
+		}
+
+		err = fn(&short)
+		if err != nil {
+			return err
+		}
+	}
+
+	if err := cur.Err(); err != nil {
+		sr.Logger.Error("ShortRepositoryImpl.IterateShortenerByUserID Cursors ERROR, ", err)
+		return err
 	}
 
-	return shorts, nil
+	return nil
 }
 
 func (sr *ShortRepositoryImpl) Create(ctx context.Context, req *model.Short) error {
@@ -103,8 +194,16 @@ func (sr *ShortRepositoryImpl) Create(ctx context.Context, req *model.Short) err
 		bson.D{{Key: "full_url", Value: req.FullURL},
 			{Key: "user_id", Value: req.UserID},
 			{Key: "short_url", Value: req.ShortURL},
+			{Key: "expires_at", Value: req.ExpiresAt},
+			{Key: "max_clicks", Value: req.MaxClicks},
+			{Key: "password", Value: req.Password},
+			{Key: "tags", Value: req.Tags},
 			{Key: "visited", Value: 0}, {Key: "created_at", Value: time.Now()}})
 	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return model.NewError(model.Conflict, fmt.Sprintf("short_url %s already exists", req.ShortURL))
+		}
+
 		sr.Logger.Error("ShortRepositoryImpl.Create InsertOne ERROR, ", err)
 		return err
 	}
@@ -132,19 +231,28 @@ func (sr *ShortRepositoryImpl) GetByShortURL(ctx context.Context, shortURL strin
 	return short, nil
 }
 
-func (sr *ShortRepositoryImpl) GetFullURLByKey(ctx context.Context, shortURL string) (string, error) {
-	tr := sr.Tracer.Tracer("Shortener-GetFullURLByKey Repository")
-	ctx, span := tr.Start(ctx, "Start GetFullURLByKey")
+func (sr *ShortRepositoryImpl) GetCachedShortByKey(ctx context.Context, shortURL string) (*model.CachedShort, error) {
+	tr := sr.Tracer.Tracer("Shortener-GetCachedShortByKey Repository")
+	ctx, span := tr.Start(ctx, "Start GetCachedShortByKey")
 	defer span.End()
 
 	result := sr.Redis.Get(ctx, fmt.Sprintf(model.KeyShortURL, shortURL))
 	if result.Err() != nil {
-		sr.Logger.Error("ShortRepositoryImpl.GetFullURLByKey Get ERROR, ", result.Err())
+		sr.Logger.Error("ShortRepositoryImpl.GetCachedShortByKey Get ERROR, ", result.Err())
 
-		return "", result.Err()
+		return nil, result.Err()
 	}
 
-	return result.Val(), nil
+	cached := &model.CachedShort{}
+
+	err := json.Unmarshal([]byte(result.Val()), cached)
+	if err != nil {
+		sr.Logger.Error("ShortRepositoryImpl.GetCachedShortByKey Unmarshal ERROR, ", err)
+
+		return nil, err
+	}
+
+	return cached, nil
 }
 
 func (sr *ShortRepositoryImpl) GetByID(ctx context.Context, id string) (*model.Short, error) {
@@ -155,7 +263,7 @@ func (sr *ShortRepositoryImpl) GetByID(ctx context.Context, id string) (*model.S
 	objShortID, err := primitive.ObjectIDFromHex(id)
 	if err != nil {
 		sr.Logger.Error("ShortRepositoryImpl.GetByID primitive.ObjectIDFromHex ERROR, ", err)
-		return nil, err
+		return nil, model.NewError(model.NotFound, "short_url not found")
 	}
 
 	short := &model.Short{}
@@ -173,14 +281,21 @@ func (sr *ShortRepositoryImpl) GetByID(ctx context.Context, id string) (*model.S
 	return short, nil
 }
 
-func (sr *ShortRepositoryImpl) SetFullURLByKey(ctx context.Context, shortURL string, fullURL string, duration time.Duration) error {
-	tr := sr.Tracer.Tracer("Shortener-SetFullURLByKey Repository")
-	ctx, span := tr.Start(ctx, "Start SetFullURLByKey")
+func (sr *ShortRepositoryImpl) SetCachedShortByKey(ctx context.Context, shortURL string, data *model.CachedShort, duration time.Duration) error {
+	tr := sr.Tracer.Tracer("Shortener-SetCachedShortByKey Repository")
+	ctx, span := tr.Start(ctx, "Start SetCachedShortByKey")
 	defer span.End()
 
-	err := sr.Redis.SetEx(ctx, fmt.Sprintf(model.KeyShortURL, shortURL), fullURL, duration).Err()
+	b, err := json.Marshal(data)
+	if err != nil {
+		sr.Logger.Error("ShortRepositoryImpl.SetCachedShortByKey Marshal ERROR, ", err)
+
+		return err
+	}
+
+	err = sr.Redis.SetEx(ctx, fmt.Sprintf(model.KeyShortURL, shortURL), b, duration).Err()
 	if err != nil {
-		sr.Logger.Error("ShortRepositoryImpl.SetFullURLByKey SetEx ERROR, ", err)
+		sr.Logger.Error("ShortRepositoryImpl.SetCachedShortByKey SetEx ERROR, ", err)
 
 		return err
 	}
@@ -188,23 +303,55 @@ func (sr *ShortRepositoryImpl) SetFullURLByKey(ctx context.Context, shortURL str
 	return nil
 }
 
-func (sr *ShortRepositoryImpl) PublishUpdateVisitorCount(ctx context.Context, req *model.UpdateVisitorRequest) error {
-	tr := sr.Tracer.Tracer("Shortener-PublishUpdateVisitorCount Repository")
-	_, span := tr.Start(ctx, "Start PublishUpdateVisitorCount")
+func (sr *ShortRepositoryImpl) SetClicksByKey(ctx context.Context, shortURL string, clicks int64, duration time.Duration) error {
+	tr := sr.Tracer.Tracer("Shortener-SetClicksByKey Repository")
+	ctx, span := tr.Start(ctx, "Start SetClicksByKey")
+	defer span.End()
+
+	// only initialize when counter not exists yet, so ongoing clicks not overwritten
+	err := sr.Redis.SetNX(ctx, fmt.Sprintf(model.KeyShortURLClicks, shortURL), clicks, duration).Err()
+	if err != nil {
+		sr.Logger.Error("ShortRepositoryImpl.SetClicksByKey SetNX ERROR, ", err)
+
+		return err
+	}
+
+	return nil
+}
+
+func (sr *ShortRepositoryImpl) IncrClicksByKey(ctx context.Context, shortURL string) (int64, error) {
+	tr := sr.Tracer.Tracer("Shortener-IncrClicksByKey Repository")
+	ctx, span := tr.Start(ctx, "Start IncrClicksByKey")
+	defer span.End()
+
+	result := sr.Redis.Incr(ctx, fmt.Sprintf(model.KeyShortURLClicks, shortURL))
+	if result.Err() != nil {
+		sr.Logger.Error("ShortRepositoryImpl.IncrClicksByKey Incr ERROR, ", result.Err())
+
+		return 0, result.Err()
+	}
+
+	return result.Val(), nil
+}
+
+func (sr *ShortRepositoryImpl) PublishClickEvent(ctx context.Context, req *model.ClickEvent) error {
+	tr := sr.Tracer.Tracer("Shortener-PublishClickEvent Repository")
+	ctx, span := tr.Start(ctx, "Start PublishClickEvent")
 	defer span.End()
 
 	sr.Logger.Info("data req before publish", req)
 
 	// transform data to proto
-	msg := sr.prepareProtoPublishUpdateVisitorCountMessage(req)
+	msg := sr.prepareProtoPublishClickEventMessage(req)
 
 	b, err := proto.Marshal(msg)
 	if err != nil {
-		sr.Logger.Error("ShortRepositoryImpl.PublishUpdateVisitorCount Marshal proto UpdateVisitorCountMessage ERROR, ", err)
+		sr.Logger.Error("ShortRepositoryImpl.PublishClickEvent Marshal proto ClickEventMessage ERROR, ", err)
 		return err
 	}
 
 	message := amqp.Publishing{
+		Headers:     helper.InjectTraceContext(ctx, nil),
 		ContentType: "text/plain",
 		Body:        []byte(b),
 	}
@@ -217,26 +364,144 @@ func (sr *ShortRepositoryImpl) PublishUpdateVisitorCount(ctx context.Context, re
 		false,                                 // immediate
 		message,                               // message to publish
 	); err != nil {
-		sr.Logger.Error("ShortRepositoryImpl.PublishUpdateVisitorCount RabbitMQ.Publish ERROR, ", err)
+		sr.Logger.Error("ShortRepositoryImpl.PublishClickEvent RabbitMQ.Publish ERROR, ", err)
 		return err
 	}
 
-	sr.Logger.Info("Success Publish Update Visitor Count to Queue: ", sr.Config.RabbitMQ.QueueUpdateVisitor)
+	sr.Logger.Info("Success Publish Click Event to Queue: ", sr.Config.RabbitMQ.QueueUpdateVisitor)
 
 	return nil
 }
 
-func (sr *ShortRepositoryImpl) UpdateVisitorByShortURL(ctx context.Context, req *model.UpdateVisitorRequest, lastVisitedCount int64) error {
-	tr := sr.Tracer.Tracer("Shortener-UpdateVisitorByShortURL Repository")
-	ctx, span := tr.Start(ctx, "Start UpdateVisitorByShortURL")
+func (sr *ShortRepositoryImpl) IncrVisitorByShortURLs(ctx context.Context, visitors map[string]int64) error {
+	tr := sr.Tracer.Tracer("Shortener-IncrVisitorByShortURLs Repository")
+	ctx, span := tr.Start(ctx, "Start IncrVisitorByShortURLs")
 	defer span.End()
 
-	_, err := sr.DB.Collection(sr.Config.Database.ShortenersCollection).UpdateOne(ctx,
-		bson.D{{Key: "short_url", Value: req.ShortURL}}, bson.M{
-			"$set": bson.D{{Key: "visited", Value: lastVisitedCount + 1}, {Key: "updated_at", Value: time.Now()}},
-		})
+	if len(visitors) < 1 {
+		return nil
+	}
+
+	now := time.Now()
+
+	writes := make([]mongo.WriteModel, 0, len(visitors))
+	for shortURL, count := range visitors {
+		writes = append(writes, mongo.NewUpdateOneModel().
+			SetFilter(bson.D{{Key: "short_url", Value: shortURL}}).
+			SetUpdate(bson.M{
+				"$inc": bson.D{{Key: "visited", Value: count}},
+				"$set": bson.D{{Key: "updated_at", Value: now}},
+			}))
+	}
+
+	_, err := sr.DB.Collection(sr.Config.Database.ShortenersCollection).BulkWrite(ctx, writes, options.BulkWrite().SetOrdered(false))
 	if err != nil {
-		sr.Logger.Error("ShortRepositoryImpl.UpdateVisitorByShortURL UpdateOne ERROR, ", err)
+		sr.Logger.Error("ShortRepositoryImpl.IncrVisitorByShortURLs BulkWrite ERROR, ", err)
+		return err
+	}
+
+	return nil
+}
+
+func (sr *ShortRepositoryImpl) IncrVisitorByKey(ctx context.Context, shortURL string) error {
+	tr := sr.Tracer.Tracer("Shortener-IncrVisitorByKey Repository")
+	ctx, span := tr.Start(ctx, "Start IncrVisitorByKey")
+	defer span.End()
+
+	pipe := sr.Redis.TxPipeline()
+	pipe.Incr(ctx, fmt.Sprintf(model.KeyShortURLVisited, shortURL))
+	pipe.SAdd(ctx, model.KeyShortURLVisitedPending, shortURL)
+
+	_, err := pipe.Exec(ctx)
+	if err != nil {
+		sr.Logger.Error("ShortRepositoryImpl.IncrVisitorByKey Exec ERROR, ", err)
+
+		return err
+	}
+
+	return nil
+}
+
+func (sr *ShortRepositoryImpl) GetVisitorByKey(ctx context.Context, shortURL string) (int64, error) {
+	tr := sr.Tracer.Tracer("Shortener-GetVisitorByKey Repository")
+	ctx, span := tr.Start(ctx, "Start GetVisitorByKey")
+	defer span.End()
+
+	visited, err := sr.Redis.Get(ctx, fmt.Sprintf(model.KeyShortURLVisited, shortURL)).Int64()
+	if err != nil {
+		if err == redis.Nil {
+			return 0, nil
+		}
+
+		sr.Logger.Error("ShortRepositoryImpl.GetVisitorByKey Get ERROR, ", err)
+
+		return 0, err
+	}
+
+	return visited, nil
+}
+
+func (sr *ShortRepositoryImpl) PopVisitorsByKey(ctx context.Context, count int) (map[string]int64, int, error) {
+	tr := sr.Tracer.Tracer("Shortener-PopVisitorsByKey Repository")
+	ctx, span := tr.Start(ctx, "Start PopVisitorsByKey")
+	defer span.End()
+
+	shortURLs, err := sr.Redis.SPopN(ctx, model.KeyShortURLVisitedPending, int64(count)).Result()
+	if err != nil {
+		sr.Logger.Error("ShortRepositoryImpl.PopVisitorsByKey SPopN ERROR, ", err)
+
+		return nil, 0, err
+	}
+
+	// GETDEL is atomic, clicks arrived afterwards buffered again on new counter
+	pipe := sr.Redis.Pipeline()
+
+	cmds := make(map[string]*redis.StringCmd, len(shortURLs))
+	for _, shortURL := range shortURLs {
+		cmds[shortURL] = pipe.GetDel(ctx, fmt.Sprintf(model.KeyShortURLVisited, shortURL))
+	}
+
+	_, err = pipe.Exec(ctx)
+	if err != nil && err != redis.Nil {
+		sr.Logger.Error("ShortRepositoryImpl.PopVisitorsByKey Exec ERROR, ", err)
+
+		// put back popped short url, so counter flushed on next round
+		if len(shortURLs) > 0 {
+			sr.Redis.SAdd(ctx, model.KeyShortURLVisitedPending, shortURLs)
+		}
+
+		return nil, 0, err
+	}
+
+	visitors := make(map[string]int64, len(cmds))
+	for shortURL, cmd := range cmds {
+		visited, err := cmd.Int64()
+		if err != nil || visited < 1 {
+			continue
+		}
+
+		visitors[shortURL] = visited
+	}
+
+	// popped count returned as well, since short url without buffered clicks left out of visitors
+	return visitors, len(shortURLs), nil
+}
+
+func (sr *ShortRepositoryImpl) RestoreVisitorsByKey(ctx context.Context, visitors map[string]int64) error {
+	tr := sr.Tracer.Tracer("Shortener-RestoreVisitorsByKey Repository")
+	ctx, span := tr.Start(ctx, "Start RestoreVisitorsByKey")
+	defer span.End()
+
+	pipe := sr.Redis.TxPipeline()
+	for shortURL, count := range visitors {
+		pipe.IncrBy(ctx, fmt.Sprintf(model.KeyShortURLVisited, shortURL), count)
+		pipe.SAdd(ctx, model.KeyShortURLVisitedPending, shortURL)
+	}
+
+	_, err := pipe.Exec(ctx)
+	if err != nil {
+		sr.Logger.Error("ShortRepositoryImpl.RestoreVisitorsByKey Exec ERROR, ", err)
+
 		return err
 	}
 
@@ -254,10 +519,38 @@ func (sr *ShortRepositoryImpl) UpdateFullURLByID(ctx context.Context, req *model
 		return err
 	}
 
+	// only given fields updated, so omitted ones kept as is
+	set := bson.D{{Key: "updated_at", Value: time.Now()}}
+
+	if req.FullURL != nil {
+		set = append(set, bson.E{Key: "full_url", Value: *req.FullURL})
+	}
+
+	if req.ExpiresAt != nil {
+		set = append(set, bson.E{Key: "expires_at", Value: req.ExpiresAt})
+	}
+
+	if req.MaxClicks != nil {
+		set = append(set, bson.E{Key: "max_clicks", Value: *req.MaxClicks})
+	}
+
+	if req.Password != nil {
+		set = append(set, bson.E{Key: "password", Value: *req.Password})
+	}
+
+	update := bson.M{"$set": set}
+
+	if len(req.Clear) > 0 {
+		unset := bson.D{}
+		for _, field := range req.Clear {
+			unset = append(unset, bson.E{Key: field, Value: ""})
+		}
+
+		update["$unset"] = unset
+	}
+
 	_, err = sr.DB.Collection(sr.Config.Database.ShortenersCollection).UpdateOne(ctx,
-		bson.D{{Key: "_id", Value: objShortID}}, bson.M{
-			"$set": bson.D{{Key: "full_url", Value: req.FullURL}, {Key: "updated_at", Value: time.Now()}},
-		})
+		bson.D{{Key: "_id", Value: objShortID}, {Key: "user_id", Value: req.UserID}}, update)
 	if err != nil {
 		sr.Logger.Error("ShortRepositoryImpl.UpdateFullURLByID UpdateOne ERROR, ", err)
 		return err
@@ -278,7 +571,7 @@ func (sr *ShortRepositoryImpl) DeleteByID(ctx context.Context, req *model.Delete
 	}
 
 	_, err = sr.DB.Collection(sr.Config.Database.ShortenersCollection).DeleteOne(ctx,
-		bson.D{{Key: "_id", Value: objShortID}})
+		bson.D{{Key: "_id", Value: objShortID}, {Key: "user_id", Value: req.UserID}})
 	if err != nil {
 		sr.Logger.Error("ShortRepositoryImpl.DeleteByID DeleteOne ERROR, ", err)
 		return err
@@ -287,14 +580,308 @@ func (sr *ShortRepositoryImpl) DeleteByID(ctx context.Context, req *model.Delete
 	return nil
 }
 
-func (sr *ShortRepositoryImpl) DeleteFullURLByKey(ctx context.Context, shortURL string) error {
-	tr := sr.Tracer.Tracer("Shortener-DeleteFullURLByKey Repository")
-	ctx, span := tr.Start(ctx, "Start DeleteFullURLByKey")
+func (sr *ShortRepositoryImpl) DeleteCachedShortByKey(ctx context.Context, shortURL string) error {
+	tr := sr.Tracer.Tracer("Shortener-DeleteCachedShortByKey Repository")
+	ctx, span := tr.Start(ctx, "Start DeleteCachedShortByKey")
 	defer span.End()
 
 	err := sr.Redis.Del(ctx, fmt.Sprintf(model.KeyShortURL, shortURL)).Err()
 	if err != nil {
-		sr.Logger.Error("ShortRepositoryImpl.DeleteFullURLByKey Del ERROR, ", err)
+		sr.Logger.Error("ShortRepositoryImpl.DeleteCachedShortByKey Del ERROR, ", err)
+
+		return err
+	}
+
+	return nil
+}
+
+func (sr *ShortRepositoryImpl) DeleteClicksByKey(ctx context.Context, shortURL string) error {
+	tr := sr.Tracer.Tracer("Shortener-DeleteClicksByKey Repository")
+	ctx, span := tr.Start(ctx, "Start DeleteClicksByKey")
+	defer span.End()
+
+	err := sr.Redis.Del(ctx, fmt.Sprintf(model.KeyShortURLClicks, shortURL)).Err()
+	if err != nil {
+		sr.Logger.Error("ShortRepositoryImpl.DeleteClicksByKey Del ERROR, ", err)
+
+		return err
+	}
+
+	return nil
+}
+
+func (sr *ShortRepositoryImpl) GetCountryByIP(ctx context.Context, ip string) (string, error) {
+	tr := sr.Tracer.Tracer("Shortener-GetCountryByIP Repository")
+	_, span := tr.Start(ctx, "Start GetCountryByIP")
+	defer span.End()
+
+	parsedIP := net.ParseIP(ip)
+	if sr.GeoIP == nil || parsedIP == nil {
+		return model.ClickUnknown, nil
+	}
+
+	country, err := sr.GeoIP.Country(parsedIP)
+	if err != nil {
+		sr.Logger.Error("ShortRepositoryImpl.GetCountryByIP GeoIP.Country ERROR, ", err)
+		return model.ClickUnknown, err
+	}
+
+	if country.Country.IsoCode == "" {
+		return model.ClickUnknown, nil
+	}
+
+	return country.Country.IsoCode, nil
+}
+
+func (sr *ShortRepositoryImpl) CreateClick(ctx context.Context, req *model.ClickEvent) (*model.ClickEvent, error) {
+	tr := sr.Tracer.Tracer("Shortener-CreateClick Repository")
+	ctx, span := tr.Start(ctx, "Start CreateClick")
+	defer span.End()
+
+	click := model.ClickEvent{}
+
+	// inserted once per click id, redelivered click returning existing one along with its applied counters
+	err := sr.DB.Collection(sr.Config.Database.ClicksCollection).FindOneAndUpdate(ctx,
+		bson.D{{Key: "_id", Value: req.ID}},
+		bson.M{"$setOnInsert": bson.D{{Key: "short_url", Value: req.ShortURL},
+			{Key: "clicked_at", Value: req.ClickedAt},
+			{Key: "referrer", Value: req.Referrer},
+			{Key: "user_agent", Value: req.UserAgent},
+			{Key: "device", Value: req.Device},
+			{Key: "country", Value: req.Country},
+			{Key: "accept_language", Value: req.AcceptLanguage}}},
+		options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)).Decode(&click)
+	if err != nil {
+		sr.Logger.Error("ShortRepositoryImpl.CreateClick FindOneAndUpdate ERROR, ", err)
+		return nil, err
+	}
+
+	return &click, nil
+}
+
+func (sr *ShortRepositoryImpl) MarkClickAppliedByID(ctx context.Context, clickID primitive.ObjectID, step string) error {
+	tr := sr.Tracer.Tracer("Shortener-MarkClickAppliedByID Repository")
+	ctx, span := tr.Start(ctx, "Start MarkClickAppliedByID")
+	defer span.End()
+
+	_, err := sr.DB.Collection(sr.Config.Database.ClicksCollection).UpdateOne(ctx,
+		bson.D{{Key: "_id", Value: clickID}},
+		bson.M{"$addToSet": bson.D{{Key: "applied", Value: step}}})
+	if err != nil {
+		sr.Logger.Error("ShortRepositoryImpl.MarkClickAppliedByID UpdateOne ERROR, ", err)
+		return err
+	}
+
+	return nil
+}
+
+func (sr *ShortRepositoryImpl) IncrClickRollups(ctx context.Context, shortURL string, day time.Time, rollups []model.ClickRollup) error {
+	tr := sr.Tracer.Tracer("Shortener-IncrClickRollups Repository")
+	ctx, span := tr.Start(ctx, "Start IncrClickRollups")
+	defer span.End()
+
+	writes := make([]mongo.WriteModel, 0, len(rollups))
+	for _, rollup := range rollups {
+		writes = append(writes, mongo.NewUpdateOneModel().
+			SetFilter(bson.D{{Key: "short_url", Value: shortURL},
+				{Key: "day", Value: day},
+				{Key: "dimension", Value: rollup.Dimension},
+				{Key: "value", Value: rollup.Value}}).
+			SetUpdate(bson.M{"$inc": bson.D{{Key: "count", Value: rollup.Count}}}).
+			SetUpsert(true))
+	}
+
+	_, err := sr.DB.Collection(sr.Config.Database.ClickRollupsCollection).BulkWrite(ctx, writes, options.BulkWrite().SetOrdered(false))
+	if err != nil {
+		sr.Logger.Error("ShortRepositoryImpl.IncrClickRollups BulkWrite ERROR, ", err)
+		return err
+	}
+
+	return nil
+}
+
+func (sr *ShortRepositoryImpl) AggregateClickRollups(ctx context.Context, shortURL string, from, to time.Time) ([]model.ClickRollup, error) {
+	tr := sr.Tracer.Tracer("Shortener-AggregateClickRollups Repository")
+	ctx, span := tr.Start(ctx, "Start AggregateClickRollups")
+	defer span.End()
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.D{{Key: "short_url", Value: shortURL},
+			{Key: "day", Value: bson.D{{Key: "$gte", Value: from}, {Key: "$lte", Value: to}}}}}},
+		{{Key: "$group", Value: bson.D{
+			{Key: "_id", Value: bson.D{{Key: "dimension", Value: "$dimension"}, {Key: "value", Value: "$value"}}},
+			{Key: "count", Value: bson.D{{Key: "$sum", Value: "$count"}}}}}},
+		{{Key: "$project", Value: bson.D{{Key: "_id", Value: 0},
+			{Key: "dimension", Value: "$_id.dimension"},
+			{Key: "value", Value: "$_id.value"},
+			{Key: "count", Value: 1}}}},
+	}
+
+	cur, err := sr.DB.Collection(sr.Config.Database.ClickRollupsCollection).Aggregate(ctx, pipeline)
+	if err != nil {
+		sr.Logger.Error("ShortRepositoryImpl.AggregateClickRollups Aggregate ERROR, ", err)
+		return nil, err
+	}
+
+	rollups := []model.ClickRollup{}
+
+	err = cur.All(ctx, &rollups)
+	if err != nil {
+		sr.Logger.Error("ShortRepositoryImpl.AggregateClickRollups Cursors ERROR, ", err)
+		return nil, err
+	}
+
+	return rollups, nil
+}
+
+func (sr *ShortRepositoryImpl) DeleteClicksByShortURL(ctx context.Context, shortURL string) error {
+	tr := sr.Tracer.Tracer("Shortener-DeleteClicksByShortURL Repository")
+	ctx, span := tr.Start(ctx, "Start DeleteClicksByShortURL")
+	defer span.End()
+
+	_, err := sr.DB.Collection(sr.Config.Database.ClicksCollection).DeleteMany(ctx, bson.D{{Key: "short_url", Value: shortURL}})
+	if err != nil {
+		sr.Logger.Error("ShortRepositoryImpl.DeleteClicksByShortURL Clicks DeleteMany ERROR, ", err)
+		return err
+	}
+
+	_, err = sr.DB.Collection(sr.Config.Database.ClickRollupsCollection).DeleteMany(ctx, bson.D{{Key: "short_url", Value: shortURL}})
+	if err != nil {
+		sr.Logger.Error("ShortRepositoryImpl.DeleteClicksByShortURL Rollups DeleteMany ERROR, ", err)
+		return err
+	}
+
+	return nil
+}
+
+func (sr *ShortRepositoryImpl) GetCachedQRCodeByKey(ctx context.Context, shortURL string, options string) ([]byte, error) {
+	tr := sr.Tracer.Tracer("Shortener-GetCachedQRCodeByKey Repository")
+	ctx, span := tr.Start(ctx, "Start GetCachedQRCodeByKey")
+	defer span.End()
+
+	result := sr.Redis.Get(ctx, fmt.Sprintf(model.KeyShortURLQRCode, shortURL, options))
+	if result.Err() != nil {
+		if result.Err() != redis.Nil {
+			sr.Logger.Error("ShortRepositoryImpl.GetCachedQRCodeByKey Get ERROR, ", result.Err())
+		}
+
+		return nil, result.Err()
+	}
+
+	return result.Bytes()
+}
+
+func (sr *ShortRepositoryImpl) SetCachedQRCodeByKey(ctx context.Context, shortURL string, options string, image []byte, duration time.Duration) error {
+	tr := sr.Tracer.Tracer("Shortener-SetCachedQRCodeByKey Repository")
+	ctx, span := tr.Start(ctx, "Start SetCachedQRCodeByKey")
+	defer span.End()
+
+	err := sr.Redis.SetEx(ctx, fmt.Sprintf(model.KeyShortURLQRCode, shortURL, options), image, duration).Err()
+	if err != nil {
+		sr.Logger.Error("ShortRepositoryImpl.SetCachedQRCodeByKey SetEx ERROR, ", err)
+
+		return err
+	}
+
+	return nil
+}
+
+func (sr *ShortRepositoryImpl) DeleteCachedQRCodeByKey(ctx context.Context, shortURL string) error {
+	tr := sr.Tracer.Tracer("Shortener-DeleteCachedQRCodeByKey Repository")
+	ctx, span := tr.Start(ctx, "Start DeleteCachedQRCodeByKey")
+	defer span.End()
+
+	// every render options cached on different key
+	iter := sr.Redis.Scan(ctx, 0, fmt.Sprintf(model.KeyShortURLQRCode, shortURL, "*"), 100).Iterator()
+	for iter.Next(ctx) {
+		err := sr.Redis.Del(ctx, iter.Val()).Err()
+		if err != nil {
+			sr.Logger.Error("ShortRepositoryImpl.DeleteCachedQRCodeByKey Del ERROR, ", err)
+
+			return err
+		}
+	}
+
+	if err := iter.Err(); err != nil {
+		sr.Logger.Error("ShortRepositoryImpl.DeleteCachedQRCodeByKey Scan ERROR, ", err)
+
+		return err
+	}
+
+	return nil
+}
+
+func (sr *ShortRepositoryImpl) GetUnlockAttemptsByKey(ctx context.Context, shortURL string, clientIP string) (int64, time.Duration, error) {
+	tr := sr.Tracer.Tracer("Shortener-GetUnlockAttemptsByKey Repository")
+	ctx, span := tr.Start(ctx, "Start GetUnlockAttemptsByKey")
+	defer span.End()
+
+	attemptsKey := fmt.Sprintf(model.KeyShortURLUnlockAttempts, shortURL, clientIP)
+
+	var (
+		count *redis.StringCmd
+		ttl   *redis.DurationCmd
+	)
+
+	_, err := sr.Redis.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		count = pipe.Get(ctx, attemptsKey)
+		ttl = pipe.PTTL(ctx, attemptsKey)
+
+		return nil
+	})
+	if err != nil && err != redis.Nil {
+		sr.Logger.Error("ShortRepositoryImpl.GetUnlockAttemptsByKey TxPipelined ERROR, ", err)
+
+		return 0, 0, err
+	}
+
+	if count.Err() == redis.Nil {
+		return 0, 0, nil
+	}
+
+	attempts, err := count.Int64()
+	if err != nil {
+		sr.Logger.Error("ShortRepositoryImpl.GetUnlockAttemptsByKey Int64 ERROR, ", err)
+
+		return 0, 0, err
+	}
+
+	return attempts, ttl.Val(), nil
+}
+
+func (sr *ShortRepositoryImpl) IncrUnlockAttemptsByKey(ctx context.Context, shortURL string, clientIP string, lockout time.Duration) (int64, error) {
+	tr := sr.Tracer.Tracer("Shortener-IncrUnlockAttemptsByKey Repository")
+	ctx, span := tr.Start(ctx, "Start IncrUnlockAttemptsByKey")
+	defer span.End()
+
+	attemptsKey := fmt.Sprintf(model.KeyShortURLUnlockAttempts, shortURL, clientIP)
+
+	var count *redis.IntCmd
+
+	// expiry extended every failure, so attempts only forgotten after lockout passed without failing again
+	_, err := sr.Redis.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		count = pipe.Incr(ctx, attemptsKey)
+		pipe.PExpire(ctx, attemptsKey, lockout)
+
+		return nil
+	})
+	if err != nil {
+		sr.Logger.Error("ShortRepositoryImpl.IncrUnlockAttemptsByKey TxPipelined ERROR, ", err)
+
+		return 0, err
+	}
+
+	return count.Val(), nil
+}
+
+func (sr *ShortRepositoryImpl) DeleteUnlockAttemptsByKey(ctx context.Context, shortURL string, clientIP string) error {
+	tr := sr.Tracer.Tracer("Shortener-DeleteUnlockAttemptsByKey Repository")
+	ctx, span := tr.Start(ctx, "Start DeleteUnlockAttemptsByKey")
+	defer span.End()
+
+	err := sr.Redis.Del(ctx, fmt.Sprintf(model.KeyShortURLUnlockAttempts, shortURL, clientIP)).Err()
+	if err != nil {
+		sr.Logger.Error("ShortRepositoryImpl.DeleteUnlockAttemptsByKey Del ERROR, ", err)
 
 		return err
 	}
@@ -302,8 +889,15 @@ func (sr *ShortRepositoryImpl) DeleteFullURLByKey(ctx context.Context, shortURL
 	return nil
 }
 
-func (sr *ShortRepositoryImpl) prepareProtoPublishUpdateVisitorCountMessage(req *model.UpdateVisitorRequest) *shortenerpb.UpdateVisitorCountMessage {
-	return &shortenerpb.UpdateVisitorCountMessage{
-		ShortUrl: req.ShortURL,
+func (sr *ShortRepositoryImpl) prepareProtoPublishClickEventMessage(req *model.ClickEvent) *shortenerpb.ClickEventMessage {
+	return &shortenerpb.ClickEventMessage{
+		EventId:        req.ID.Hex(),
+		ShortUrl:       req.ShortURL,
+		ClickedAt:      req.ClickedAt.Unix(),
+		Referrer:       req.Referrer,
+		UserAgent:      req.UserAgent,
+		Device:         req.Device,
+		Country:        req.Country,
+		AcceptLanguage: req.AcceptLanguage,
 	}
 }
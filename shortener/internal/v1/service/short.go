@@ -2,26 +2,40 @@ package service
 
 import (
 	"context"
+	"fmt"
+	"math"
 	"net/url"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/PickHD/singkatin-revamp/shortener/internal/v1/config"
+	"github.com/PickHD/singkatin-revamp/shortener/internal/v1/helper"
 	"github.com/PickHD/singkatin-revamp/shortener/internal/v1/model"
 	"github.com/PickHD/singkatin-revamp/shortener/internal/v1/repository"
 	"github.com/redis/go-redis/v9"
 	"github.com/sirupsen/logrus"
+	"github.com/skip2/go-qrcode"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.opentelemetry.io/otel/sdk/trace"
 )
 
 type (
 	// ShortService is an interface that has all the function to be implemented inside short service
 	ShortService interface {
-		GetListShortenerByUserID(ctx context.Context, userID string) ([]model.Short, error)
-		CreateShort(ctx context.Context, req *model.CreateShortRequest) error
-		ClickShort(shortURL string) (*model.ClickShortResponse, error)
+		GetListShortenerByUserID(ctx context.Context, req *model.ListShortRequest) (*model.ListShortResponse, error)
+		ExportShortenerByUserID(ctx context.Context, userID string, fn func(short *model.Short) error) error
+		CreateShort(ctx context.Context, req *model.CreateShortRequest) (*model.CreateShortResponse, error)
+		ClickShort(ctx context.Context, req *model.ClickShortRequest) (*model.ClickShortResponse, error)
+		UnlockShort(ctx context.Context, req *model.UnlockShortRequest) (*model.UnlockShortResponse, error)
 		UpdateVisitorShort(ctx context.Context, req *model.UpdateVisitorRequest) error
+		FlushVisitorShort(ctx context.Context) (int, error)
 		UpdateShort(ctx context.Context, req *model.UpdateShortRequest) error
 		DeleteShort(ctx context.Context, req *model.DeleteShortRequest) error
+		RecordClick(ctx context.Context, req *model.ClickEvent) error
+		GetClickStats(ctx context.Context, req *model.ClickStatsRequest) (*model.ClickStatsResponse, error)
+		GetQRCode(ctx context.Context, req *model.QRCodeRequest) (*model.QRCodeResponse, error)
 	}
 
 	// ShortServiceImpl is an app short struct that consists of all the dependencies needed for short repository
@@ -34,6 +48,54 @@ type (
 	}
 )
 
+const (
+	// shortURLLength is length of generated short url
+	shortURLLength = 8
+
+	// maxGenerateAttempts is maximum regenerating short url when collision happen
+	maxGenerateAttempts = 5
+
+	// minPasswordLength & maxPasswordLength is allowed length of short url password, bcrypt only use first 72 bytes
+	minPasswordLength = 4
+	maxPasswordLength = 72
+
+	// defaultPageSize & maxPageSize is number of shorts listed per page
+	defaultPageSize = 10
+	maxPageSize     = 100
+
+	// defaultStatsDays is range of click stats when not specified
+	defaultStatsDays = 30
+
+	// defaultFlushBatchSize is maximum short url flushed per bulk write when not configured
+	defaultFlushBatchSize = 500
+
+	// defaultQRMargin is quiet zone modules around QR code recommended by spec, maxQRMargin is its upper limit
+	defaultQRMargin = 4
+	maxQRMargin     = 16
+
+	qrFormatPNG = "png"
+	qrFormatSVG = "svg"
+
+	// defaultUnlockMaxAttempts & defaultUnlockLockout (minutes) limit failed unlock attempts when not configured
+	defaultUnlockMaxAttempts = 5
+	defaultUnlockLockout     = 15
+)
+
+// listSortFields is fields allowed for sorting list of shorts
+var listSortFields = map[string]bool{
+	"created_at": true,
+	"visited":    true,
+	"full_url":   true,
+}
+
+// qrLevels is error correction level of QR code, higher level readable even when damaged but denser
+var qrLevels = map[string]qrcode.RecoveryLevel{
+	"L": qrcode.Low,
+	"M": qrcode.Medium,
+	"Q": qrcode.High,
+	"H": qrcode.Highest,
+}
+
 // NewShortService return new instances short service
 func NewShortService(ctx context.Context, config *config.Configuration, logger *logrus.Logger, tracer *trace.TracerProvider, shortRepo repository.ShortRepository) *ShortServiceImpl {
 	return &ShortServiceImpl{
@@ -45,104 +107,371 @@ func NewShortService(ctx context.Context, config *config.Configuration, logger *
 	}
 }
 
-func (ss *ShortServiceImpl) GetListShortenerByUserID(ctx context.Context, userID string) ([]model.Short, error) {
+func (ss *ShortServiceImpl) GetListShortenerByUserID(ctx context.Context, req *model.ListShortRequest) (*model.ListShortResponse, error) {
 	tr := ss.Tracer.Tracer("Shortener-GetListShortenerByUserID Service")
 	ctx, span := tr.Start(ctx, "Start GetListShortenerByUserID")
 	defer span.End()
 
-	data, err := ss.ShortRepo.GetListShortenerByUserID(ctx, userID)
+	err := ss.validateListShort(req)
 	if err != nil {
 		return nil, err
 	}
 
-	return data, nil
+	data, total, err := ss.ShortRepo.GetListShortenerByUserID(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.ListShortResponse{
+		Shorts:   data,
+		Total:    total,
+		Page:     req.Page,
+		PageSize: req.PageSize,
+	}, nil
 }
 
-func (ss *ShortServiceImpl) CreateShort(ctx context.Context, req *model.CreateShortRequest) error {
+func (ss *ShortServiceImpl) ExportShortenerByUserID(ctx context.Context, userID string, fn func(short *model.Short) error) error {
+	tr := ss.Tracer.Tracer("Shortener-ExportShortenerByUserID Service")
+	ctx, span := tr.Start(ctx, "Start ExportShortenerByUserID")
+	defer span.End()
+
+	if userID == "" {
+		return model.NewError(model.Validation, "user id required")
+	}
+
+	return ss.ShortRepo.IterateShortenerByUserID(ctx, userID, fn)
+}
+
+func (ss *ShortServiceImpl) CreateShort(ctx context.Context, req *model.CreateShortRequest) (*model.CreateShortResponse, error) {
 	tr := ss.Tracer.Tracer("Shortener-CreateShort Service")
 	ctx, span := tr.Start(ctx, "Start CreateShort")
 	defer span.End()
 
 	err := ss.validateCreateShort(req)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	return ss.ShortRepo.Create(ctx, &model.Short{
-		FullURL:  req.FullURL,
-		ShortURL: req.ShortURL,
-		UserID:   req.UserID,
-	})
+	hashedPassword, err := ss.hashShortPassword(req.Password, req.PasswordHash)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.IsCustom {
+		err = ss.ShortRepo.Create(ctx, &model.Short{
+			FullURL:   req.FullURL,
+			ShortURL:  req.ShortURL,
+			UserID:    req.UserID,
+			ExpiresAt: req.ExpiresAt,
+			MaxClicks: req.MaxClicks,
+			Password:  hashedPassword,
+			Tags:      req.Tags,
+		})
+		if err != nil {
+			if model.IsKind(err, model.Conflict) {
+				return nil, (&model.Error{
+					Kind:    model.Conflict,
+					Code:    model.CodeAliasTaken,
+					Message: fmt.Sprintf("alias %s already taken", req.ShortURL),
+				}).WithDetail("short_url", req.ShortURL)
+			}
+
+			return nil, err
+		}
+
+		helper.LinksCreated.Inc()
+
+		return &model.CreateShortResponse{ShortURL: req.ShortURL}, nil
+	}
+
+	// generate short url, regenerate when collide with existing one
+	for i := 0; i < maxGenerateAttempts; i++ {
+		shortURL := helper.RandomStringBytesMaskImprSrcSB(shortURLLength)
+
+		err = ss.ShortRepo.Create(ctx, &model.Short{
+			FullURL:   req.FullURL,
+			ShortURL:  shortURL,
+			UserID:    req.UserID,
+			ExpiresAt: req.ExpiresAt,
+			MaxClicks: req.MaxClicks,
+			Password:  hashedPassword,
+			Tags:      req.Tags,
+		})
+		if err == nil {
+			helper.LinksCreated.Inc()
+
+			return &model.CreateShortResponse{ShortURL: shortURL}, nil
+		}
+
+		if !model.IsKind(err, model.Conflict) {
+			return nil, err
+		}
+
+		ss.Logger.Info("short url collision, regenerating....", shortURL)
+	}
+
+	return nil, model.NewError(model.Internal, "failed generate unique short url")
 }
 
-func (ss *ShortServiceImpl) ClickShort(shortURL string) (*model.ClickShortResponse, error) {
+func (ss *ShortServiceImpl) ClickShort(ctx context.Context, clickReq *model.ClickShortRequest) (*model.ClickShortResponse, error) {
 	var (
 		redisTTLDuration = time.Minute * time.Duration(ss.Config.Redis.TTL)
 	)
 
 	tr := ss.Tracer.Tracer("Shortener-ClickShort Service")
-	ctx, span := tr.Start(ss.Context, "Start ClickShort")
+	ctx, span := tr.Start(ctx, "Start ClickShort")
 	defer span.End()
 
-	req := &model.UpdateVisitorRequest{ShortURL: shortURL}
+	req := &model.UpdateVisitorRequest{ShortURL: clickReq.ShortURL}
 
 	err := ss.validateClickShort(req)
 	if err != nil {
 		return nil, err
 	}
 
-	cachedFullURL, err := ss.ShortRepo.GetFullURLByKey(ctx, req.ShortURL)
+	cached, err := ss.ShortRepo.GetCachedShortByKey(ctx, req.ShortURL)
 	if err != nil {
-		if err == redis.Nil {
-			ss.Logger.Info("get data from default databases....")
+		if err != redis.Nil {
+			return nil, err
+		}
 
-			data, err := ss.ShortRepo.GetByShortURL(ss.Context, req.ShortURL)
-			if err != nil {
-				return nil, err
+		helper.RedirectCache.WithLabelValues(helper.CacheMiss).Inc()
+
+		ss.Logger.Info("get data from default databases....")
+
+		data, err := ss.ShortRepo.GetByShortURL(ctx, req.ShortURL)
+		if err != nil {
+			return nil, err
+		}
+
+		cached = &model.CachedShort{
+			FullURL:   data.FullURL,
+			ExpiresAt: data.ExpiresAt,
+			MaxClicks: data.MaxClicks,
+			Password:  data.Password,
+		}
+
+		if cached.ExpiresAt != nil {
+			untilExpired := time.Until(*cached.ExpiresAt)
+			if untilExpired <= 0 {
+				return nil, model.NewError(model.Expired, "short url already expired")
 			}
 
-			err = ss.ShortRepo.SetFullURLByKey(ss.Context, req.ShortURL, data.FullURL, redisTTLDuration)
+			// never keep cache longer than the link itself
+			if untilExpired < redisTTLDuration {
+				redisTTLDuration = untilExpired
+			}
+		}
+
+		err = ss.ShortRepo.SetCachedShortByKey(ctx, req.ShortURL, cached, redisTTLDuration)
+		if err != nil {
+			return nil, err
+		}
+
+		if cached.MaxClicks > 0 {
+			// visitor count still buffered not yet written on database
+			buffered, err := ss.ShortRepo.GetVisitorByKey(ctx, req.ShortURL)
 			if err != nil {
 				return nil, err
 			}
 
-			err = ss.ShortRepo.PublishUpdateVisitorCount(ss.Context, req)
+			err = ss.ShortRepo.SetClicksByKey(ctx, req.ShortURL, data.Visited+buffered, ss.untilExpired(cached))
 			if err != nil {
 				return nil, err
 			}
-
-			return &model.ClickShortResponse{FullURL: data.FullURL}, nil
 		}
+	} else {
+		helper.RedirectCache.WithLabelValues(helper.CacheHit).Inc()
 
-		return nil, err
+		ss.Logger.Info("get data from caching....")
+	}
+
+	if cached.ExpiresAt != nil && time.Now().After(*cached.ExpiresAt) {
+		return nil, model.NewError(model.Expired, "short url already expired")
 	}
 
-	ss.Logger.Info("get data from caching....")
+	// protected short only redirecting when unlock token valid, so full url never leaked from cache
+	if cached.Password != "" && !helper.VerifyUnlockToken(ss.Config.Unlock.Secret, req.ShortURL, cached.Password, clickReq.UnlockToken) {
+		return nil, model.NewError(model.Protected, "short url protected by password")
+	}
+
+	if cached.MaxClicks > 0 {
+		clicks, err := ss.ShortRepo.IncrClicksByKey(ctx, req.ShortURL)
+		if err != nil {
+			return nil, err
+		}
 
-	err = ss.ShortRepo.PublishUpdateVisitorCount(ctx, req)
+		if clicks > cached.MaxClicks {
+			return nil, model.NewError(model.Expired, "short url already reach maximum clicks")
+		}
+	}
+
+	err = ss.ShortRepo.PublishClickEvent(ctx, ss.prepareClickEvent(ctx, clickReq))
 	if err != nil {
 		return nil, err
 	}
 
-	return &model.ClickShortResponse{FullURL: cachedFullURL}, nil
+	helper.Clicks.Inc()
+
+	return &model.ClickShortResponse{FullURL: cached.FullURL}, nil
 }
 
-func (ss *ShortServiceImpl) UpdateVisitorShort(ctx context.Context, req *model.UpdateVisitorRequest) error {
-	tr := ss.Tracer.Tracer("Shortener-UpdateVisitorShort Service")
-	ctx, span := tr.Start(ctx, "Start UpdateVisitorShort")
+func (ss *ShortServiceImpl) UnlockShort(ctx context.Context, req *model.UnlockShortRequest) (*model.UnlockShortResponse, error) {
+	tr := ss.Tracer.Tracer("Shortener-UnlockShort Service")
+	ctx, span := tr.Start(ctx, "Start UnlockShort")
 	defer span.End()
 
+	err := ss.validateClickShort(&model.UpdateVisitorRequest{ShortURL: req.ShortURL})
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Password == "" {
+		return nil, model.NewError(model.Validation, "password required")
+	}
+
+	err = ss.checkUnlockAttempts(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
 	data, err := ss.ShortRepo.GetByShortURL(ctx, req.ShortURL)
+	if err != nil {
+		return nil, err
+	}
+
+	if data.ExpiresAt != nil && time.Now().After(*data.ExpiresAt) {
+		return nil, model.NewError(model.Expired, "short url already expired")
+	}
+
+	if data.Password == "" {
+		return nil, model.NewError(model.Validation, "short url not protected by password")
+	}
+
+	if !helper.CheckPasswordHash(data.Password, req.Password) {
+		return nil, ss.failUnlock(ctx, req)
+	}
+
+	err = ss.ShortRepo.DeleteUnlockAttemptsByKey(ctx, req.ShortURL, req.ClientIP)
+	if err != nil {
+		return nil, err
+	}
+
+	expiresAt := time.Now().Add(time.Minute * time.Duration(ss.Config.Unlock.TTL))
+
+	return &model.UnlockShortResponse{
+		Token:     helper.SignUnlockToken(ss.Config.Unlock.Secret, data.ShortURL, data.Password, expiresAt),
+		ExpiresAt: expiresAt,
+	}, nil
+}
+
+// checkUnlockAttempts rejecting unlock of short url when client IP locked out by too many failed attempts
+func (ss *ShortServiceImpl) checkUnlockAttempts(ctx context.Context, req *model.UnlockShortRequest) error {
+	attempts, retryAfter, err := ss.ShortRepo.GetUnlockAttemptsByKey(ctx, req.ShortURL, req.ClientIP)
 	if err != nil {
 		return err
 	}
 
-	err = ss.ShortRepo.UpdateVisitorByShortURL(ctx, req, data.Visited)
+	if attempts < int64(ss.unlockMaxAttempts()) {
+		return nil
+	}
+
+	return unlockLockedError(retryAfter)
+}
+
+// failUnlock record failed unlock attempt, responding lockout instead of invalid password once reached maximum attempts
+func (ss *ShortServiceImpl) failUnlock(ctx context.Context, req *model.UnlockShortRequest) error {
+	lockout := ss.unlockLockout()
+
+	attempts, err := ss.ShortRepo.IncrUnlockAttemptsByKey(ctx, req.ShortURL, req.ClientIP, lockout)
 	if err != nil {
 		return err
 	}
 
-	return nil
+	if attempts < int64(ss.unlockMaxAttempts()) {
+		return model.NewError(model.Protected, "invalid password")
+	}
+
+	return unlockLockedError(lockout)
+}
+
+func (ss *ShortServiceImpl) unlockMaxAttempts() int {
+	if ss.Config.Unlock.MaxAttempts <= 0 {
+		return defaultUnlockMaxAttempts
+	}
+
+	return ss.Config.Unlock.MaxAttempts
+}
+
+func (ss *ShortServiceImpl) unlockLockout() time.Duration {
+	if ss.Config.Unlock.Lockout <= 0 {
+		return defaultUnlockLockout * time.Minute
+	}
+
+	return time.Duration(ss.Config.Unlock.Lockout) * time.Minute
+}
+
+// unlockLockedError return error of unlock attempts locked out, telling how long until allowed again
+func unlockLockedError(retryAfter time.Duration) error {
+	seconds := strconv.FormatInt(int64(math.Ceil(retryAfter.Seconds())), 10)
+
+	return (&model.Error{
+		Kind:    model.TooManyRequests,
+		Code:    model.ErrorCode(model.TooManyRequests),
+		Message: "too many failed unlock attempts, please retry after " + seconds + " seconds",
+	}).WithDetail(model.DetailRetryAfter, seconds)
+}
+
+func (ss *ShortServiceImpl) UpdateVisitorShort(ctx context.Context, req *model.UpdateVisitorRequest) error {
+	tr := ss.Tracer.Tracer("Shortener-UpdateVisitorShort Service")
+	ctx, span := tr.Start(ctx, "Start UpdateVisitorShort")
+	defer span.End()
+
+	// without flusher, increment directly on database
+	if ss.Config.Visitor.FlushInterval <= 0 {
+		return ss.ShortRepo.IncrVisitorByShortURLs(ctx, map[string]int64{req.ShortURL: 1})
+	}
+
+	return ss.ShortRepo.IncrVisitorByKey(ctx, req.ShortURL)
+}
+
+func (ss *ShortServiceImpl) FlushVisitorShort(ctx context.Context) (int, error) {
+	tr := ss.Tracer.Tracer("Shortener-FlushVisitorShort Service")
+	ctx, span := tr.Start(ctx, "Start FlushVisitorShort")
+	defer span.End()
+
+	var (
+		flushed   int
+		batchSize = ss.Config.Visitor.FlushBatchSize
+	)
+
+	if batchSize <= 0 {
+		batchSize = defaultFlushBatchSize
+	}
+
+	for {
+		visitors, popped, err := ss.ShortRepo.PopVisitorsByKey(ctx, batchSize)
+		if err != nil {
+			return flushed, err
+		}
+
+		err = ss.ShortRepo.IncrVisitorByShortURLs(ctx, visitors)
+		if err != nil {
+			// keep counts buffered, so it retried on next flush
+			if errRestore := ss.ShortRepo.RestoreVisitorsByKey(ctx, visitors); errRestore != nil {
+				ss.Logger.Error("failed restore visitor count, ", errRestore)
+			}
+
+			return flushed, err
+		}
+
+		flushed += len(visitors)
+
+		// pending set drained, visitors alone may be fewer since short url without counter skipped
+		if popped < batchSize {
+			return flushed, nil
+		}
+	}
 }
 
 func (ss *ShortServiceImpl) UpdateShort(ctx context.Context, req *model.UpdateShortRequest) error {
@@ -150,17 +479,48 @@ func (ss *ShortServiceImpl) UpdateShort(ctx context.Context, req *model.UpdateSh
 	ctx, span := tr.Start(ctx, "Start UpdateShort")
 	defer span.End()
 
-	if _, err := url.ParseRequestURI(req.FullURL); err != nil {
-		return model.NewError(model.Validation, err.Error())
+	err := ss.validateUpdateShort(req)
+	if err != nil {
+		return err
 	}
 
-	data, err := ss.ShortRepo.GetByID(ctx, req.ID)
+	var maxClicks int64
+	if req.MaxClicks != nil {
+		maxClicks = *req.MaxClicks
+	}
+
+	err = ss.validateLimit(req.ExpiresAt, maxClicks)
+	if err != nil {
+		return err
+	}
+
+	if req.Password != nil || req.PasswordHash != "" {
+		var password string
+		if req.Password != nil {
+			password = *req.Password
+		}
+
+		err = ss.validatePassword(password)
+		if err != nil {
+			return err
+		}
+
+		// only hashed password stored
+		password, err = ss.hashShortPassword(password, req.PasswordHash)
+		if err != nil {
+			return err
+		}
+
+		req.Password = &password
+	}
+
+	data, err := ss.getOwnedShort(ctx, req.ID, req.UserID)
 	if err != nil {
 		return err
 	}
 
 	// delete cache if any
-	err = ss.ShortRepo.DeleteFullURLByKey(ctx, data.ShortURL)
+	err = ss.ShortRepo.DeleteCachedShortByKey(ctx, data.ShortURL)
 	if err != nil {
 		return err
 	}
@@ -173,13 +533,28 @@ func (ss *ShortServiceImpl) DeleteShort(ctx context.Context, req *model.DeleteSh
 	ctx, span := tr.Start(ctx, "Start DeleteShort")
 	defer span.End()
 
-	data, err := ss.ShortRepo.GetByID(ctx, req.ID)
+	data, err := ss.getOwnedShort(ctx, req.ID, req.UserID)
 	if err != nil {
 		return err
 	}
 
 	// delete cache if any
-	err = ss.ShortRepo.DeleteFullURLByKey(ctx, data.ShortURL)
+	err = ss.ShortRepo.DeleteCachedShortByKey(ctx, data.ShortURL)
+	if err != nil {
+		return err
+	}
+
+	err = ss.ShortRepo.DeleteClicksByKey(ctx, data.ShortURL)
+	if err != nil {
+		return err
+	}
+
+	err = ss.ShortRepo.DeleteClicksByShortURL(ctx, data.ShortURL)
+	if err != nil {
+		return err
+	}
+
+	err = ss.ShortRepo.DeleteCachedQRCodeByKey(ctx, data.ShortURL)
 	if err != nil {
 		return err
 	}
@@ -187,21 +562,491 @@ func (ss *ShortServiceImpl) DeleteShort(ctx context.Context, req *model.DeleteSh
 	return ss.ShortRepo.DeleteByID(ctx, req)
 }
 
+// RecordClick will recording click & counting it, every counter applied once per click id so redelivered click
+// only applies counters not applied yet
+func (ss *ShortServiceImpl) RecordClick(ctx context.Context, req *model.ClickEvent) error {
+	tr := ss.Tracer.Tracer("Shortener-RecordClick Service")
+	ctx, span := tr.Start(ctx, "Start RecordClick")
+	defer span.End()
+
+	click, err := ss.ShortRepo.CreateClick(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	applied := make(map[string]bool, len(click.Applied))
+	for _, step := range click.Applied {
+		applied[step] = true
+	}
+
+	if !applied[model.ClickStepVisitor] {
+		err = ss.UpdateVisitorShort(ctx, &model.UpdateVisitorRequest{ShortURL: click.ShortURL})
+		if err != nil {
+			return err
+		}
+
+		err = ss.ShortRepo.MarkClickAppliedByID(ctx, click.ID, model.ClickStepVisitor)
+		if err != nil {
+			return err
+		}
+	}
+
+	if applied[model.ClickStepRollups] {
+		return nil
+	}
+
+	referrer := helper.ParseReferrerHost(click.Referrer)
+	if referrer == "" {
+		referrer = model.ClickDirect
+	}
+
+	day := click.ClickedAt.UTC().Truncate(24 * time.Hour)
+
+	// rollups kept forever, so stats still available after raw clicks expired
+	err = ss.ShortRepo.IncrClickRollups(ctx, click.ShortURL, day, []model.ClickRollup{
+		{Dimension: model.ClickDimensionDay, Value: day.Format("2006-01-02"), Count: 1},
+		{Dimension: model.ClickDimensionReferrer, Value: referrer, Count: 1},
+		{Dimension: model.ClickDimensionCountry, Value: click.Country, Count: 1},
+		{Dimension: model.ClickDimensionDevice, Value: click.Device, Count: 1},
+	})
+	if err != nil {
+		return err
+	}
+
+	return ss.ShortRepo.MarkClickAppliedByID(ctx, click.ID, model.ClickStepRollups)
+}
+
+func (ss *ShortServiceImpl) GetClickStats(ctx context.Context, req *model.ClickStatsRequest) (*model.ClickStatsResponse, error) {
+	tr := ss.Tracer.Tracer("Shortener-GetClickStats Service")
+	ctx, span := tr.Start(ctx, "Start GetClickStats")
+	defer span.End()
+
+	err := ss.validateClickStats(req)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := ss.ShortRepo.GetByID(ctx, req.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if data.UserID != req.UserID {
+		return nil, model.NewError(model.NotFound, "short_url not found")
+	}
+
+	rollups, err := ss.ShortRepo.AggregateClickRollups(ctx, data.ShortURL, *req.From, *req.To)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &model.ClickStatsResponse{
+		ShortURL:   data.ShortURL,
+		ByDay:      []model.ClickStat{},
+		ByReferrer: []model.ClickStat{},
+		ByCountry:  []model.ClickStat{},
+		ByDevice:   []model.ClickStat{},
+	}
+
+	for _, rollup := range rollups {
+		stat := model.ClickStat{Key: rollup.Value, Count: rollup.Count}
+
+		switch rollup.Dimension {
+		case model.ClickDimensionDay:
+			resp.Total += rollup.Count
+			resp.ByDay = append(resp.ByDay, stat)
+		case model.ClickDimensionReferrer:
+			resp.ByReferrer = append(resp.ByReferrer, stat)
+		case model.ClickDimensionCountry:
+			resp.ByCountry = append(resp.ByCountry, stat)
+		case model.ClickDimensionDevice:
+			resp.ByDevice = append(resp.ByDevice, stat)
+		}
+	}
+
+	sort.Slice(resp.ByDay, func(i, j int) bool { return resp.ByDay[i].Key < resp.ByDay[j].Key })
+	sortClickStats(resp.ByReferrer)
+	sortClickStats(resp.ByCountry)
+	sortClickStats(resp.ByDevice)
+
+	return resp, nil
+}
+
+func (ss *ShortServiceImpl) GetQRCode(ctx context.Context, req *model.QRCodeRequest) (*model.QRCodeResponse, error) {
+	tr := ss.Tracer.Tracer("Shortener-GetQRCode Service")
+	ctx, span := tr.Start(ctx, "Start GetQRCode")
+	defer span.End()
+
+	err := ss.validateClickShort(&model.UpdateVisitorRequest{ShortURL: req.ShortURL})
+	if err != nil {
+		return nil, err
+	}
+
+	err = ss.validateQRCode(req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &model.QRCodeResponse{ContentType: "image/png"}
+	if req.Format == qrFormatSVG {
+		resp.ContentType = "image/svg+xml"
+	}
+
+	options := fmt.Sprintf("%s:%d:%s:%d:%s:%s", req.Format, req.Size, req.Level, *req.Margin, req.Foreground, req.Background)
+
+	resp.Image, err = ss.ShortRepo.GetCachedQRCodeByKey(ctx, req.ShortURL, options)
+	if err == nil {
+		ss.Logger.Info("get QR code from caching....")
+
+		return resp, nil
+	}
+
+	if err != redis.Nil {
+		return nil, err
+	}
+
+	// only render QR code of existing short url
+	_, err = ss.ShortRepo.GetByShortURL(ctx, req.ShortURL)
+	if err != nil {
+		return nil, err
+	}
+
+	code, err := qrcode.New(fmt.Sprintf("%s/%s", ss.Config.QRCode.BaseURL, req.ShortURL), qrLevels[req.Level])
+	if err != nil {
+		return nil, model.WrapError(model.Internal, "failed generate QR code", err)
+	}
+
+	// margin drawn by renderer, so it configurable
+	code.DisableBorder = true
+
+	fg, _ := helper.ParseHexColor(req.Foreground)
+	bg, _ := helper.ParseHexColor(req.Background)
+
+	if req.Format == qrFormatSVG {
+		resp.Image = helper.RenderQRCodeSVG(code.Bitmap(), req.Size, *req.Margin, fg, bg)
+	} else {
+		resp.Image, err = helper.RenderQRCodePNG(code.Bitmap(), req.Size, *req.Margin, fg, bg)
+		if err != nil {
+			return nil, model.NewError(model.Validation, err.Error())
+		}
+	}
+
+	err = ss.ShortRepo.SetCachedQRCodeByKey(ctx, req.ShortURL, options, resp.Image, time.Minute*time.Duration(ss.Config.QRCode.CacheTTL))
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// validateQRCode will validating & defaulting render options of QR code
+func (ss *ShortServiceImpl) validateQRCode(req *model.QRCodeRequest) error {
+	req.Format = strings.ToLower(req.Format)
+	if req.Format == "" {
+		req.Format = qrFormatPNG
+	}
+
+	if req.Format != qrFormatPNG && req.Format != qrFormatSVG {
+		return model.NewError(model.Validation, "format must be png or svg")
+	}
+
+	if req.Size == 0 {
+		req.Size = ss.Config.QRCode.DefaultSize
+	}
+
+	if req.Size < 1 || req.Size > ss.Config.QRCode.MaxSize {
+		return model.NewError(model.Validation, fmt.Sprintf("size must between 1 and %d", ss.Config.QRCode.MaxSize))
+	}
+
+	req.Level = strings.ToUpper(req.Level)
+	if req.Level == "" {
+		req.Level = "M"
+	}
+
+	if _, ok := qrLevels[req.Level]; !ok {
+		return model.NewError(model.Validation, "level must be one of L, M, Q or H")
+	}
+
+	if req.Margin == nil {
+		margin := defaultQRMargin
+		req.Margin = &margin
+	}
+
+	if *req.Margin < 0 || *req.Margin > maxQRMargin {
+		return model.NewError(model.Validation, fmt.Sprintf("margin must between 0 and %d", maxQRMargin))
+	}
+
+	if req.Foreground == "" {
+		req.Foreground = "000000"
+	}
+
+	if req.Background == "" {
+		req.Background = "ffffff"
+	}
+
+	for _, c := range []*string{&req.Foreground, &req.Background} {
+		*c = strings.ToLower(strings.TrimPrefix(*c, "#"))
+
+		if _, err := helper.ParseHexColor(*c); err != nil {
+			return model.NewError(model.Validation, err.Error())
+		}
+	}
+
+	return nil
+}
+
+// prepareClickEvent will enrich click with device & country, raw ip never leaving this service
+func (ss *ShortServiceImpl) prepareClickEvent(ctx context.Context, clickReq *model.ClickShortRequest) *model.ClickEvent {
+	country, err := ss.ShortRepo.GetCountryByIP(ctx, clickReq.IP)
+	if err != nil {
+		country = model.ClickUnknown
+	}
+
+	return &model.ClickEvent{
+		ID:             primitive.NewObjectID(),
+		ShortURL:       clickReq.ShortURL,
+		ClickedAt:      time.Now(),
+		Referrer:       clickReq.Referrer,
+		UserAgent:      clickReq.UserAgent,
+		Device:         helper.ParseDevice(clickReq.UserAgent),
+		Country:        country,
+		AcceptLanguage: clickReq.AcceptLanguage,
+	}
+}
+
+// getOwnedShort will returning short by id only when it owned by user
+func (ss *ShortServiceImpl) getOwnedShort(ctx context.Context, id string, userID string) (*model.Short, error) {
+	if userID == "" {
+		return nil, model.NewError(model.Validation, "user id required")
+	}
+
+	data, err := ss.ShortRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if data.UserID != userID {
+		return nil, model.NewError(model.Forbidden, "short_url not owned by user")
+	}
+
+	return data, nil
+}
+
+// validateListShort will validating & defaulting pagination, sorting & filter of list shorts
+func (ss *ShortServiceImpl) validateListShort(req *model.ListShortRequest) error {
+	if req.UserID == "" {
+		return model.NewError(model.Validation, "user id required")
+	}
+
+	if req.Page < 1 {
+		req.Page = 1
+	}
+
+	if req.PageSize < 1 {
+		req.PageSize = defaultPageSize
+	}
+
+	if req.PageSize > maxPageSize {
+		return model.NewError(model.Validation, fmt.Sprintf("page_size must not exceed %d", maxPageSize))
+	}
+
+	if req.SortBy == "" {
+		req.SortBy = "created_at"
+	}
+
+	if !listSortFields[req.SortBy] {
+		return model.NewError(model.Validation, "sort_by must be one of created_at, visited or full_url")
+	}
+
+	req.SortOrder = strings.ToLower(req.SortOrder)
+	if req.SortOrder == "" {
+		req.SortOrder = "desc"
+	}
+
+	if req.SortOrder != "asc" && req.SortOrder != "desc" {
+		return model.NewError(model.Validation, "sort_order must be asc or desc")
+	}
+
+	req.Search = strings.TrimSpace(req.Search)
+	req.Tag = strings.ToLower(strings.TrimSpace(req.Tag))
+
+	return nil
+}
+
+// validateClickStats will validating & defaulting range of click stats into whole days
+func (ss *ShortServiceImpl) validateClickStats(req *model.ClickStatsRequest) error {
+	if req.ID == "" {
+		return model.NewError(model.Validation, "id required")
+	}
+
+	to := time.Now()
+	if req.To != nil {
+		to = *req.To
+	}
+
+	from := to.AddDate(0, 0, -defaultStatsDays)
+	if req.From != nil {
+		from = *req.From
+	}
+
+	from = from.UTC().Truncate(24 * time.Hour)
+	to = to.UTC().Truncate(24 * time.Hour)
+
+	req.From, req.To = &from, &to
+
+	if from.After(to) {
+		return model.NewError(model.Validation, "from must be before to")
+	}
+
+	return nil
+}
+
+// sortClickStats sort stats by highest count first
+func sortClickStats(stats []model.ClickStat) {
+	sort.Slice(stats, func(i, j int) bool {
+		if stats[i].Count == stats[j].Count {
+			return stats[i].Key < stats[j].Key
+		}
+
+		return stats[i].Count > stats[j].Count
+	})
+}
+
 func (ss *ShortServiceImpl) validateCreateShort(req *model.CreateShortRequest) error {
 	if _, err := url.ParseRequestURI(req.FullURL); err != nil {
 		return model.NewError(model.Validation, err.Error())
 	}
 
+	err := ss.validateLimit(req.ExpiresAt, req.MaxClicks)
+	if err != nil {
+		return err
+	}
+
+	err = ss.validatePassword(req.Password)
+	if err != nil {
+		return err
+	}
+
+	if req.IsCustom {
+		return ss.validateAlias(req.ShortURL)
+	}
+
 	return nil
 }
 
+// validateUpdateShort will validating fields given on update short, at least one of them updated or removed
+func (ss *ShortServiceImpl) validateUpdateShort(req *model.UpdateShortRequest) error {
+	if req.FullURL != nil {
+		if _, err := url.ParseRequestURI(*req.FullURL); err != nil {
+			return model.NewError(model.Validation, err.Error())
+		}
+	}
+
+	given := map[string]bool{
+		model.ShortFieldExpiresAt: req.ExpiresAt != nil,
+		model.ShortFieldMaxClicks: req.MaxClicks != nil,
+		model.ShortFieldPassword:  req.Password != nil || req.PasswordHash != "",
+	}
+
+	for _, field := range req.Clear {
+		set, ok := given[field]
+		if !ok {
+			return model.NewError(model.Validation, fmt.Sprintf("%s cannot be cleared", field))
+		}
+
+		if set {
+			return model.NewError(model.Validation, fmt.Sprintf("%s cannot be updated & cleared at once", field))
+		}
+	}
+
+	if req.FullURL == nil && len(req.Clear) == 0 && !given[model.ShortFieldExpiresAt] &&
+		!given[model.ShortFieldMaxClicks] && !given[model.ShortFieldPassword] {
+		return model.NewError(model.Validation, "nothing to update")
+	}
+
+	return nil
+}
+
+func (ss *ShortServiceImpl) validateLimit(expiresAt *time.Time, maxClicks int64) error {
+	if expiresAt != nil && !expiresAt.After(time.Now()) {
+		return model.NewError(model.Validation, "expires_at must be in the future")
+	}
+
+	if maxClicks < 0 {
+		return model.NewError(model.Validation, "max_clicks cannot be negative")
+	}
+
+	return nil
+}
+
+func (ss *ShortServiceImpl) validatePassword(password string) error {
+	if password == "" {
+		return nil
+	}
+
+	if len(password) < minPasswordLength || len(password) > maxPasswordLength {
+		return model.NewError(model.Validation, fmt.Sprintf("password length must between %d and %d", minPasswordLength, maxPasswordLength))
+	}
+
+	return nil
+}
+
+// hashShortPassword return bcrypt hash of password, empty when short not protected.
+// Password already hashed by user services stored as is
+func (ss *ShortServiceImpl) hashShortPassword(password string, passwordHash string) (string, error) {
+	if passwordHash != "" {
+		if !helper.IsPasswordHash(passwordHash) {
+			return "", model.NewError(model.Validation, "invalid password hash")
+		}
+
+		return passwordHash, nil
+	}
+
+	if password == "" {
+		return "", nil
+	}
+
+	return helper.HashPassword(password)
+}
+
+// untilExpired return remaining lifetime of short, zero means never expired
+func (ss *ShortServiceImpl) untilExpired(cached *model.CachedShort) time.Duration {
+	if cached.ExpiresAt == nil {
+		return 0
+	}
+
+	return time.Until(*cached.ExpiresAt)
+}
+
 func (ss *ShortServiceImpl) validateClickShort(req *model.UpdateVisitorRequest) error {
 	if req.ShortURL == "" {
 		return model.NewError(model.Validation, "short URL cannot be empty")
 	}
 
-	if len(req.ShortURL) != 8 {
-		return model.NewError(model.Validation, "short URL length must be 8")
+	// generated short URL always have fixed length, anything else must be a valid alias
+	if len(req.ShortURL) != shortURLLength {
+		return ss.validateAlias(req.ShortURL)
+	}
+
+	return nil
+}
+
+// validateAlias will checking custom alias against configured charset, length range & reserved words
+func (ss *ShortServiceImpl) validateAlias(alias string) error {
+	if len(alias) < ss.Config.Alias.MinLength || len(alias) > ss.Config.Alias.MaxLength {
+		return model.NewError(model.Validation, fmt.Sprintf("alias length must between %d and %d", ss.Config.Alias.MinLength, ss.Config.Alias.MaxLength))
+	}
+
+	for _, c := range alias {
+		if !strings.ContainsRune(ss.Config.Alias.Charset, c) {
+			return model.NewError(model.Validation, fmt.Sprintf("alias contains invalid character %q", c))
+		}
+	}
+
+	for _, w := range ss.Config.Alias.ReservedWords {
+		if strings.EqualFold(alias, w) {
+			return model.NewError(model.Validation, fmt.Sprintf("alias %s is reserved", alias))
+		}
 	}
 
 	return nil
@@ -23,6 +23,11 @@ const _ = grpc.SupportPackageIsVersion7
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type ShortenerServiceClient interface {
 	GetListShortenerByUserID(ctx context.Context, in *ListShortenerRequest, opts ...grpc.CallOption) (*ListShortenerResponse, error)
+	GetClickStatsByID(ctx context.Context, in *ClickStatsRequest, opts ...grpc.CallOption) (*ClickStatsResponse, error)
+	ExportShortenerByUserID(ctx context.Context, in *ListShortenerRequest, opts ...grpc.CallOption) (ShortenerService_ExportShortenerByUserIDClient, error)
+	CreateShortener(ctx context.Context, in *CreateShortenerMessage, opts ...grpc.CallOption) (*CreateShortenerReplyMessage, error)
+	UpdateShortener(ctx context.Context, in *UpdateShortenerMessage, opts ...grpc.CallOption) (*ShortenerReplyMessage, error)
+	DeleteShortener(ctx context.Context, in *DeleteShortenerMessage, opts ...grpc.CallOption) (*ShortenerReplyMessage, error)
 }
 
 type shortenerServiceClient struct {
@@ -42,11 +47,84 @@ func (c *shortenerServiceClient) GetListShortenerByUserID(ctx context.Context, i
 	return out, nil
 }
 
+func (c *shortenerServiceClient) GetClickStatsByID(ctx context.Context, in *ClickStatsRequest, opts ...grpc.CallOption) (*ClickStatsResponse, error) {
+	out := new(ClickStatsResponse)
+	err := c.cc.Invoke(ctx, "/api.v1.proto.shortener.ShortenerService/GetClickStatsByID", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shortenerServiceClient) ExportShortenerByUserID(ctx context.Context, in *ListShortenerRequest, opts ...grpc.CallOption) (ShortenerService_ExportShortenerByUserIDClient, error) {
+	stream, err := c.cc.NewStream(ctx, &ShortenerService_ServiceDesc.Streams[0], "/api.v1.proto.shortener.ShortenerService/ExportShortenerByUserID", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &shortenerServiceExportShortenerByUserIDClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type ShortenerService_ExportShortenerByUserIDClient interface {
+	Recv() (*Shortener, error)
+	grpc.ClientStream
+}
+
+type shortenerServiceExportShortenerByUserIDClient struct {
+	grpc.ClientStream
+}
+
+func (x *shortenerServiceExportShortenerByUserIDClient) Recv() (*Shortener, error) {
+	m := new(Shortener)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *shortenerServiceClient) CreateShortener(ctx context.Context, in *CreateShortenerMessage, opts ...grpc.CallOption) (*CreateShortenerReplyMessage, error) {
+	out := new(CreateShortenerReplyMessage)
+	err := c.cc.Invoke(ctx, "/api.v1.proto.shortener.ShortenerService/CreateShortener", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shortenerServiceClient) UpdateShortener(ctx context.Context, in *UpdateShortenerMessage, opts ...grpc.CallOption) (*ShortenerReplyMessage, error) {
+	out := new(ShortenerReplyMessage)
+	err := c.cc.Invoke(ctx, "/api.v1.proto.shortener.ShortenerService/UpdateShortener", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shortenerServiceClient) DeleteShortener(ctx context.Context, in *DeleteShortenerMessage, opts ...grpc.CallOption) (*ShortenerReplyMessage, error) {
+	out := new(ShortenerReplyMessage)
+	err := c.cc.Invoke(ctx, "/api.v1.proto.shortener.ShortenerService/DeleteShortener", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ShortenerServiceServer is the server API for ShortenerService service.
 // All implementations must embed UnimplementedShortenerServiceServer
 // for forward compatibility
 type ShortenerServiceServer interface {
 	GetListShortenerByUserID(context.Context, *ListShortenerRequest) (*ListShortenerResponse, error)
+	GetClickStatsByID(context.Context, *ClickStatsRequest) (*ClickStatsResponse, error)
+	ExportShortenerByUserID(*ListShortenerRequest, ShortenerService_ExportShortenerByUserIDServer) error
+	CreateShortener(context.Context, *CreateShortenerMessage) (*CreateShortenerReplyMessage, error)
+	UpdateShortener(context.Context, *UpdateShortenerMessage) (*ShortenerReplyMessage, error)
+	DeleteShortener(context.Context, *DeleteShortenerMessage) (*ShortenerReplyMessage, error)
 	mustEmbedUnimplementedShortenerServiceServer()
 }
 
@@ -57,6 +135,21 @@ type UnimplementedShortenerServiceServer struct {
 func (UnimplementedShortenerServiceServer) GetListShortenerByUserID(context.Context, *ListShortenerRequest) (*ListShortenerResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetListShortenerByUserID not implemented")
 }
+func (UnimplementedShortenerServiceServer) GetClickStatsByID(context.Context, *ClickStatsRequest) (*ClickStatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetClickStatsByID not implemented")
+}
+func (UnimplementedShortenerServiceServer) ExportShortenerByUserID(*ListShortenerRequest, ShortenerService_ExportShortenerByUserIDServer) error {
+	return status.Errorf(codes.Unimplemented, "method ExportShortenerByUserID not implemented")
+}
+func (UnimplementedShortenerServiceServer) CreateShortener(context.Context, *CreateShortenerMessage) (*CreateShortenerReplyMessage, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateShortener not implemented")
+}
+func (UnimplementedShortenerServiceServer) UpdateShortener(context.Context, *UpdateShortenerMessage) (*ShortenerReplyMessage, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateShortener not implemented")
+}
+func (UnimplementedShortenerServiceServer) DeleteShortener(context.Context, *DeleteShortenerMessage) (*ShortenerReplyMessage, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteShortener not implemented")
+}
 func (UnimplementedShortenerServiceServer) mustEmbedUnimplementedShortenerServiceServer() {}
 
 // UnsafeShortenerServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -88,6 +181,99 @@ func _ShortenerService_GetListShortenerByUserID_Handler(srv interface{}, ctx con
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ShortenerService_GetClickStatsByID_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ClickStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShortenerServiceServer).GetClickStatsByID(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.v1.proto.shortener.ShortenerService/GetClickStatsByID",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShortenerServiceServer).GetClickStatsByID(ctx, req.(*ClickStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShortenerService_ExportShortenerByUserID_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListShortenerRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ShortenerServiceServer).ExportShortenerByUserID(m, &shortenerServiceExportShortenerByUserIDServer{stream})
+}
+
+type ShortenerService_ExportShortenerByUserIDServer interface {
+	Send(*Shortener) error
+	grpc.ServerStream
+}
+
+type shortenerServiceExportShortenerByUserIDServer struct {
+	grpc.ServerStream
+}
+
+func (x *shortenerServiceExportShortenerByUserIDServer) Send(m *Shortener) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _ShortenerService_CreateShortener_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateShortenerMessage)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShortenerServiceServer).CreateShortener(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.v1.proto.shortener.ShortenerService/CreateShortener",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShortenerServiceServer).CreateShortener(ctx, req.(*CreateShortenerMessage))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShortenerService_UpdateShortener_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateShortenerMessage)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShortenerServiceServer).UpdateShortener(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.v1.proto.shortener.ShortenerService/UpdateShortener",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShortenerServiceServer).UpdateShortener(ctx, req.(*UpdateShortenerMessage))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShortenerService_DeleteShortener_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteShortenerMessage)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShortenerServiceServer).DeleteShortener(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.v1.proto.shortener.ShortenerService/DeleteShortener",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShortenerServiceServer).DeleteShortener(ctx, req.(*DeleteShortenerMessage))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // ShortenerService_ServiceDesc is the grpc.ServiceDesc for ShortenerService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -99,7 +285,29 @@ var ShortenerService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetListShortenerByUserID",
 			Handler:    _ShortenerService_GetListShortenerByUserID_Handler,
 		},
+		{
+			MethodName: "GetClickStatsByID",
+			Handler:    _ShortenerService_GetClickStatsByID_Handler,
+		},
+		{
+			MethodName: "CreateShortener",
+			Handler:    _ShortenerService_CreateShortener_Handler,
+		},
+		{
+			MethodName: "UpdateShortener",
+			Handler:    _ShortenerService_UpdateShortener_Handler,
+		},
+		{
+			MethodName: "DeleteShortener",
+			Handler:    _ShortenerService_DeleteShortener_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ExportShortenerByUserID",
+			Handler:       _ShortenerService_ExportShortenerByUserID_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "api/v1/proto/shortener/shortener.proto",
 }
@@ -11,7 +11,8 @@ import (
 )
 
 const (
-	consumerMode = "consumer"
+	consumerMode   = "consumer"
+	deadLetterMode = "dlq"
 )
 
 func main() {
@@ -51,6 +52,14 @@ func main() {
 			go infrastructure.ConsumeMessages(app, q)
 		}
 
+		infrastructure.ServeMetrics(app)
+
 		<-forever
+	case deadLetterMode:
+		// inspect / replay dead lettered messages, e.g. dlq list upload-avatar-queue 10
+		if err := infrastructure.ManageDeadLetters(app, args[1:]); err != nil {
+			app.Logger.Error("Failed manage dead letters. Error: ", err)
+			os.Exit(1)
+		}
 	}
 }
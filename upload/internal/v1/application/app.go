@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/PickHD/singkatin-revamp/upload/internal/v1/config"
+	"github.com/PickHD/singkatin-revamp/upload/internal/v1/helper"
 	"github.com/minio/minio-go/v7"
 	"github.com/minio/minio-go/v7/pkg/credentials"
 	"github.com/sirupsen/logrus"
@@ -12,17 +13,24 @@ import (
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/exporters/jaeger"
+	"go.opentelemetry.io/otel/propagation"
 	"go.opentelemetry.io/otel/sdk/resource"
 	"go.opentelemetry.io/otel/sdk/trace"
 	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
 )
 
+const (
+	// defaultMaxAttempts & defaultRetryBaseDelay (seconds) used when retry of consumed messages not configured
+	defaultMaxAttempts    = 5
+	defaultRetryBaseDelay = 5
+)
+
 // App ...
 type App struct {
 	Context  context.Context
 	Config   *config.Configuration
 	Logger   *logrus.Logger
-	RabbitMQ *amqp.Channel
+	RabbitMQ *helper.Broker
 	Tracer   *trace.TracerProvider
 	MinIO    *minio.Client
 }
@@ -53,36 +61,46 @@ func SetupApplication(ctx context.Context) (*App, error) {
 
 	otel.SetTracerProvider(app.Tracer)
 
-	amqpConn, err := amqp.Dial(app.Config.RabbitMQ.ConnURL)
-	if err != nil {
-		app.Logger.Error("failed dial RabbitMQ, error :", err)
-		return app, err
+	// trace context propagated through queue messages headers as W3C traceparent
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	if app.Config.RabbitMQ.MaxAttempts < 1 {
+		app.Config.RabbitMQ.MaxAttempts = defaultMaxAttempts
 	}
 
-	amqpClient, err := amqpConn.Channel()
-	if err != nil {
-		app.Logger.Error("failed open RabbitMQ Channels, error :", err)
-		return app, err
+	if app.Config.RabbitMQ.RetryBaseDelay < 1 {
+		app.Config.RabbitMQ.RetryBaseDelay = defaultRetryBaseDelay
 	}
 
 	queues := []string{app.Config.RabbitMQ.QueueUploadAvatar}
 
-	for _, q := range queues {
-		_, err = amqpClient.QueueDeclare(
-			q,     // queue name
-			true,  // durable
-			false, // auto delete
-			false, // exclusive
-			false, // no wait
-			nil,   // arguments
-		)
-		if err != nil {
-			app.Logger.Error("failed queue declare Channels, error :", err)
-			return nil, err
+	// topology re-declared every time connection recovered
+	app.RabbitMQ, err = helper.NewBroker(app.Config.RabbitMQ.ConnURL, app.Logger, app.Config.RabbitMQ.ChannelPoolSize, app.Config.RabbitMQ.PrefetchCount, func(ch *amqp.Channel) error {
+		for _, q := range queues {
+			_, err := ch.QueueDeclare(
+				q,     // queue name
+				true,  // durable
+				false, // auto delete
+				false, // exclusive
+				false, // no wait
+				nil,   // arguments
+			)
+			if err != nil {
+				return err
+			}
+
+			err = helper.DeclareRetryTopology(ch, q, app.Config.RabbitMQ.MaxAttempts, time.Duration(app.Config.RabbitMQ.RetryBaseDelay)*time.Second)
+			if err != nil {
+				return err
+			}
 		}
-	}
 
-	app.RabbitMQ = amqpClient
+		return nil
+	})
+	if err != nil {
+		app.Logger.Error("failed connect RabbitMQ, error :", err)
+		return nil, err
+	}
 
 	// initialize minIO client
 	minioClient, errInit := minio.New(app.Config.MinIO.Endpoint, &minio.Options{
@@ -117,6 +135,12 @@ func SetupApplication(ctx context.Context) (*App, error) {
 func (a *App) Close(ctx context.Context) {
 	a.Logger.Info("APP CLOSED SUCCESSFULLY")
 
+	if a.RabbitMQ != nil {
+		if err := a.RabbitMQ.Close(); err != nil {
+			a.Logger.Error("failed close RabbitMQ, error :", err)
+		}
+	}
+
 	defer func(ctx context.Context) {
 		ctx, cancel := context.WithTimeout(ctx, time.Second*5)
 		defer cancel()
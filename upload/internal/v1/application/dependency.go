@@ -7,20 +7,25 @@ import (
 )
 
 type Dependency struct {
-	UploadController controller.UploadController
+	HealthCheckController controller.HealthCheckController
+	UploadController      controller.UploadController
 }
 
 func SetupDependencyInjection(app *App) *Dependency {
 	// repository
+	healthCheckRepo := repository.NewHealthCheckRepository(app.Context, app.Config, app.Logger, app.Tracer, app.RabbitMQ, app.MinIO)
 	uploadRepo := repository.NewUploadRepository(app.Context, app.Config, app.Logger, app.Tracer, app.MinIO)
 
 	// service
+	healthCheckSvc := service.NewHealthCheckService(app.Context, app.Config, app.Tracer, healthCheckRepo)
 	uploadSvc := service.NewUploadService(app.Context, app.Config, app.Logger, app.Tracer, uploadRepo)
 
 	// controller
+	healthCheckController := controller.NewHealthCheckController(app.Context, app.Config, app.Tracer, healthCheckSvc)
 	uploadController := controller.NewUploadController(app.Context, app.Config, app.Logger, app.Tracer, uploadSvc)
 
 	return &Dependency{
-		UploadController: uploadController,
+		HealthCheckController: healthCheckController,
+		UploadController:      uploadController,
 	}
 }
@@ -18,15 +18,20 @@ type (
 	}
 
 	Server struct {
-		AppPort int
-		AppEnv  string
-		AppName string
-		AppID   string
+		AppPort     int
+		AppEnv      string
+		AppName     string
+		AppID       string
+		MetricsPort int
 	}
 
 	RabbitMQ struct {
 		ConnURL           string
 		QueueUploadAvatar string
+		MaxAttempts       int
+		RetryBaseDelay    int
+		PrefetchCount     int
+		ChannelPoolSize   int
 	}
 
 	Tracer struct {
@@ -49,14 +54,19 @@ func loadConfiguration() *Configuration {
 			GrpcPort: helper.GetEnvInt("GRPC_PORT"),
 		},
 		Server: &Server{
-			AppPort: helper.GetEnvInt("APP_PORT"),
-			AppEnv:  helper.GetEnvString("APP_ENV"),
-			AppName: helper.GetEnvString("APP_NAME"),
-			AppID:   helper.GetEnvString("APP_ID"),
+			AppPort:     helper.GetEnvInt("APP_PORT"),
+			AppEnv:      helper.GetEnvString("APP_ENV"),
+			AppName:     helper.GetEnvString("APP_NAME"),
+			AppID:       helper.GetEnvString("APP_ID"),
+			MetricsPort: helper.GetEnvInt("METRICS_PORT"),
 		},
 		RabbitMQ: &RabbitMQ{
 			ConnURL:           helper.GetEnvString("AMQP_SERVER_URL"),
 			QueueUploadAvatar: helper.GetEnvString("AMQP_QUEUE_UPLOAD_AVATAR"),
+			MaxAttempts:       helper.GetEnvInt("AMQP_MAX_ATTEMPTS"),
+			RetryBaseDelay:    helper.GetEnvInt("AMQP_RETRY_BASE_DELAY"),
+			PrefetchCount:     helper.GetEnvInt("AMQP_PREFETCH_COUNT"),
+			ChannelPoolSize:   helper.GetEnvInt("AMQP_CHANNEL_POOL_SIZE"),
 		},
 		Tracer: &Tracer{
 			JaegerURL: helper.GetEnvString("JAEGER_URL"),
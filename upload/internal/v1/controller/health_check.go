@@ -0,0 +1,67 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/PickHD/singkatin-revamp/upload/internal/v1/config"
+	"github.com/PickHD/singkatin-revamp/upload/internal/v1/model"
+	"github.com/PickHD/singkatin-revamp/upload/internal/v1/service"
+	"go.opentelemetry.io/otel/sdk/trace"
+)
+
+type (
+	// HealthCheckController is an interface that has all the function to be implemented inside health check controller
+	HealthCheckController interface {
+		Live(w http.ResponseWriter, r *http.Request)
+		Ready(w http.ResponseWriter, r *http.Request)
+	}
+
+	// HealthCheckControllerImpl is an app health check struct that consists of all the dependencies needed for health check controller
+	HealthCheckControllerImpl struct {
+		Context        context.Context
+		Config         *config.Configuration
+		Tracer         *trace.TracerProvider
+		HealthCheckSvc service.HealthCheckService
+	}
+)
+
+// NewHealthCheckController return new instances health check controller
+func NewHealthCheckController(ctx context.Context, config *config.Configuration, tracer *trace.TracerProvider, healthCheckSvc service.HealthCheckService) *HealthCheckControllerImpl {
+	return &HealthCheckControllerImpl{
+		Context:        ctx,
+		Config:         config,
+		Tracer:         tracer,
+		HealthCheckSvc: healthCheckSvc,
+	}
+}
+
+func (hc *HealthCheckControllerImpl) Live(w http.ResponseWriter, r *http.Request) {
+	tr := hc.Tracer.Tracer("Upload-Live Controller")
+	spanCtx, span := tr.Start(r.Context(), "Start Live")
+	defer span.End()
+
+	writeHealthReport(w, hc.HealthCheckSvc.Live(spanCtx))
+}
+
+func (hc *HealthCheckControllerImpl) Ready(w http.ResponseWriter, r *http.Request) {
+	tr := hc.Tracer.Tracer("Upload-Ready Controller")
+	spanCtx, span := tr.Start(r.Context(), "Start Ready")
+	defer span.End()
+
+	writeHealthReport(w, hc.HealthCheckSvc.Ready(spanCtx))
+}
+
+// writeHealthReport will responding report as JSON, service unavailable when any dependency down
+func writeHealthReport(w http.ResponseWriter, report *model.HealthReport) {
+	code := http.StatusOK
+	if report.Status != model.HealthUp {
+		code = http.StatusServiceUnavailable
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+
+	_ = json.NewEncoder(w).Encode(report)
+}
@@ -40,7 +40,7 @@ func NewUploadController(ctx context.Context, config *config.Configuration, logg
 
 func (uc *UploadControllerImpl) ProcessUploadAvatarUser(ctx context.Context, msg *uploadpb.UploadAvatarMessage) error {
 	tr := uc.Tracer.Tracer("Upload-ProcessUploadAvatarUser Controller")
-	_, span := tr.Start(uc.Context, "Start ProcessUploadAvatarUser")
+	ctx, span := tr.Start(ctx, "Start ProcessUploadAvatarUser")
 	defer span.End()
 
 	req := &model.UploadAvatarRequest{
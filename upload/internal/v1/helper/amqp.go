@@ -0,0 +1,219 @@
+package helper
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/streadway/amqp"
+)
+
+const (
+	// HeaderAttempts is header counting how many times message already failed to process
+	HeaderAttempts = "x-attempts"
+
+	// HeaderLastError is header describing latest error before message dead lettered
+	HeaderLastError = "x-last-error"
+)
+
+// DeadLetterExchange return name of exchange receiving exhausted messages of queue
+func DeadLetterExchange(queue string) string {
+	return queue + ".dlx"
+}
+
+// DeadLetterQueue return name of queue holding exhausted messages of queue
+func DeadLetterQueue(queue string) string {
+	return queue + ".dlq"
+}
+
+// RetryQueue return name of delay queue holding failed messages of queue before next attempt
+func RetryQueue(queue string, attempt int) string {
+	return fmt.Sprintf("%s.retry.%d", queue, attempt)
+}
+
+// RetryDelay return exponential backoff of attempt, doubled every attempt starting from base delay
+func RetryDelay(base time.Duration, attempt int) time.Duration {
+	return base << (attempt - 1)
+}
+
+// DeclareRetryTopology will declaring dead letter exchange & queue of queue, also delay queues
+// which route expired messages back into queue for retrying
+func DeclareRetryTopology(ch *amqp.Channel, queue string, maxAttempts int, baseDelay time.Duration) error {
+	err := ch.ExchangeDeclare(
+		DeadLetterExchange(queue), // exchange name
+		amqp.ExchangeDirect,       // kind
+		true,                      // durable
+		false,                     // auto delete
+		false,                     // internal
+		false,                     // no wait
+		nil,                       // arguments
+	)
+	if err != nil {
+		return err
+	}
+
+	_, err = ch.QueueDeclare(
+		DeadLetterQueue(queue), // queue name
+		true,                   // durable
+		false,                  // auto delete
+		false,                  // exclusive
+		false,                  // no wait
+		nil,                    // arguments
+	)
+	if err != nil {
+		return err
+	}
+
+	err = ch.QueueBind(DeadLetterQueue(queue), queue, DeadLetterExchange(queue), false, nil)
+	if err != nil {
+		return err
+	}
+
+	for attempt := 1; attempt < maxAttempts; attempt++ {
+		_, err = ch.QueueDeclare(
+			RetryQueue(queue, attempt), // queue name
+			true,                       // durable
+			false,                      // auto delete
+			false,                      // exclusive
+			false,                      // no wait
+			amqp.Table{
+				"x-message-ttl":             RetryDelay(baseDelay, attempt).Milliseconds(),
+				"x-dead-letter-exchange":    "",
+				"x-dead-letter-routing-key": queue,
+			}, // arguments
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Attempts return how many times message already failed to process
+func Attempts(msg amqp.Delivery) int {
+	switch v := msg.Headers[HeaderAttempts].(type) {
+	case int32:
+		return int(v)
+	case int64:
+		return int(v)
+	case int:
+		return v
+	}
+
+	return 0
+}
+
+// RetryOrDeadLetter will republishing failed message into delay queue of next attempt,
+// or into dead letter exchange when attempts exhausted, then acking the original one.
+// returning true when message dead lettered
+func RetryOrDeadLetter(ch Publisher, msg amqp.Delivery, queue string, maxAttempts int, cause error) (bool, error) {
+	attempts := Attempts(msg) + 1
+	if attempts >= maxAttempts {
+		return true, DeadLetter(ch, msg, queue, cause)
+	}
+
+	headers := copyHeaders(msg.Headers)
+	headers[HeaderAttempts] = int32(attempts)
+
+	return false, republish(ch, msg, "", RetryQueue(queue, attempts), headers)
+}
+
+// DeadLetter will publishing message into dead letter exchange of queue then acking the original one
+func DeadLetter(ch Publisher, msg amqp.Delivery, queue string, cause error) error {
+	headers := copyHeaders(msg.Headers)
+	headers[HeaderAttempts] = int32(Attempts(msg) + 1)
+	headers[HeaderLastError] = cause.Error()
+
+	return republish(ch, msg, DeadLetterExchange(queue), queue, headers)
+}
+
+// PeekDeadLetters will reading up to limit dead lettered messages of queue, messages requeued afterwards
+func PeekDeadLetters(ch *amqp.Channel, queue string, limit int) ([]amqp.Delivery, error) {
+	var messages []amqp.Delivery
+
+	for len(messages) < limit {
+		msg, ok, err := ch.Get(DeadLetterQueue(queue), false)
+		if err != nil {
+			return nil, err
+		}
+
+		if !ok {
+			break
+		}
+
+		messages = append(messages, msg)
+	}
+
+	if len(messages) > 0 {
+		if err := ch.Nack(messages[len(messages)-1].DeliveryTag, true, true); err != nil {
+			return nil, err
+		}
+	}
+
+	return messages, nil
+}
+
+// ReplayDeadLetters will moving up to limit dead lettered messages back into queue with attempts reset
+func ReplayDeadLetters(ch *amqp.Channel, queue string, limit int) (int, error) {
+	replayed := 0
+
+	for replayed < limit {
+		msg, ok, err := ch.Get(DeadLetterQueue(queue), false)
+		if err != nil {
+			return replayed, err
+		}
+
+		if !ok {
+			break
+		}
+
+		headers := copyHeaders(msg.Headers)
+		delete(headers, HeaderAttempts)
+		delete(headers, HeaderLastError)
+
+		if err := republish(ch, msg, "", queue, headers); err != nil {
+			return replayed, err
+		}
+
+		replayed++
+	}
+
+	return replayed, nil
+}
+
+// republish will publishing copy of message with headers then acking the original one,
+// original message requeued when publishing failed so it never lost
+func republish(ch Publisher, msg amqp.Delivery, exchange string, key string, headers amqp.Table) error {
+	err := ch.Publish(
+		exchange, // exchange
+		key,      // routing key
+		false,    // mandatory
+		false,    // immediate
+		amqp.Publishing{
+			Headers:       headers,
+			ContentType:   msg.ContentType,
+			CorrelationId: msg.CorrelationId,
+			ReplyTo:       msg.ReplyTo,
+			DeliveryMode:  amqp.Persistent,
+			Body:          msg.Body,
+		}, // message to publish
+	)
+	if err != nil {
+		if errNack := msg.Nack(false, true); errNack != nil {
+			return errNack
+		}
+
+		return err
+	}
+
+	return msg.Ack(false)
+}
+
+func copyHeaders(headers amqp.Table) amqp.Table {
+	copied := make(amqp.Table, len(headers)+2)
+	for k, v := range headers {
+		copied[k] = v
+	}
+
+	return copied
+}
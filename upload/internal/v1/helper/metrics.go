@@ -0,0 +1,32 @@
+package helper
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	// QueuePublished count messages published by queue
+	QueuePublished = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "amqp_messages_published_total",
+		Help: "Total messages published by queue.",
+	}, []string{"queue"})
+
+	// QueuePublishFailures count messages failed to publish by queue
+	QueuePublishFailures = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "amqp_publish_failures_total",
+		Help: "Total messages failed to publish by queue.",
+	}, []string{"queue"})
+
+	// QueueConsumed count messages consumed by queue
+	QueueConsumed = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "amqp_messages_consumed_total",
+		Help: "Total messages consumed by queue.",
+	}, []string{"queue"})
+
+	// QueueConsumeFailures count consumed messages failed to process by queue
+	QueueConsumeFailures = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "amqp_consume_failures_total",
+		Help: "Total consumed messages failed to process by queue.",
+	}, []string{"queue"})
+)
@@ -0,0 +1,27 @@
+package infrastructure
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/PickHD/singkatin-revamp/upload/internal/v1/application"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// ServeMetrics will exposing metrics & health checks in background as consumer has no HTTP server
+func ServeMetrics(app *application.App) {
+	dep := application.SetupDependencyInjection(app)
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.Handler())
+	mux.HandleFunc("/live", dep.HealthCheckController.Live)
+	mux.HandleFunc("/ready", dep.HealthCheckController.Ready)
+
+	go func() {
+		app.Logger.Info("Serving metrics on port ", app.Config.Server.MetricsPort)
+
+		if err := http.ListenAndServe(fmt.Sprintf(":%d", app.Config.Server.MetricsPort), mux); err != nil {
+			app.Logger.Error("Failed serve metrics. Error: ", err)
+		}
+	}()
+}
@@ -1,50 +1,147 @@
 package infrastructure
 
 import (
+	"errors"
 	"fmt"
+	"strconv"
 
 	"github.com/PickHD/singkatin-revamp/upload/internal/v1/application"
+	"github.com/PickHD/singkatin-revamp/upload/internal/v1/helper"
 	uploadpb "github.com/PickHD/singkatin-revamp/upload/pkg/api/v1/proto/upload"
+	"github.com/sirupsen/logrus"
+	"github.com/streadway/amqp"
 	"google.golang.org/protobuf/proto"
 )
 
+// defaultDeadLetterLimit is number of dead lettered messages listed / replayed when limit not defined
+const defaultDeadLetterLimit = 100
+
 // ConsumeMessages generic function to consume message from defined param queues
 func ConsumeMessages(app *application.App, queueName string) {
 	dep := application.SetupDependencyInjection(app)
 
-	// Subscribing to queues for getting messages.
-	messages, err := app.RabbitMQ.Consume(
-		queueName, // queue name
-		"",        // consumer
-		true,      // auto-ack
-		false,     // exclusive
-		false,     // no local
-		false,     // no wait
-		nil,       // arguments
-	)
+	app.Logger.Info("Waiting Message in Queues ", queueName, ".....")
+
+	// Subscribing to queues for getting messages, resumed when connection recovered.
+	app.RabbitMQ.Consume(queueName, false, func(msg amqp.Delivery) {
+		// continue trace of publisher carried by message headers
+		ctx := helper.ExtractTraceContext(app.Context, msg.Headers)
+
+		req := &uploadpb.UploadAvatarMessage{}
+
+		err := proto.Unmarshal(msg.Body, req)
+		if err != nil {
+			app.Logger.Error("Unmarshal proto UploadAvatarMessage ERROR, ", err)
+			deadLetterMessage(app, msg, queueName, err)
+			return
+		}
+
+		app.Logger.Info(fmt.Sprintf("[%s] Success Consume Message :", queueName), req)
+
+		err = dep.UploadController.ProcessUploadAvatarUser(ctx, req)
+		if err != nil {
+			app.Logger.Error("ProcessUploadAvatarUser ERROR, ", err)
+		}
+
+		settleMessage(app, msg, queueName, err)
+
+		app.Logger.Info(fmt.Sprintf("[%s] Success Process Message :", queueName), req)
+	})
+}
+
+// ManageDeadLetters will listing or replaying dead lettered messages of queue,
+// args is action (list / replay), queue name & optional limit
+func ManageDeadLetters(app *application.App, args []string) error {
+	if len(args) < 2 {
+		return errors.New("usage: dlq <list|replay> <queue> [limit]")
+	}
+
+	action, queueName, limit := args[0], args[1], defaultDeadLetterLimit
+
+	if len(args) > 2 {
+		n, err := strconv.Atoi(args[2])
+		if err != nil || n < 1 {
+			return errors.New("limit must be positive number")
+		}
+
+		limit = n
+	}
+
+	ch, err := app.RabbitMQ.Channel()
 	if err != nil {
-		app.Logger.Error("Failed consume message in queue", queueName)
+		return err
 	}
+	defer ch.Close()
 
-	app.Logger.Info("Waiting Message in Queues ", queueName, ".....")
+	switch action {
+	case "list":
+		messages, err := helper.PeekDeadLetters(ch, queueName, limit)
+		if err != nil {
+			return err
+		}
 
-	go func() {
-		for msg := range messages {
+		for _, msg := range messages {
 			req := &uploadpb.UploadAvatarMessage{}
 
-			err := proto.Unmarshal(msg.Body, req)
-			if err != nil {
-				app.Logger.Error("Unmarshal proto UploadAvatarMessage ERROR, ", err)
+			// avatar bytes not logged, only describing the file
+			fileName := "undecodable message"
+			if err := proto.Unmarshal(msg.Body, req); err == nil {
+				fileName = req.GetFileName()
 			}
 
-			app.Logger.Info(fmt.Sprintf("[%s] Success Consume Message :", queueName), req)
+			app.Logger.WithFields(logrus.Fields{
+				"queue":      queueName,
+				"attempts":   helper.Attempts(msg),
+				"last_error": msg.Headers[helper.HeaderLastError],
+				"timestamp":  msg.Timestamp,
+				"file_name":  fileName,
+				"size":       len(msg.Body),
+			}).Info("Dead Lettered Message")
+		}
 
-			err = dep.UploadController.ProcessUploadAvatarUser(app.Context, req)
-			if err != nil {
-				app.Logger.Error("ProcessUploadAvatarUser ERROR, ", err)
-			}
+		app.Logger.Info(fmt.Sprintf("[%s] Total Dead Lettered Message Listed : %d", queueName, len(messages)))
+	case "replay":
+		replayed, err := helper.ReplayDeadLetters(ch, queueName, limit)
+		if err != nil {
+			return err
+		}
 
-			app.Logger.Info(fmt.Sprintf("[%s] Success Process Message :", queueName), req)
+		app.Logger.Info(fmt.Sprintf("[%s] Total Dead Lettered Message Replayed : %d", queueName, replayed))
+	default:
+		return errors.New("action must be list or replay")
+	}
+
+	return nil
+}
+
+// settleMessage will acking processed message, or retrying / dead lettering it when processing failed
+func settleMessage(app *application.App, msg amqp.Delivery, queueName string, err error) {
+	if err == nil {
+		if errAck := msg.Ack(false); errAck != nil {
+			app.Logger.Error("Ack message ERROR, ", errAck)
 		}
-	}()
+
+		return
+	}
+
+	helper.QueueConsumeFailures.WithLabelValues(queueName).Inc()
+
+	deadLettered, errRetry := helper.RetryOrDeadLetter(app.RabbitMQ, msg, queueName, app.Config.RabbitMQ.MaxAttempts, err)
+	if errRetry != nil {
+		app.Logger.Error("Retry message ERROR, ", errRetry)
+		return
+	}
+
+	if deadLettered {
+		app.Logger.Error(fmt.Sprintf("[%s] Message Dead Lettered after %d attempts, ", queueName, app.Config.RabbitMQ.MaxAttempts), err)
+	}
+}
+
+// deadLetterMessage will moving message which never can be processed straight into dead letter exchange
+func deadLetterMessage(app *application.App, msg amqp.Delivery, queueName string, err error) {
+	helper.QueueConsumeFailures.WithLabelValues(queueName).Inc()
+
+	if errDLQ := helper.DeadLetter(app.RabbitMQ, msg, queueName, err); errDLQ != nil {
+		app.Logger.Error("Dead letter message ERROR, ", errDLQ)
+	}
 }
@@ -0,0 +1,76 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/PickHD/singkatin-revamp/upload/internal/v1/config"
+	"github.com/PickHD/singkatin-revamp/upload/internal/v1/helper"
+	"github.com/minio/minio-go/v7"
+	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/sdk/trace"
+)
+
+type (
+	// HealthCheckRepository is an interface that has all the function to be implemented inside health check repository
+	HealthCheckRepository interface {
+		PingRabbitMQ(ctx context.Context) error
+		CheckBucket(ctx context.Context) error
+	}
+
+	// HealthCheckRepositoryImpl is an app health check struct that consists of all the dependencies needed for health check repository
+	HealthCheckRepositoryImpl struct {
+		Context  context.Context
+		Config   *config.Configuration
+		Logger   *logrus.Logger
+		Tracer   *trace.TracerProvider
+		RabbitMQ *helper.Broker
+		MinIO    *minio.Client
+	}
+)
+
+// NewHealthCheckRepository return new instances health check repository
+func NewHealthCheckRepository(ctx context.Context, config *config.Configuration, logger *logrus.Logger, tracer *trace.TracerProvider, amqp *helper.Broker, minioCli *minio.Client) *HealthCheckRepositoryImpl {
+	return &HealthCheckRepositoryImpl{
+		Context:  ctx,
+		Config:   config,
+		Logger:   logger,
+		Tracer:   tracer,
+		RabbitMQ: amqp,
+		MinIO:    minioCli,
+	}
+}
+
+func (hr *HealthCheckRepositoryImpl) PingRabbitMQ(ctx context.Context) error {
+	tr := hr.Tracer.Tracer("Upload-PingRabbitMQ Repository")
+	_, span := tr.Start(ctx, "Start PingRabbitMQ")
+	defer span.End()
+
+	if err := hr.RabbitMQ.Ping(); err != nil {
+		hr.Logger.Error("HealthCheckRepositoryImpl.PingRabbitMQ ERROR, ", err)
+		return err
+	}
+
+	return nil
+}
+
+func (hr *HealthCheckRepositoryImpl) CheckBucket(ctx context.Context) error {
+	tr := hr.Tracer.Tracer("Upload-CheckBucket Repository")
+	ctx, span := tr.Start(ctx, "Start CheckBucket")
+	defer span.End()
+
+	exists, err := hr.MinIO.BucketExists(ctx, hr.Config.MinIO.Bucket)
+	if err != nil {
+		hr.Logger.Error("HealthCheckRepositoryImpl.CheckBucket ERROR, ", err)
+		return err
+	}
+
+	if !exists {
+		err = fmt.Errorf("bucket %s not exists", hr.Config.MinIO.Bucket)
+
+		hr.Logger.Error("HealthCheckRepositoryImpl.CheckBucket ERROR, ", err)
+		return err
+	}
+
+	return nil
+}
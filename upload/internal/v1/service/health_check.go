@@ -0,0 +1,114 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/PickHD/singkatin-revamp/upload/internal/v1/config"
+	"github.com/PickHD/singkatin-revamp/upload/internal/v1/model"
+	"github.com/PickHD/singkatin-revamp/upload/internal/v1/repository"
+	"go.opentelemetry.io/otel/sdk/trace"
+)
+
+// healthCheckTimeout is maximum duration waiting single dependency responding health check
+const healthCheckTimeout = 3 * time.Second
+
+type (
+	// HealthCheckService is an interface that has all the function to be implemented inside health check service
+	HealthCheckService interface {
+		Live(ctx context.Context) *model.HealthReport
+		Ready(ctx context.Context) *model.HealthReport
+	}
+
+	// HealthCheckServiceImpl is an app health check struct that consists of all the dependencies needed for health check service
+	HealthCheckServiceImpl struct {
+		Context         context.Context
+		Config          *config.Configuration
+		Tracer          *trace.TracerProvider
+		HealthCheckRepo repository.HealthCheckRepository
+	}
+
+	// dependencyCheck is named function checking single dependency
+	dependencyCheck struct {
+		name  string
+		check func(ctx context.Context) error
+	}
+)
+
+// NewHealthCheckService return new instances health check service
+func NewHealthCheckService(ctx context.Context, config *config.Configuration, tracer *trace.TracerProvider, healthCheckRepo repository.HealthCheckRepository) *HealthCheckServiceImpl {
+	return &HealthCheckServiceImpl{
+		Context:         ctx,
+		Config:          config,
+		Tracer:          tracer,
+		HealthCheckRepo: healthCheckRepo,
+	}
+}
+
+// Live only telling process is running, dependencies never checked so outage of them not restarting the service
+func (hs *HealthCheckServiceImpl) Live(ctx context.Context) *model.HealthReport {
+	tr := hs.Tracer.Tracer("Upload-Live Service")
+	_, span := tr.Start(ctx, "Start Live")
+	defer span.End()
+
+	return &model.HealthReport{Status: model.HealthUp}
+}
+
+// Ready checking every dependency needed to serve requests
+func (hs *HealthCheckServiceImpl) Ready(ctx context.Context) *model.HealthReport {
+	tr := hs.Tracer.Tracer("Upload-Ready Service")
+	ctx, span := tr.Start(ctx, "Start Ready")
+	defer span.End()
+
+	return checkDependencies(ctx, []dependencyCheck{
+		{name: "rabbitmq", check: hs.HealthCheckRepo.PingRabbitMQ},
+		{name: "minio", check: hs.HealthCheckRepo.CheckBucket},
+	})
+}
+
+// checkDependencies will running every checks concurrently, service down when any of dependency down
+func checkDependencies(ctx context.Context, checks []dependencyCheck) *model.HealthReport {
+	report := &model.HealthReport{
+		Status:       model.HealthUp,
+		Dependencies: make([]model.DependencyHealth, len(checks)),
+	}
+
+	var wg sync.WaitGroup
+
+	for i, c := range checks {
+		wg.Add(1)
+
+		go func(i int, c dependencyCheck) {
+			defer wg.Done()
+
+			ctx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+			defer cancel()
+
+			start := time.Now()
+			err := c.check(ctx)
+
+			dep := model.DependencyHealth{
+				Name:      c.name,
+				Status:    model.HealthUp,
+				LatencyMs: float64(time.Since(start).Microseconds()) / 1000,
+			}
+			if err != nil {
+				dep.Status = model.HealthDown
+				dep.Error = err.Error()
+			}
+
+			report.Dependencies[i] = dep
+		}(i, c)
+	}
+
+	wg.Wait()
+
+	for _, dep := range report.Dependencies {
+		if dep.Status == model.HealthDown {
+			report.Status = model.HealthDown
+		}
+	}
+
+	return report
+}
@@ -71,6 +71,15 @@ func main() {
 			httpServer = infrastructure.ServeHTTP(app)
 		)
 
+		// bulk jobs interrupted by previous shutdown never resumed
+		infrastructure.RecoverBulkJobs(app)
+
+		// queue messages written along with database changes published by relay
+		infrastructure.RelayOutbox(app)
+
+		// outcome of async short operations replied by shortener
+		infrastructure.ConsumeOperationReplies(app)
+
 		if err := httpServer.Listen(fmt.Sprintf(":%d", app.Config.Server.AppPort)); err != nil {
 			app.Logger.Error("Failed to to start server. Error: ", err)
 			panic(err)
@@ -6,15 +6,20 @@ import (
 	"time"
 
 	"github.com/PickHD/singkatin-revamp/user/internal/v1/config"
+	"github.com/PickHD/singkatin-revamp/user/internal/v1/helper"
+	"github.com/PickHD/singkatin-revamp/user/internal/v1/middleware"
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/cors"
+	"github.com/redis/go-redis/v9"
 	"github.com/sirupsen/logrus"
 	"github.com/streadway/amqp"
+	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/exporters/jaeger"
+	"go.opentelemetry.io/otel/propagation"
 	"go.opentelemetry.io/otel/sdk/resource"
 	"go.opentelemetry.io/otel/sdk/trace"
 	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
@@ -29,7 +34,11 @@ type App struct {
 	Config      *config.Configuration
 	Logger      *logrus.Logger
 	DB          *mongo.Database
-	RabbitMQ    *amqp.Channel
+	Redis       *redis.Client
+	Revocations *helper.RevocationList
+	JWKS        *helper.JWKS
+	RabbitMQ    *helper.Broker
+	Replies     *helper.ReplyDispatcher
 	GRPC        *grpc.ClientConn
 	Tracer      *trace.TracerProvider
 }
@@ -60,6 +69,9 @@ func SetupApplication(ctx context.Context) (*App, error) {
 
 	otel.SetTracerProvider(app.Tracer)
 
+	// trace context propagated through queue messages headers as W3C traceparent
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
 	mongoClient, err := mongo.Connect(ctx, options.Client().ApplyURI(fmt.Sprintf("mongodb://%s:%d", app.Config.Database.Host, app.Config.Database.Port)))
 	if err != nil {
 		app.Logger.Error("failed connect mongoDB, error :", err)
@@ -68,39 +80,79 @@ func SetupApplication(ctx context.Context) (*App, error) {
 	db := mongoClient.Database(app.Config.Database.Name)
 	app.DB = db
 
-	amqpConn, err := amqp.Dial(app.Config.RabbitMQ.ConnURL)
+	// API keys authenticated by hash of the key, listed by their users
+	_, err = db.Collection(app.Config.Database.APIKeysCollection).Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "key_hash", Value: 1}}, Options: options.Index().SetUnique(true)},
+		{Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "created_at", Value: -1}}},
+	})
 	if err != nil {
-		app.Logger.Error("failed dial RabbitMQ, error :", err)
+		app.Logger.Error("failed create api keys indexes, error :", err)
 		return app, err
 	}
 
-	amqpClient, err := amqpConn.Channel()
+	app.Redis = redis.NewClient(&redis.Options{
+		Addr:     fmt.Sprintf("%s:%d", app.Config.Redis.Host, app.Config.Redis.Port),
+		Password: "", // no password set
+		DB:       0,  // use default DB
+	})
+
+	// revocation list of access tokens maintained by auth services
+	app.Revocations = helper.NewRevocationList(app.Redis, time.Duration(app.Config.Redis.RevocationCacheTTL)*time.Second)
+
+	// public keys verifying access tokens published by auth services
+	app.JWKS = helper.NewJWKS(app.Config.JWKS.URL, time.Duration(app.Config.JWKS.CacheTTL)*time.Second)
+
+	queues := []string{app.Config.RabbitMQ.QueueCreateShortener, app.Config.RabbitMQ.QueueUpdateVisitor, app.Config.RabbitMQ.QueueUpdateShortener, app.Config.RabbitMQ.QueueDeleteShortener, app.Config.RabbitMQ.QueueOperationReply}
+
+	// topology re-declared every time connection recovered
+	app.RabbitMQ, err = helper.NewBroker(app.Config.RabbitMQ.ConnURL, app.Logger, app.Config.RabbitMQ.ChannelPoolSize, 0, func(ch *amqp.Channel) error {
+		for _, q := range queues {
+			_, err := ch.QueueDeclare(
+				q,     // queue name
+				true,  // durable
+				false, // auto delete
+				false, // exclusive
+				false, // no wait
+				nil,   // arguments
+			)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
 	if err != nil {
-		app.Logger.Error("failed open RabbitMQ Channels, error :", err)
-		return app, err
+		app.Logger.Error("failed connect RabbitMQ, error :", err)
+		return nil, err
 	}
 
-	queues := []string{app.Config.RabbitMQ.QueueCreateShortener, app.Config.RabbitMQ.QueueUpdateVisitor, app.Config.RabbitMQ.QueueUpdateShortener, app.Config.RabbitMQ.QueueDeleteShortener}
+	app.Replies = helper.NewReplyDispatcher()
 
-	for _, q := range queues {
-		_, err = amqpClient.QueueDeclare(
-			q,     // queue name
-			true,  // durable
-			false, // auto delete
-			false, // exclusive
+	// exclusive queue for receiving replies of published messages, deleted by server along with connection
+	// so new one declared every time connection recovered
+	app.RabbitMQ.ConsumeDeclared(func(ch *amqp.Channel) (string, error) {
+		replyQueue, err := ch.QueueDeclare(
+			"",    // queue name, let server generate it
+			false, // durable
+			true,  // auto delete
+			true,  // exclusive
 			false, // no wait
 			nil,   // arguments
 		)
 		if err != nil {
-			app.Logger.Error("failed queue declare Channels, error :", err)
-			return nil, err
+			return "", err
 		}
-	}
 
-	app.RabbitMQ = amqpClient
+		app.Replies.SetQueue(replyQueue.Name)
+
+		return replyQueue.Name, nil
+	}, true, app.Replies.Dispatch)
 
 	opts := []grpc.DialOption{
 		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithUnaryInterceptor(helper.UnaryClientMetrics),
+		grpc.WithStreamInterceptor(helper.StreamClientMetrics),
 	}
 
 	app.GRPC, err = grpc.Dial(app.Config.Common.GRPCPort, opts...)
@@ -114,6 +166,7 @@ func SetupApplication(ctx context.Context) (*App, error) {
 		AllowOrigins: "*",
 		AllowHeaders: "Origin, Content-Type, Accept, Authorization",
 	}))
+	app.Application.Use(middleware.MetricsMiddleware)
 
 	app.Logger.Info("APP RUN SUCCESSFULLY")
 
@@ -124,6 +177,18 @@ func SetupApplication(ctx context.Context) (*App, error) {
 func (a *App) Close(ctx context.Context) {
 	a.Logger.Info("APP CLOSED SUCCESSFULLY")
 
+	if a.RabbitMQ != nil {
+		if err := a.RabbitMQ.Close(); err != nil {
+			a.Logger.Error("failed close RabbitMQ, error :", err)
+		}
+	}
+
+	if a.Redis != nil {
+		if err := a.Redis.Close(); err != nil {
+			a.Logger.Error("failed close Redis, error :", err)
+		}
+	}
+
 	defer func(ctx context.Context) {
 		if err := a.DB.Client().Disconnect(a.Context); err != nil {
 			panic(err)
@@ -16,8 +16,8 @@ func SetupDependencyInjection(app *App) *Dependency {
 	shortenerServiceClient := shortenerpb.NewShortenerServiceClient(app.GRPC)
 
 	// repository
-	healthCheckRepoImpl := repository.NewHealthCheckRepository(app.Context, app.Config, app.Logger, app.Tracer, app.DB)
-	userRepoImpl := repository.NewUserRepository(app.Context, app.Config, app.Logger, app.Tracer, app.DB, app.RabbitMQ)
+	healthCheckRepoImpl := repository.NewHealthCheckRepository(app.Context, app.Config, app.Logger, app.Tracer, app.DB, app.Redis, app.RabbitMQ, app.GRPC)
+	userRepoImpl := repository.NewUserRepository(app.Context, app.Config, app.Logger, app.Tracer, app.DB, app.RabbitMQ, app.Replies)
 
 	// service
 	healthCheckSvcImpl := service.NewHealthCheckService(app.Context, app.Config, app.Tracer, healthCheckRepoImpl)
@@ -7,11 +7,16 @@ type (
 		Server      *Server
 		Common      *Common
 		Database    *Database
+		Redis       *Redis
 		RabbitMQ    *RabbitMQ
-		Secret      *Secret
+		JWKS        *JWKS
 		Tracer      *Tracer
 		MinIO       *MinIO
 		HttpService *HttpService
+		Alias       *Alias
+		Bulk        *Bulk
+		Outbox      *Outbox
+		APIKey      *APIKey
 	}
 
 	Common struct {
@@ -32,6 +37,16 @@ type (
 		Name                 string
 		UsersCollection      string
 		ShortenersCollection string
+		BulkJobsCollection   string
+		OperationsCollection string
+		OutboxCollection     string
+		APIKeysCollection    string
+	}
+
+	Redis struct {
+		Host               string
+		Port               int
+		RevocationCacheTTL int
 	}
 
 	RabbitMQ struct {
@@ -41,10 +56,14 @@ type (
 		QueueUploadAvatar    string
 		QueueUpdateShortener string
 		QueueDeleteShortener string
+		QueueOperationReply  string
+		ReplyTimeout         int
+		ChannelPoolSize      int
 	}
 
-	Secret struct {
-		JWTSecret string
+	JWKS struct {
+		URL      string
+		CacheTTL int
 	}
 
 	Tracer struct {
@@ -63,6 +82,32 @@ type (
 	HttpService struct {
 		ShortenerBaseAPIURL string
 	}
+
+	Alias struct {
+		Charset       string
+		MinLength     int
+		MaxLength     int
+		ReservedWords []string
+	}
+
+	Bulk struct {
+		MaxRows      int
+		SyncLimit    int
+		BatchSize    int
+		StaleTimeout int
+	}
+
+	Outbox struct {
+		RelayInterval int
+		LockDuration  int
+		MaxAttempts   int
+		RetryBackoff  int
+	}
+
+	APIKey struct {
+		MaxPerUser       int
+		LastUsedInterval int
+	}
 )
 
 func loadConfiguration() *Configuration {
@@ -83,6 +128,15 @@ func loadConfiguration() *Configuration {
 			Name:                 helper.GetEnvString("DB_NAME"),
 			UsersCollection:      helper.GetEnvString("DB_COLLECTION_USERS"),
 			ShortenersCollection: helper.GetEnvString("DB_COLLECTION_SHORTENERS"),
+			BulkJobsCollection:   helper.GetEnvString("DB_COLLECTION_BULK_JOBS"),
+			OperationsCollection: helper.GetEnvString("DB_COLLECTION_SHORT_OPERATIONS"),
+			OutboxCollection:     helper.GetEnvString("DB_COLLECTION_OUTBOX"),
+			APIKeysCollection:    helper.GetEnvString("DB_COLLECTION_API_KEYS"),
+		},
+		Redis: &Redis{
+			Host:               helper.GetEnvString("REDIS_HOST"),
+			Port:               helper.GetEnvInt("REDIS_PORT"),
+			RevocationCacheTTL: helper.GetEnvInt("REDIS_REVOCATION_CACHE_TTL"),
 		},
 		RabbitMQ: &RabbitMQ{
 			ConnURL:              helper.GetEnvString("AMQP_SERVER_URL"),
@@ -91,9 +145,13 @@ func loadConfiguration() *Configuration {
 			QueueUploadAvatar:    helper.GetEnvString("AMQP_QUEUE_UPLOAD_AVATAR"),
 			QueueUpdateShortener: helper.GetEnvString("AMQP_QUEUE_UPDATE_SHORTENER"),
 			QueueDeleteShortener: helper.GetEnvString("AMQP_QUEUE_DELETE_SHORTENER"),
+			QueueOperationReply:  helper.GetEnvString("AMQP_QUEUE_OPERATION_REPLY"),
+			ReplyTimeout:         helper.GetEnvInt("AMQP_REPLY_TIMEOUT"),
+			ChannelPoolSize:      helper.GetEnvInt("AMQP_CHANNEL_POOL_SIZE"),
 		},
-		Secret: &Secret{
-			JWTSecret: helper.GetEnvString("JWT_SECRET"),
+		JWKS: &JWKS{
+			URL:      helper.GetEnvString("JWKS_URL"),
+			CacheTTL: helper.GetEnvInt("JWKS_CACHE_TTL"),
 		},
 		Tracer: &Tracer{
 			JaegerURL: helper.GetEnvString("JAEGER_URL"),
@@ -109,6 +167,28 @@ func loadConfiguration() *Configuration {
 		HttpService: &HttpService{
 			ShortenerBaseAPIURL: helper.GetEnvString("SHORTENER_BASE_API_URL"),
 		},
+		Alias: &Alias{
+			Charset:       helper.GetEnvString("ALIAS_CHARSET"),
+			MinLength:     helper.GetEnvInt("ALIAS_MIN_LENGTH"),
+			MaxLength:     helper.GetEnvInt("ALIAS_MAX_LENGTH"),
+			ReservedWords: helper.GetEnvStringSlice("ALIAS_RESERVED_WORDS"),
+		},
+		Bulk: &Bulk{
+			MaxRows:      helper.GetEnvInt("BULK_MAX_ROWS"),
+			SyncLimit:    helper.GetEnvInt("BULK_SYNC_LIMIT"),
+			BatchSize:    helper.GetEnvInt("BULK_BATCH_SIZE"),
+			StaleTimeout: helper.GetEnvInt("BULK_STALE_TIMEOUT"),
+		},
+		Outbox: &Outbox{
+			RelayInterval: helper.GetEnvInt("OUTBOX_RELAY_INTERVAL"),
+			LockDuration:  helper.GetEnvInt("OUTBOX_LOCK_DURATION"),
+			MaxAttempts:   helper.GetEnvInt("OUTBOX_MAX_ATTEMPTS"),
+			RetryBackoff:  helper.GetEnvInt("OUTBOX_RETRY_BACKOFF"),
+		},
+		APIKey: &APIKey{
+			MaxPerUser:       helper.GetEnvInt("API_KEY_MAX_PER_USER"),
+			LastUsedInterval: helper.GetEnvInt("API_KEY_LAST_USED_INTERVAL"),
+		},
 	}
 }
 
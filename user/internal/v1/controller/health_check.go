@@ -6,6 +6,7 @@ import (
 
 	"github.com/PickHD/singkatin-revamp/user/internal/v1/config"
 	"github.com/PickHD/singkatin-revamp/user/internal/v1/helper"
+	"github.com/PickHD/singkatin-revamp/user/internal/v1/model"
 	"github.com/PickHD/singkatin-revamp/user/internal/v1/service"
 	"github.com/gofiber/fiber/v2"
 	"go.opentelemetry.io/otel/sdk/trace"
@@ -14,7 +15,8 @@ import (
 type (
 	// HealthCheckController is an interface that has all the function to be implemented inside health check controller
 	HealthCheckController interface {
-		Check(ctx *fiber.Ctx) error
+		Live(ctx *fiber.Ctx) error
+		Ready(ctx *fiber.Ctx) error
 	}
 
 	// HealthCheckControllerImpl is an app health check struct that consists of all the dependencies needed for health check controller
@@ -36,23 +38,39 @@ func NewHealthCheckController(ctx context.Context, config *config.Configuration,
 	}
 }
 
-// Check godoc
-// @Summary      Checking Health Services
+// Live godoc
+// @Summary      Checking Liveness Services
 // @Tags         Health Check
 // @Accept       json
 // @Produce      json
-// @Success      200  {object}  helper.BaseResponse
-// @Failure      500  {object}  helper.BaseResponse
+// @Success      200  {object}  helper.BaseResponse{data=model.HealthReport}
+// @Router       /live [get]
+func (hc *HealthCheckControllerImpl) Live(ctx *fiber.Ctx) error {
+	tr := hc.Tracer.Tracer("User-Live Controller")
+	spanCtx, span := tr.Start(hc.Context, "Start Live")
+	defer span.End()
+
+	return helper.NewResponses[any](ctx, http.StatusOK, "OK", hc.HealthCheckSvc.Live(spanCtx), nil, nil)
+}
+
+// Ready godoc
+// @Summary      Checking Readiness Services & Its Dependencies
+// @Tags         Health Check
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  helper.BaseResponse{data=model.HealthReport}
+// @Failure      503  {object}  helper.BaseResponse{data=model.HealthReport}
+// @Router       /ready [get]
 // @Router       /health-check [get]
-func (hc *HealthCheckControllerImpl) Check(ctx *fiber.Ctx) error {
-	tr := hc.Tracer.Tracer("User-Check Controller")
-	_, span := tr.Start(hc.Context, "Start Check")
+func (hc *HealthCheckControllerImpl) Ready(ctx *fiber.Ctx) error {
+	tr := hc.Tracer.Tracer("User-Ready Controller")
+	spanCtx, span := tr.Start(hc.Context, "Start Ready")
 	defer span.End()
 
-	ok, err := hc.HealthCheckSvc.Check()
-	if err != nil || !ok {
-		return helper.NewResponses[any](ctx, http.StatusInternalServerError, "not OK", ok, err, nil)
+	report := hc.HealthCheckSvc.Ready(spanCtx)
+	if report.Status != model.HealthUp {
+		return helper.NewResponses[any](ctx, http.StatusServiceUnavailable, "not OK", report, nil, nil)
 	}
 
-	return helper.NewResponses[any](ctx, http.StatusOK, "OK", ok, nil, nil)
+	return helper.NewResponses[any](ctx, http.StatusOK, "OK", report, nil, nil)
 }
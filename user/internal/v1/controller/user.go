@@ -1,7 +1,9 @@
 package controller
 
 import (
+	"bufio"
 	"context"
+	"fmt"
 	"strings"
 
 	"github.com/PickHD/singkatin-revamp/user/internal/v1/config"
@@ -24,6 +26,18 @@ type (
 		UploadAvatar(ctx *fiber.Ctx) error
 		UpdateShort(ctx *fiber.Ctx) error
 		DeleteShort(ctx *fiber.Ctx) error
+		ShortStats(ctx *fiber.Ctx) error
+		BulkGenerateShort(ctx *fiber.Ctx) error
+		BulkJob(ctx *fiber.Ctx) error
+		ExportShort(ctx *fiber.Ctx) error
+		ShortOperation(ctx *fiber.Ctx) error
+		RelayOutboxMessages() error
+		FailStaleBulkJobs() (int64, error)
+		FinishShortOperation(operationID string, body []byte) error
+		CreateAPIKey(ctx *fiber.Ctx) error
+		APIKeys(ctx *fiber.Ctx) error
+		RevokeAPIKey(ctx *fiber.Ctx) error
+		AuthenticateAPIKey(key string, scope string) (middleware.DecodePayloadData, error)
 	}
 
 	// UserControllerImpl is an app user struct that consists of all the dependencies needed for user controller
@@ -70,11 +84,7 @@ func (uc *UserControllerImpl) Profile(ctx *fiber.Ctx) error {
 
 	detail, err := uc.UserSvc.GetUserDetail(extData.Email)
 	if err != nil {
-		if strings.Contains(err.Error(), string(model.NotFound)) {
-			return helper.NewResponses[any](ctx, fiber.StatusNotFound, err.Error(), nil, err, nil)
-		}
-
-		return helper.NewResponses[any](ctx, fiber.StatusInternalServerError, err.Error(), nil, err, nil)
+		return helper.NewErrorResponses[any](ctx, err, "failed get profiles", nil)
 	}
 
 	return helper.NewResponses[any](ctx, fiber.StatusOK, "Success get Profiles", detail, nil, nil)
@@ -85,8 +95,15 @@ func (uc *UserControllerImpl) Profile(ctx *fiber.Ctx) error {
 // @Tags         User
 // @Accept       json
 // @Produce      json
-// @Param        Authorization header string true "Authorization Bearer <Place Access Token Here>"
+// @Param        Authorization header string true "Authorization Bearer <Place Access Token Here> or ApiKey <Place API Key Here>"
+// @Param        page       query int    false "page number, default 1"
+// @Param        page_size  query int    false "shorts per page, default 10, max 100"
+// @Param        sort_by    query string false "created_at (default) / visited / full_url"
+// @Param        sort_order query string false "desc (default) / asc"
+// @Param        search     query string false "free text search over full url & alias"
+// @Param        tag        query string false "filter by tag"
 // @Success      200  {object}  helper.BaseResponse
+// @Failure      400  {object}  helper.BaseResponse
 // @Failure      500  {object}  helper.BaseResponse
 // @Router       /dashboard [get]
 func (uc *UserControllerImpl) Dashboard(ctx *fiber.Ctx) error {
@@ -100,12 +117,17 @@ func (uc *UserControllerImpl) Dashboard(ctx *fiber.Ctx) error {
 		return helper.NewResponses[any](ctx, fiber.StatusInternalServerError, err.Error(), nil, err, nil)
 	}
 
-	detail, err := uc.UserSvc.GetUserShorts(extData.UserID)
+	var req model.DashboardRequest
+	if err := ctx.QueryParser(&req); err != nil {
+		return helper.NewResponses[any](ctx, fiber.StatusBadRequest, err.Error(), nil, err, nil)
+	}
+
+	detail, meta, err := uc.UserSvc.GetUserShorts(extData.UserID, &req)
 	if err != nil {
-		return helper.NewResponses[any](ctx, fiber.StatusInternalServerError, err.Error(), nil, err, nil)
+		return helper.NewErrorResponses[any](ctx, err, "failed get dashboard", nil)
 	}
 
-	return helper.NewResponses[any](ctx, fiber.StatusOK, "Success get Dashboard", detail, nil, nil)
+	return helper.NewResponses[any](ctx, fiber.StatusOK, "Success get Dashboard", detail, nil, meta)
 }
 
 // Check godoc
@@ -113,10 +135,13 @@ func (uc *UserControllerImpl) Dashboard(ctx *fiber.Ctx) error {
 // @Tags         User
 // @Accept       json
 // @Produce      json
-// @Param        Authorization header string true "Authorization Bearer <Place Access Token Here>"
+// @Param        Authorization header string true "Authorization Bearer <Place Access Token Here> or ApiKey <Place API Key Here>"
 // @Param        short body model.ShortUserRequest true "generate short user"
+// @Param        async query bool false "process through queue, poll outcome from /short/operations/{id}"
 // @Success      201  {object}  helper.BaseResponse
+// @Success      202  {object}  helper.BaseResponse
 // @Failure      400  {object}  helper.BaseResponse
+// @Failure      409  {object}  helper.BaseResponse
 // @Failure      500  {object}  helper.BaseResponse
 // @Router       /short/generate [post]
 func (uc *UserControllerImpl) GenerateShort(ctx *fiber.Ctx) error {
@@ -136,9 +161,18 @@ func (uc *UserControllerImpl) GenerateShort(ctx *fiber.Ctx) error {
 		return helper.NewResponses[any](ctx, fiber.StatusBadRequest, err.Error(), nil, err, nil)
 	}
 
+	if ctx.QueryBool("async") {
+		operation, err := uc.UserSvc.GenerateUserShortsAsync(extData.UserID, &req)
+		if err != nil {
+			return helper.NewErrorResponses[any](ctx, err, "failed generate Short URL's", nil)
+		}
+
+		return helper.NewResponses[any](ctx, fiber.StatusAccepted, "Accepted generate Short URL's", operation, nil, nil)
+	}
+
 	newShort, err := uc.UserSvc.GenerateUserShorts(extData.UserID, &req)
 	if err != nil {
-		return helper.NewResponses[any](ctx, fiber.StatusInternalServerError, err.Error(), nil, err, nil)
+		return helper.NewErrorResponses[any](ctx, err, "failed generate Short URL's", nil)
 	}
 
 	return helper.NewResponses[any](ctx, fiber.StatusCreated, "Success generate Short URL's", newShort, nil, nil)
@@ -174,11 +208,7 @@ func (uc *UserControllerImpl) EditProfile(ctx *fiber.Ctx) error {
 
 	err = uc.UserSvc.UpdateUserProfile(extData.UserID, &req)
 	if err != nil {
-		if strings.Contains(err.Error(), string(model.Validation)) {
-			return helper.NewResponses[any](ctx, fiber.StatusBadRequest, err.Error(), nil, err, nil)
-		}
-
-		return helper.NewResponses[any](ctx, fiber.StatusInternalServerError, err.Error(), nil, err, nil)
+		return helper.NewErrorResponses[any](ctx, err, "failed update profile", nil)
 	}
 
 	return helper.NewResponses[any](ctx, fiber.StatusOK, "Success update profile", nil, nil, nil)
@@ -197,9 +227,12 @@ func (uc *UserControllerImpl) EditProfile(ctx *fiber.Ctx) error {
 // @Router       /upload/avatar [post]
 func (uc *UserControllerImpl) UploadAvatar(ctx *fiber.Ctx) error {
 	tr := uc.Tracer.Tracer("User-UploadAvatar Controller")
-	_, span := tr.Start(uc.Context, "Start UploadAvatar")
+	spanCtx, span := tr.Start(uc.Context, "Start UploadAvatar")
 	defer span.End()
 
+	// trace continued by upload services storing the avatar
+	ctx.SetUserContext(spanCtx)
+
 	data := ctx.Locals(model.KeyJWTValidAccess)
 	extData, err := middleware.Extract(data)
 	if err != nil {
@@ -208,7 +241,7 @@ func (uc *UserControllerImpl) UploadAvatar(ctx *fiber.Ctx) error {
 
 	resp, err := uc.UserSvc.UploadUserAvatar(ctx, extData.UserID)
 	if err != nil {
-		return helper.NewResponses[any](ctx, fiber.StatusInternalServerError, err.Error(), nil, err, nil)
+		return helper.NewErrorResponses[any](ctx, err, "failed upload avatar", nil)
 	}
 
 	return helper.NewResponses[any](ctx, fiber.StatusOK, "Success upload avatar users", resp, nil, nil)
@@ -220,10 +253,13 @@ func (uc *UserControllerImpl) UploadAvatar(ctx *fiber.Ctx) error {
 // @Accept       json
 // @Produce      json
 // @Param        id   path string  true  "id short urls"
-// @Param        Authorization header string true "Authorization Bearer <Place Access Token Here>"
-// @Param        short body model.ShortUserRequest true "update short user"
+// @Param        Authorization header string true "Authorization Bearer <Place Access Token Here> or ApiKey <Place API Key Here>"
+// @Param        short body model.UpdateShortUserRequest true "update short user, only given fields updated & fields listed on clear removed"
+// @Param        async query bool false "process through queue, poll outcome from /short/operations/{id}"
 // @Success      200  {object}  helper.BaseResponse
+// @Success      202  {object}  helper.BaseResponse
 // @Failure      400  {object}  helper.BaseResponse
+// @Failure      403  {object}  helper.BaseResponse
 // @Failure      404  {object}  helper.BaseResponse
 // @Failure      500  {object}  helper.BaseResponse
 // @Router       /short/{id} [put]
@@ -232,7 +268,13 @@ func (uc *UserControllerImpl) UpdateShort(ctx *fiber.Ctx) error {
 	_, span := tr.Start(uc.Context, "Start UpdateShort")
 	defer span.End()
 
-	var req model.ShortUserRequest
+	data := ctx.Locals(model.KeyJWTValidAccess)
+	extData, err := middleware.Extract(data)
+	if err != nil {
+		return helper.NewResponses[any](ctx, fiber.StatusInternalServerError, err.Error(), nil, err, nil)
+	}
+
+	var req model.UpdateShortUserRequest
 
 	if err := ctx.BodyParser(&req); err != nil {
 		return helper.NewResponses[any](ctx, fiber.StatusBadRequest, err.Error(), nil, err, nil)
@@ -243,9 +285,18 @@ func (uc *UserControllerImpl) UpdateShort(ctx *fiber.Ctx) error {
 		return helper.NewResponses[any](ctx, fiber.StatusBadRequest, "id required", model.NewError(model.Validation, "ID Required"), nil, nil)
 	}
 
-	_, err := uc.UserSvc.UpdateUserShorts(shortID, &req)
+	if ctx.QueryBool("async") {
+		operation, err := uc.UserSvc.UpdateUserShortsAsync(extData.UserID, shortID, &req)
+		if err != nil {
+			return helper.NewErrorResponses[any](ctx, err, "failed update Short URL's", nil)
+		}
+
+		return helper.NewResponses[any](ctx, fiber.StatusAccepted, "Accepted update Short URL's", operation, nil, nil)
+	}
+
+	_, err = uc.UserSvc.UpdateUserShorts(extData.UserID, shortID, &req)
 	if err != nil {
-		return helper.NewResponses[any](ctx, fiber.StatusInternalServerError, err.Error(), nil, err, nil)
+		return helper.NewErrorResponses[any](ctx, err, "failed update Short URL's", nil)
 	}
 
 	return helper.NewResponses[any](ctx, fiber.StatusOK, "Success update Short URL's", nil, nil, nil)
@@ -257,9 +308,12 @@ func (uc *UserControllerImpl) UpdateShort(ctx *fiber.Ctx) error {
 // @Accept       json
 // @Produce      json
 // @Param        id   path string  true  "id short urls"
-// @Param        Authorization header string true "Authorization Bearer <Place Access Token Here>"
+// @Param        Authorization header string true "Authorization Bearer <Place Access Token Here> or ApiKey <Place API Key Here>"
+// @Param        async query bool false "process through queue, poll outcome from /short/operations/{id}"
 // @Success      200  {object}  helper.BaseResponse
+// @Success      202  {object}  helper.BaseResponse
 // @Failure      400  {object}  helper.BaseResponse
+// @Failure      403  {object}  helper.BaseResponse
 // @Failure      404  {object}  helper.BaseResponse
 // @Failure      500  {object}  helper.BaseResponse
 // @Router       /short/{id} [delete]
@@ -268,15 +322,359 @@ func (uc *UserControllerImpl) DeleteShort(ctx *fiber.Ctx) error {
 	_, span := tr.Start(uc.Context, "Start DeleteShort")
 	defer span.End()
 
+	data := ctx.Locals(model.KeyJWTValidAccess)
+	extData, err := middleware.Extract(data)
+	if err != nil {
+		return helper.NewResponses[any](ctx, fiber.StatusInternalServerError, err.Error(), nil, err, nil)
+	}
+
 	shortID := ctx.Params("id", "")
 	if shortID == "" {
 		return helper.NewResponses[any](ctx, fiber.StatusBadRequest, "id required", model.NewError(model.Validation, "ID Required"), nil, nil)
 	}
 
-	_, err := uc.UserSvc.DeleteUserShorts(shortID)
+	if ctx.QueryBool("async") {
+		operation, err := uc.UserSvc.DeleteUserShortsAsync(extData.UserID, shortID)
+		if err != nil {
+			return helper.NewErrorResponses[any](ctx, err, "failed delete Short URL's", nil)
+		}
+
+		return helper.NewResponses[any](ctx, fiber.StatusAccepted, "Accepted delete Short URL's", operation, nil, nil)
+	}
+
+	_, err = uc.UserSvc.DeleteUserShorts(extData.UserID, shortID)
 	if err != nil {
-		return helper.NewResponses[any](ctx, fiber.StatusInternalServerError, err.Error(), nil, err, nil)
+		return helper.NewErrorResponses[any](ctx, err, "failed delete Short URL's", nil)
 	}
 
 	return helper.NewResponses[any](ctx, fiber.StatusOK, "Success delete Short URL's", nil, nil, nil)
 }
+
+// Check godoc
+// @Summary      Get Users Short URL Click Stats
+// @Tags         User
+// @Accept       json
+// @Produce      json
+// @Param        id   path string  true  "id short urls"
+// @Param        from query string false "start date (YYYY-MM-DD), default 30 days before to"
+// @Param        to   query string false "end date (YYYY-MM-DD), default today"
+// @Param        Authorization header string true "Authorization Bearer <Place Access Token Here> or ApiKey <Place API Key Here>"
+// @Success      200  {object}  helper.BaseResponse
+// @Failure      400  {object}  helper.BaseResponse
+// @Failure      404  {object}  helper.BaseResponse
+// @Failure      500  {object}  helper.BaseResponse
+// @Router       /short/{id}/stats [get]
+func (uc *UserControllerImpl) ShortStats(ctx *fiber.Ctx) error {
+	tr := uc.Tracer.Tracer("User-ShortStats Controller")
+	_, span := tr.Start(uc.Context, "Start ShortStats")
+	defer span.End()
+
+	var req model.ShortStatsRequest
+
+	data := ctx.Locals(model.KeyJWTValidAccess)
+	extData, err := middleware.Extract(data)
+	if err != nil {
+		return helper.NewResponses[any](ctx, fiber.StatusInternalServerError, err.Error(), nil, err, nil)
+	}
+
+	if err := ctx.QueryParser(&req); err != nil {
+		return helper.NewResponses[any](ctx, fiber.StatusBadRequest, err.Error(), nil, err, nil)
+	}
+
+	shortID := ctx.Params("id", "")
+	if shortID == "" {
+		return helper.NewResponses[any](ctx, fiber.StatusBadRequest, "id required", model.NewError(model.Validation, "ID Required"), nil, nil)
+	}
+
+	stats, err := uc.UserSvc.GetUserShortStats(extData.UserID, shortID, &req)
+	if err != nil {
+		return helper.NewErrorResponses[any](ctx, err, "failed get Short URL's stats", nil)
+	}
+
+	return helper.NewResponses[any](ctx, fiber.StatusOK, "Success get Short URL's stats", stats, nil, nil)
+}
+
+// Check godoc
+// @Summary      Bulk Generate Users Short URL
+// @Description  Accept JSON array of short, CSV upload (form field file) or CSV body (text/csv) with header full_url, alias, tags (separated by ;), expires_at & max_clicks. Small import processed directly, large import processed in background & can be polled.
+// @Tags         User
+// @Accept       json,mpfd,text/csv
+// @Produce      json
+// @Param        Authorization header string true "Authorization Bearer <Place Access Token Here> or ApiKey <Place API Key Here>"
+// @Param        shorts body []model.ShortUserRequest false "bulk short user"
+// @Param        file formData file false "csv file"
+// @Success      201  {object}  helper.BaseResponse
+// @Success      202  {object}  helper.BaseResponse
+// @Failure      400  {object}  helper.BaseResponse
+// @Failure      500  {object}  helper.BaseResponse
+// @Router       /short/bulk [post]
+func (uc *UserControllerImpl) BulkGenerateShort(ctx *fiber.Ctx) error {
+	tr := uc.Tracer.Tracer("User-BulkGenerateShort Controller")
+	_, span := tr.Start(uc.Context, "Start BulkGenerateShort")
+	defer span.End()
+
+	data := ctx.Locals(model.KeyJWTValidAccess)
+	extData, err := middleware.Extract(data)
+	if err != nil {
+		return helper.NewResponses[any](ctx, fiber.StatusInternalServerError, err.Error(), nil, err, nil)
+	}
+
+	job, err := uc.UserSvc.BulkGenerateUserShorts(ctx, extData.UserID)
+	if err != nil {
+		return helper.NewErrorResponses[any](ctx, err, "failed bulk generate Short URL's", nil)
+	}
+
+	if job.Status != model.BulkJobCompleted {
+		return helper.NewResponses[any](ctx, fiber.StatusAccepted, "Bulk generate Short URL's in progress", job, nil, nil)
+	}
+
+	return helper.NewResponses[any](ctx, fiber.StatusCreated, "Success bulk generate Short URL's", job, nil, nil)
+}
+
+// Check godoc
+// @Summary      Get Users Bulk Short URL Job
+// @Tags         User
+// @Accept       json
+// @Produce      json
+// @Param        id   path string  true  "id bulk job"
+// @Param        Authorization header string true "Authorization Bearer <Place Access Token Here> or ApiKey <Place API Key Here>"
+// @Success      200  {object}  helper.BaseResponse
+// @Failure      404  {object}  helper.BaseResponse
+// @Failure      500  {object}  helper.BaseResponse
+// @Router       /short/bulk/{id} [get]
+func (uc *UserControllerImpl) BulkJob(ctx *fiber.Ctx) error {
+	tr := uc.Tracer.Tracer("User-BulkJob Controller")
+	_, span := tr.Start(uc.Context, "Start BulkJob")
+	defer span.End()
+
+	data := ctx.Locals(model.KeyJWTValidAccess)
+	extData, err := middleware.Extract(data)
+	if err != nil {
+		return helper.NewResponses[any](ctx, fiber.StatusInternalServerError, err.Error(), nil, err, nil)
+	}
+
+	job, err := uc.UserSvc.GetUserBulkJob(extData.UserID, ctx.Params("id", ""))
+	if err != nil {
+		return helper.NewErrorResponses[any](ctx, err, "failed get bulk job", nil)
+	}
+
+	return helper.NewResponses[any](ctx, fiber.StatusOK, "Success get bulk job", job, nil, nil)
+}
+
+// Check godoc
+// @Summary      Export Users Short URL
+// @Description  Streaming every short of users with its visitor count, so large export never loaded entirely into memory
+// @Tags         User
+// @Accept       json
+// @Produce      text/csv,json,application/x-ndjson
+// @Param        format query string false "export format, csv (default) / json / ndjson"
+// @Param        Authorization header string true "Authorization Bearer <Place Access Token Here> or ApiKey <Place API Key Here>"
+// @Success      200  {file}    binary
+// @Failure      400  {object}  helper.BaseResponse
+// @Failure      500  {object}  helper.BaseResponse
+// @Router       /short/export [get]
+func (uc *UserControllerImpl) ExportShort(ctx *fiber.Ctx) error {
+	tr := uc.Tracer.Tracer("User-ExportShort Controller")
+	_, span := tr.Start(uc.Context, "Start ExportShort")
+	defer span.End()
+
+	data := ctx.Locals(model.KeyJWTValidAccess)
+	extData, err := middleware.Extract(data)
+	if err != nil {
+		return helper.NewResponses[any](ctx, fiber.StatusInternalServerError, err.Error(), nil, err, nil)
+	}
+
+	format := strings.ToLower(ctx.Query("format", "csv"))
+
+	contentType, ok := model.ExportFormats[format]
+	if !ok {
+		err := model.NewError(model.Validation, "format must be csv, json or ndjson")
+		return helper.NewResponses[any](ctx, fiber.StatusBadRequest, err.Error(), nil, err, nil)
+	}
+
+	export, err := uc.UserSvc.ExportUserShorts(extData.UserID, format)
+	if err != nil {
+		return helper.NewErrorResponses[any](ctx, err, "failed export Short URL's", nil)
+	}
+
+	ctx.Attachment(fmt.Sprintf("shorts.%s", format))
+	ctx.Set(fiber.HeaderContentType, contentType)
+
+	// response already sent when streaming failed midway, so error only logged
+	ctx.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		if err := export(w); err != nil {
+			uc.Logger.Error("UserControllerImpl.ExportShort ExportUserShorts ERROR, ", err)
+		}
+	})
+
+	return nil
+}
+
+// Check godoc
+// @Summary      Get Users Short URL Async Operation
+// @Tags         User
+// @Accept       json
+// @Produce      json
+// @Param        id   path string  true  "id operation"
+// @Param        Authorization header string true "Authorization Bearer <Place Access Token Here> or ApiKey <Place API Key Here>"
+// @Success      200  {object}  helper.BaseResponse
+// @Failure      404  {object}  helper.BaseResponse
+// @Failure      500  {object}  helper.BaseResponse
+// @Router       /short/operations/{id} [get]
+func (uc *UserControllerImpl) ShortOperation(ctx *fiber.Ctx) error {
+	tr := uc.Tracer.Tracer("User-ShortOperation Controller")
+	_, span := tr.Start(uc.Context, "Start ShortOperation")
+	defer span.End()
+
+	data := ctx.Locals(model.KeyJWTValidAccess)
+	extData, err := middleware.Extract(data)
+	if err != nil {
+		return helper.NewResponses[any](ctx, fiber.StatusInternalServerError, err.Error(), nil, err, nil)
+	}
+
+	operation, err := uc.UserSvc.GetUserShortOperation(extData.UserID, ctx.Params("id", ""))
+	if err != nil {
+		return helper.NewErrorResponses[any](ctx, err, "failed get operation", nil)
+	}
+
+	return helper.NewResponses[any](ctx, fiber.StatusOK, "Success get operation", operation, nil, nil)
+}
+
+func (uc *UserControllerImpl) RelayOutboxMessages() error {
+	tr := uc.Tracer.Tracer("User-RelayOutboxMessages Controller")
+	_, span := tr.Start(uc.Context, "Start RelayOutboxMessages")
+	defer span.End()
+
+	return uc.UserSvc.RelayOutboxMessages()
+}
+
+func (uc *UserControllerImpl) FailStaleBulkJobs() (int64, error) {
+	tr := uc.Tracer.Tracer("User-FailStaleBulkJobs Controller")
+	_, span := tr.Start(uc.Context, "Start FailStaleBulkJobs")
+	defer span.End()
+
+	return uc.UserSvc.FailStaleBulkJobs()
+}
+
+func (uc *UserControllerImpl) FinishShortOperation(operationID string, body []byte) error {
+	tr := uc.Tracer.Tracer("User-FinishShortOperation Controller")
+	_, span := tr.Start(uc.Context, "Start FinishShortOperation")
+	defer span.End()
+
+	return uc.UserSvc.FinishShortOperation(operationID, body)
+}
+
+// Check godoc
+// @Summary      Create Users API Key
+// @Description  Create personal API key calling link endpoints with header Authorization ApiKey <key>, the key only responded once
+// @Tags         API Key
+// @Accept       json
+// @Produce      json
+// @Param        Authorization header string true "Authorization Bearer <Place Access Token Here>"
+// @Param        api_key body model.CreateAPIKeyRequest true "create api key, scopes links:read / links:write"
+// @Success      201  {object}  helper.BaseResponse
+// @Failure      400  {object}  helper.BaseResponse
+// @Failure      409  {object}  helper.BaseResponse
+// @Failure      500  {object}  helper.BaseResponse
+// @Router       /me/api-keys [post]
+func (uc *UserControllerImpl) CreateAPIKey(ctx *fiber.Ctx) error {
+	tr := uc.Tracer.Tracer("User-CreateAPIKey Controller")
+	_, span := tr.Start(uc.Context, "Start CreateAPIKey")
+	defer span.End()
+
+	var req model.CreateAPIKeyRequest
+
+	data := ctx.Locals(model.KeyJWTValidAccess)
+	extData, err := middleware.Extract(data)
+	if err != nil {
+		return helper.NewResponses[any](ctx, fiber.StatusInternalServerError, err.Error(), nil, err, nil)
+	}
+
+	if err := ctx.BodyParser(&req); err != nil {
+		return helper.NewResponses[any](ctx, fiber.StatusBadRequest, err.Error(), nil, err, nil)
+	}
+
+	apiKey, err := uc.UserSvc.CreateUserAPIKey(extData.UserID, &req)
+	if err != nil {
+		return helper.NewErrorResponses[any](ctx, err, "failed create API key", nil)
+	}
+
+	return helper.NewResponses[any](ctx, fiber.StatusCreated, "Success create API key, please store the key safely", apiKey, nil, nil)
+}
+
+// Check godoc
+// @Summary      Get Users API Keys
+// @Tags         API Key
+// @Accept       json
+// @Produce      json
+// @Param        Authorization header string true "Authorization Bearer <Place Access Token Here>"
+// @Success      200  {object}  helper.BaseResponse
+// @Failure      500  {object}  helper.BaseResponse
+// @Router       /me/api-keys [get]
+func (uc *UserControllerImpl) APIKeys(ctx *fiber.Ctx) error {
+	tr := uc.Tracer.Tracer("User-APIKeys Controller")
+	_, span := tr.Start(uc.Context, "Start APIKeys")
+	defer span.End()
+
+	data := ctx.Locals(model.KeyJWTValidAccess)
+	extData, err := middleware.Extract(data)
+	if err != nil {
+		return helper.NewResponses[any](ctx, fiber.StatusInternalServerError, err.Error(), nil, err, nil)
+	}
+
+	apiKeys, err := uc.UserSvc.GetUserAPIKeys(extData.UserID)
+	if err != nil {
+		return helper.NewErrorResponses[any](ctx, err, "failed get API keys", nil)
+	}
+
+	return helper.NewResponses[any](ctx, fiber.StatusOK, "Success get API keys", apiKeys, nil, nil)
+}
+
+// Check godoc
+// @Summary      Revoke Users API Key
+// @Tags         API Key
+// @Accept       json
+// @Produce      json
+// @Param        id   path string  true  "id api key"
+// @Param        Authorization header string true "Authorization Bearer <Place Access Token Here>"
+// @Success      200  {object}  helper.BaseResponse
+// @Failure      400  {object}  helper.BaseResponse
+// @Failure      404  {object}  helper.BaseResponse
+// @Failure      500  {object}  helper.BaseResponse
+// @Router       /me/api-keys/{id} [delete]
+func (uc *UserControllerImpl) RevokeAPIKey(ctx *fiber.Ctx) error {
+	tr := uc.Tracer.Tracer("User-RevokeAPIKey Controller")
+	_, span := tr.Start(uc.Context, "Start RevokeAPIKey")
+	defer span.End()
+
+	data := ctx.Locals(model.KeyJWTValidAccess)
+	extData, err := middleware.Extract(data)
+	if err != nil {
+		return helper.NewResponses[any](ctx, fiber.StatusInternalServerError, err.Error(), nil, err, nil)
+	}
+
+	keyID := ctx.Params("id", "")
+	if keyID == "" {
+		return helper.NewResponses[any](ctx, fiber.StatusBadRequest, "id required", model.NewError(model.Validation, "ID Required"), nil, nil)
+	}
+
+	err = uc.UserSvc.RevokeUserAPIKey(extData.UserID, keyID)
+	if err != nil {
+		return helper.NewErrorResponses[any](ctx, err, "failed revoke API key", nil)
+	}
+
+	return helper.NewResponses[any](ctx, fiber.StatusOK, "Success revoke API key", nil, nil, nil)
+}
+
+func (uc *UserControllerImpl) AuthenticateAPIKey(key string, scope string) (middleware.DecodePayloadData, error) {
+	tr := uc.Tracer.Tracer("User-AuthenticateAPIKey Controller")
+	_, span := tr.Start(uc.Context, "Start AuthenticateAPIKey")
+	defer span.End()
+
+	apiKey, err := uc.UserSvc.AuthenticateAPIKey(key, scope)
+	if err != nil {
+		return middleware.DecodePayloadData{}, err
+	}
+
+	return middleware.DecodePayloadData{UserID: apiKey.UserID}, nil
+}
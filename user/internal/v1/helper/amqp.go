@@ -0,0 +1,67 @@
+package helper
+
+import (
+	"sync"
+
+	"github.com/streadway/amqp"
+)
+
+// ReplyDispatcher will routing every reply message from exclusive reply queue into waiting publisher by correlation id
+type ReplyDispatcher struct {
+	mu      sync.RWMutex
+	queue   string
+	pending map[string]chan amqp.Delivery
+}
+
+// NewReplyDispatcher return new instances reply dispatcher
+func NewReplyDispatcher() *ReplyDispatcher {
+	return &ReplyDispatcher{
+		pending: make(map[string]chan amqp.Delivery),
+	}
+}
+
+// Queue return name of current reply queue, changed every time reply queue re-declared
+func (d *ReplyDispatcher) Queue() string {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	return d.queue
+}
+
+// SetQueue will replacing name of current reply queue
+func (d *ReplyDispatcher) SetQueue(queue string) {
+	d.mu.Lock()
+	d.queue = queue
+	d.mu.Unlock()
+}
+
+// Dispatch will handing incoming reply into publisher waiting for it
+func (d *ReplyDispatcher) Dispatch(msg amqp.Delivery) {
+	d.mu.Lock()
+	ch, ok := d.pending[msg.CorrelationId]
+	delete(d.pending, msg.CorrelationId)
+	d.mu.Unlock()
+
+	// publisher already gave up waiting, drop the reply
+	if ok {
+		ch <- msg
+	}
+}
+
+// Register will reserving reply channel for given correlation id
+func (d *ReplyDispatcher) Register(correlationID string) <-chan amqp.Delivery {
+	ch := make(chan amqp.Delivery, 1)
+
+	d.mu.Lock()
+	d.pending[correlationID] = ch
+	d.mu.Unlock()
+
+	return ch
+}
+
+// Cancel will removing reserved reply channel for given correlation id
+func (d *ReplyDispatcher) Cancel(correlationID string) {
+	d.mu.Lock()
+	delete(d.pending, correlationID)
+	d.mu.Unlock()
+}
@@ -0,0 +1,382 @@
+package helper
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/streadway/amqp"
+)
+
+const (
+	// minReconnectDelay & maxReconnectDelay is backoff range between reconnect attempts to RabbitMQ
+	minReconnectDelay = time.Second
+	maxReconnectDelay = 30 * time.Second
+
+	// confirmTimeout is maximum duration waiting broker confirming published message
+	confirmTimeout = 10 * time.Second
+)
+
+var (
+	// ErrBrokerUnavailable returned when publishing while connection to RabbitMQ still recovering
+	ErrBrokerUnavailable = errors.New("rabbitmq connection unavailable")
+
+	// ErrPublishNotConfirmed returned when broker rejected or never confirmed published message
+	ErrPublishNotConfirmed = errors.New("rabbitmq not confirmed published message")
+)
+
+type (
+	// Publisher is anything able to publish message into RabbitMQ, satisfied by *amqp.Channel & *Broker
+	Publisher interface {
+		Publish(exchange string, key string, mandatory bool, immediate bool, msg amqp.Publishing) error
+	}
+
+	// Broker keep connection of RabbitMQ alive, reconnecting with backoff & re-declaring topology when connection closed,
+	// publishing through pool of confirm mode channels & resuming consumers after reconnected
+	Broker struct {
+		url      string
+		logger   *logrus.Logger
+		prefetch int
+		setup    func(ch *amqp.Channel) error
+
+		mu     sync.RWMutex
+		conn   *amqp.Connection
+		ready  chan struct{}
+		pool   chan *confirmChannel
+		closed bool
+	}
+
+	// confirmChannel is channel in confirm mode along with confirmations of its published messages
+	confirmChannel struct {
+		ch       *amqp.Channel
+		confirms chan amqp.Confirmation
+	}
+)
+
+// NewBroker return new instances broker already connected, setup called on every (re)connection to declare topology
+func NewBroker(url string, logger *logrus.Logger, poolSize int, prefetch int, setup func(ch *amqp.Channel) error) (*Broker, error) {
+	if poolSize < 1 {
+		poolSize = 1
+	}
+
+	b := &Broker{
+		url:      url,
+		logger:   logger,
+		prefetch: prefetch,
+		setup:    setup,
+		ready:    make(chan struct{}),
+		pool:     make(chan *confirmChannel, poolSize),
+	}
+
+	closes, err := b.connect()
+	if err != nil {
+		return nil, err
+	}
+
+	go b.watch(closes)
+
+	return b, nil
+}
+
+// Publish will publishing message using channel borrowed from pool & waiting broker confirm it
+func (b *Broker) Publish(exchange string, key string, mandatory bool, immediate bool, msg amqp.Publishing) error {
+	cc, err := b.acquire()
+	if err != nil {
+		return err
+	}
+
+	err = cc.ch.Publish(exchange, key, mandatory, immediate, msg)
+	if err == nil {
+		err = cc.wait()
+	}
+
+	b.release(cc, err)
+
+	if err != nil {
+		QueuePublishFailures.WithLabelValues(key).Inc()
+	} else {
+		QueuePublished.WithLabelValues(key).Inc()
+	}
+
+	return err
+}
+
+// Channel will opening new channel on current connection, caller responsible closing it
+func (b *Broker) Channel() (*amqp.Channel, error) {
+	b.mu.RLock()
+	conn := b.conn
+	b.mu.RUnlock()
+
+	if conn == nil {
+		return nil, ErrBrokerUnavailable
+	}
+
+	return conn.Channel()
+}
+
+// Ping will checking connection usable by opening & closing channel on it
+func (b *Broker) Ping() error {
+	ch, err := b.Channel()
+	if err != nil {
+		return err
+	}
+
+	return ch.Close()
+}
+
+// Consume will consuming queue on dedicated channel in background, resumed every time connection recovered
+func (b *Broker) Consume(queue string, autoAck bool, handler func(msg amqp.Delivery)) {
+	b.ConsumeDeclared(func(ch *amqp.Channel) (string, error) {
+		return queue, nil
+	}, autoAck, handler)
+}
+
+// ConsumeDeclared same as Consume, but queue declared by declare on every connection so server named queues can be consumed
+func (b *Broker) ConsumeDeclared(declare func(ch *amqp.Channel) (string, error), autoAck bool, handler func(msg amqp.Delivery)) {
+	go func() {
+		for {
+			if !b.waitReady() {
+				return
+			}
+
+			err := b.consume(declare, autoAck, handler)
+			if b.isClosed() {
+				return
+			}
+
+			if err != nil {
+				b.logger.Error("failed consume RabbitMQ queue, error :", err)
+			}
+
+			// give connection watcher chance to notice closed connection before resuming
+			time.Sleep(minReconnectDelay)
+		}
+	}()
+}
+
+// Close will closing connection & stop reconnecting
+func (b *Broker) Close() error {
+	b.mu.Lock()
+	b.closed = true
+	conn := b.conn
+	b.mu.Unlock()
+
+	if conn == nil {
+		return nil
+	}
+
+	return conn.Close()
+}
+
+func (b *Broker) consume(declare func(ch *amqp.Channel) (string, error), autoAck bool, handler func(msg amqp.Delivery)) error {
+	ch, err := b.Channel()
+	if err != nil {
+		return err
+	}
+	defer ch.Close()
+
+	queue, err := declare(ch)
+	if err != nil {
+		return err
+	}
+
+	if !autoAck && b.prefetch > 0 {
+		if err := ch.Qos(b.prefetch, 0, false); err != nil {
+			return err
+		}
+	}
+
+	messages, err := ch.Consume(
+		queue,   // queue name
+		"",      // consumer
+		autoAck, // auto-ack
+		false,   // exclusive
+		false,   // no local
+		false,   // no wait
+		nil,     // arguments
+	)
+	if err != nil {
+		return err
+	}
+
+	// messages closed when channel / connection closed
+	for msg := range messages {
+		QueueConsumed.WithLabelValues(queue).Inc()
+
+		handler(msg)
+	}
+
+	return nil
+}
+
+// connect will dialing & declaring topology, returning notification of closed connection
+func (b *Broker) connect() (chan *amqp.Error, error) {
+	conn, err := amqp.Dial(b.url)
+	if err != nil {
+		return nil, err
+	}
+
+	ch, err := conn.Channel()
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	if b.setup != nil {
+		if err := b.setup(ch); err != nil {
+			ch.Close()
+			conn.Close()
+			return nil, err
+		}
+	}
+
+	ch.Close()
+
+	closes := conn.NotifyClose(make(chan *amqp.Error, 1))
+
+	b.mu.Lock()
+	b.conn = conn
+	close(b.ready)
+	b.mu.Unlock()
+
+	return closes, nil
+}
+
+// watch will reconnecting with backoff every time connection closed by broker
+func (b *Broker) watch(closes chan *amqp.Error) {
+	for {
+		closeErr, ok := <-closes
+		if !ok || b.isClosed() {
+			return
+		}
+
+		b.logger.Error("RabbitMQ connection closed, reconnecting, error :", closeErr)
+
+		b.disconnect()
+
+		delay := minReconnectDelay
+		for {
+			time.Sleep(delay)
+
+			if b.isClosed() {
+				return
+			}
+
+			newCloses, err := b.connect()
+			if err == nil {
+				closes = newCloses
+				break
+			}
+
+			b.logger.Error("failed reconnect RabbitMQ, error :", err)
+
+			delay *= 2
+			if delay > maxReconnectDelay {
+				delay = maxReconnectDelay
+			}
+		}
+
+		b.logger.Info("RabbitMQ RECONNECTED SUCCESSFULLY")
+	}
+}
+
+// disconnect will marking broker unavailable & dropping pooled channels of closed connection
+func (b *Broker) disconnect() {
+	b.mu.Lock()
+	b.conn = nil
+	b.ready = make(chan struct{})
+	b.mu.Unlock()
+
+	for {
+		select {
+		case cc := <-b.pool:
+			cc.ch.Close()
+		default:
+			return
+		}
+	}
+}
+
+// acquire will borrowing channel from pool, opening new one in confirm mode when pool empty
+func (b *Broker) acquire() (*confirmChannel, error) {
+	select {
+	case cc := <-b.pool:
+		return cc, nil
+	default:
+	}
+
+	ch, err := b.Channel()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := ch.Confirm(false); err != nil {
+		ch.Close()
+		return nil, err
+	}
+
+	return &confirmChannel{
+		ch:       ch,
+		confirms: ch.NotifyPublish(make(chan amqp.Confirmation, 1)),
+	}, nil
+}
+
+// release will returning channel into pool, channel discarded when publishing failed as it may closed by broker
+// or its confirmations no longer in order
+func (b *Broker) release(cc *confirmChannel, err error) {
+	if err != nil {
+		cc.ch.Close()
+		return
+	}
+
+	select {
+	case b.pool <- cc:
+	default:
+		cc.ch.Close()
+	}
+}
+
+// wait will blocking until broker confirming latest published message of channel
+func (cc *confirmChannel) wait() error {
+	select {
+	case confirm, ok := <-cc.confirms:
+		if !ok {
+			return ErrBrokerUnavailable
+		}
+
+		if !confirm.Ack {
+			return ErrPublishNotConfirmed
+		}
+
+		return nil
+	case <-time.After(confirmTimeout):
+		return ErrPublishNotConfirmed
+	}
+}
+
+// waitReady will blocking until connected, returning false when broker closed
+func (b *Broker) waitReady() bool {
+	for {
+		b.mu.RLock()
+		ready, closed := b.ready, b.closed
+		b.mu.RUnlock()
+
+		if closed {
+			return false
+		}
+
+		select {
+		case <-ready:
+			return true
+		case <-time.After(maxReconnectDelay):
+		}
+	}
+}
+
+func (b *Broker) isClosed() bool {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	return b.closed
+}
@@ -0,0 +1,59 @@
+package helper
+
+import (
+	"github.com/PickHD/singkatin-revamp/user/internal/v1/model"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// ErrorMetadataKind & ErrorMetadataMessage are keys of error info metadata carrying kind & message of typed error
+	ErrorMetadataKind    = "kind"
+	ErrorMetadataMessage = "message"
+)
+
+// grpcKinds is kind of errors decoded from grpc status code when status carrying no error info
+var grpcKinds = map[codes.Code]model.ErrorKind{
+	codes.InvalidArgument:  model.Validation,
+	codes.NotFound:         model.NotFound,
+	codes.PermissionDenied: model.Forbidden,
+	codes.AlreadyExists:    model.Conflict,
+}
+
+// FromStatusError will decoding grpc status replied by server back into typed error,
+// using error info attached by server & falling back into status code
+func FromStatusError(err error) error {
+	st, ok := status.FromError(err)
+	if !ok {
+		return err
+	}
+
+	for _, detail := range st.Details() {
+		info, ok := detail.(*errdetails.ErrorInfo)
+		if !ok {
+			continue
+		}
+
+		e := &model.Error{
+			Kind:    model.ErrorKind(info.GetMetadata()[ErrorMetadataKind]),
+			Code:    info.GetReason(),
+			Message: info.GetMetadata()[ErrorMetadataMessage],
+		}
+
+		for k, v := range info.GetMetadata() {
+			if k != ErrorMetadataKind && k != ErrorMetadataMessage {
+				e.WithDetail(k, v)
+			}
+		}
+
+		return e
+	}
+
+	kind, ok := grpcKinds[st.Code()]
+	if !ok {
+		return model.WrapError(model.Internal, st.Message(), err)
+	}
+
+	return model.NewError(kind, st.Message())
+}
@@ -1,6 +1,9 @@
 package helper
 
 import (
+	"net/http"
+
+	"github.com/PickHD/singkatin-revamp/user/internal/v1/model"
 	"github.com/gofiber/fiber/v2"
 )
 
@@ -38,5 +41,16 @@ func NewResponses[T any](ctx *fiber.Ctx, statusCode int, message string, data T,
 	})
 }
 
+// NewErrorResponses return JSON responses of err with HTTP status mapped from its kind,
+// unexpected errors responded with message instead of leaking their cause
+func NewErrorResponses[T any](ctx *fiber.Ctx, err error, message string, data T) error {
+	statusCode := model.HTTPStatus(err)
+	if statusCode == http.StatusInternalServerError {
+		return NewResponses[T](ctx, statusCode, message, data, model.NewError(model.Internal, message), nil)
+	}
+
+	return NewResponses[T](ctx, statusCode, err.Error(), data, model.AsError(err), nil)
+}
+
 // OptionsHandler will handing preflight requests
 func OptionsHandler(ctx *fiber.Ctx) error { return nil }
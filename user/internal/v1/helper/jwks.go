@@ -0,0 +1,216 @@
+package helper
+
+import (
+	"context"
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/PickHD/singkatin-revamp/user/internal/v1/model"
+	"github.com/golang-jwt/jwt"
+)
+
+const (
+	// jwksMinRefreshInterval is minimum delay between fetching JWKS, so tokens of unknown kid can't flood auth services
+	jwksMinRefreshInterval = 10 * time.Second
+
+	// jwksFetchTimeout is timeout of fetching JWKS
+	jwksFetchTimeout = 5 * time.Second
+)
+
+type (
+	// JWKS is public keys published by auth services verifying access tokens, fetched & cached for CacheTTL.
+	// Keys of unknown kid refetched right away, so freshly rotated keys accepted without waiting cache expired
+	JWKS struct {
+		URL      string
+		CacheTTL time.Duration
+		Client   *http.Client
+
+		refreshMu sync.Mutex
+		mu        sync.RWMutex
+		keys      map[string]jwksKey
+		fetchedAt time.Time
+	}
+
+	// jwksKey is public key along with algorithm it verify
+	jwksKey struct {
+		algorithm string
+		public    crypto.PublicKey
+	}
+
+	// jwk is single JSON Web Key published by auth services
+	jwk struct {
+		KeyType   string `json:"kty"`
+		KeyID     string `json:"kid"`
+		Algorithm string `json:"alg"`
+		N         string `json:"n"`
+		E         string `json:"e"`
+		Curve     string `json:"crv"`
+		X         string `json:"x"`
+	}
+)
+
+// NewJWKS return new instances JWKS
+func NewJWKS(url string, cacheTTL time.Duration) *JWKS {
+	return &JWKS{
+		URL:      url,
+		CacheTTL: cacheTTL,
+		Client:   &http.Client{Timeout: jwksFetchTimeout},
+		keys:     make(map[string]jwksKey),
+	}
+}
+
+// Keyfunc return public key verifying token by its kid header, used as jwt.Keyfunc
+func (j *JWKS) Keyfunc(token *jwt.Token) (interface{}, error) {
+	kid, ok := token.Header["kid"].(string)
+	if !ok {
+		return nil, model.NewError(model.Validation, "Invalid token, kid required")
+	}
+
+	key, err := j.find(context.Background(), kid)
+	if err != nil {
+		return nil, err
+	}
+
+	if token.Method.Alg() != key.algorithm {
+		return nil, model.NewError(model.Validation, "Invalid token")
+	}
+
+	return key.public, nil
+}
+
+// find return public key of kid, refreshing cached keys when expired or kid not known yet
+func (j *JWKS) find(ctx context.Context, kid string) (jwksKey, error) {
+	j.mu.RLock()
+	key, ok := j.keys[kid]
+	fetchedAt := j.fetchedAt
+	j.mu.RUnlock()
+
+	sinceFetched := time.Since(fetchedAt)
+	if (ok && sinceFetched < j.CacheTTL) || (!ok && sinceFetched < jwksMinRefreshInterval) {
+		if !ok {
+			return jwksKey{}, model.NewError(model.Validation, "unknown signing key")
+		}
+
+		return key, nil
+	}
+
+	err := j.refresh(ctx)
+	if err != nil {
+		// auth services unreachable, keep verifying with keys already known
+		if ok {
+			return key, nil
+		}
+
+		return jwksKey{}, err
+	}
+
+	j.mu.RLock()
+	key, ok = j.keys[kid]
+	j.mu.RUnlock()
+
+	if !ok {
+		return jwksKey{}, model.NewError(model.Validation, "unknown signing key")
+	}
+
+	return key, nil
+}
+
+// refresh fetch JWKS & replace cached keys, verifying with cached keys not blocked meanwhile
+func (j *JWKS) refresh(ctx context.Context) error {
+	j.refreshMu.Lock()
+	defer j.refreshMu.Unlock()
+
+	j.mu.Lock()
+	// another request already refreshed while waiting for lock
+	if time.Since(j.fetchedAt) < jwksMinRefreshInterval {
+		j.mu.Unlock()
+		return nil
+	}
+
+	// failed fetch also throttled, cached keys kept meanwhile
+	j.fetchedAt = time.Now()
+	j.mu.Unlock()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, j.URL, nil)
+	if err != nil {
+		return err
+	}
+
+	res, err := j.Client.Do(req)
+	if err != nil {
+		return model.WrapError(model.Internal, "failed fetch JWKS", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return model.NewError(model.Internal, fmt.Sprintf("failed fetch JWKS, status %d", res.StatusCode))
+	}
+
+	var set struct {
+		Keys []jwk `json:"keys"`
+	}
+
+	err = json.NewDecoder(res.Body).Decode(&set)
+	if err != nil {
+		return model.WrapError(model.Internal, "failed decode JWKS", err)
+	}
+
+	keys := make(map[string]jwksKey, len(set.Keys))
+	for _, k := range set.Keys {
+		public, err := k.publicKey()
+		if err != nil {
+			continue
+		}
+
+		keys[k.KeyID] = jwksKey{algorithm: k.Algorithm, public: public}
+	}
+
+	j.mu.Lock()
+	j.keys = keys
+	j.mu.Unlock()
+
+	return nil
+}
+
+// publicKey decode public key of JWK, only RSA & Ed25519 supported
+func (k jwk) publicKey() (crypto.PublicKey, error) {
+	switch k.KeyType {
+	case "RSA":
+		n, err := base64.RawURLEncoding.DecodeString(k.N)
+		if err != nil {
+			return nil, err
+		}
+
+		e, err := base64.RawURLEncoding.DecodeString(k.E)
+		if err != nil {
+			return nil, err
+		}
+
+		return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}, nil
+	case "OKP":
+		if k.Curve != "Ed25519" {
+			break
+		}
+
+		x, err := base64.RawURLEncoding.DecodeString(k.X)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(x) != ed25519.PublicKeySize {
+			return nil, model.NewError(model.Validation, "invalid Ed25519 public key size")
+		}
+
+		return ed25519.PublicKey(x), nil
+	}
+
+	return nil, model.NewError(model.Validation, "unsupported JWK type "+k.KeyType)
+}
@@ -0,0 +1,67 @@
+package helper
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	// HTTPRequestDuration observe latency of HTTP requests by method, route & status
+	HTTPRequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "http_request_duration_seconds",
+		Help:    "Latency of HTTP requests by method, route & status.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"method", "route", "status"})
+
+	// GRPCClientHandlingDuration observe latency of gRPC calls made by client by method & status code
+	GRPCClientHandlingDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "grpc_client_handling_seconds",
+		Help:    "Latency of gRPC calls made by client by method & status code.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"method", "code"})
+
+	// QueuePublished count messages published by queue
+	QueuePublished = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "amqp_messages_published_total",
+		Help: "Total messages published by queue.",
+	}, []string{"queue"})
+
+	// QueuePublishFailures count messages failed to publish by queue
+	QueuePublishFailures = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "amqp_publish_failures_total",
+		Help: "Total messages failed to publish by queue.",
+	}, []string{"queue"})
+
+	// QueueConsumed count messages consumed by queue
+	QueueConsumed = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "amqp_messages_consumed_total",
+		Help: "Total messages consumed by queue.",
+	}, []string{"queue"})
+)
+
+// UnaryClientMetrics is gRPC interceptor observing latency & status code of every unary call
+func UnaryClientMetrics(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	start := time.Now()
+
+	err := invoker(ctx, method, req, reply, cc, opts...)
+
+	GRPCClientHandlingDuration.WithLabelValues(method, status.Code(err).String()).Observe(time.Since(start).Seconds())
+
+	return err
+}
+
+// StreamClientMetrics is gRPC interceptor observing latency & status code of opening every streaming call
+func StreamClientMetrics(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	start := time.Now()
+
+	stream, err := streamer(ctx, desc, cc, method, opts...)
+
+	GRPCClientHandlingDuration.WithLabelValues(method, status.Code(err).String()).Observe(time.Since(start).Seconds())
+
+	return stream, err
+}
@@ -0,0 +1,98 @@
+package helper
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/PickHD/singkatin-revamp/user/internal/v1/model"
+	"github.com/redis/go-redis/v9"
+)
+
+// revocationCacheMaxEntries is size of local cache before expired entries swept
+const revocationCacheMaxEntries = 10000
+
+type (
+	// RevocationList is access token revocation list maintained by auth services in redis,
+	// lookups cached locally for a short while so validating token not always cost a round trip
+	RevocationList struct {
+		Redis    *redis.Client
+		CacheTTL time.Duration
+
+		mu     sync.Mutex
+		tokens map[string]revocationEntry
+		users  map[string]revocationEntry
+	}
+
+	// revocationEntry is cached lookup of single revocation key
+	revocationEntry struct {
+		value    int64
+		expireAt time.Time
+	}
+)
+
+// NewRevocationList return new instances revocation list
+func NewRevocationList(rds *redis.Client, cacheTTL time.Duration) *RevocationList {
+	return &RevocationList{
+		Redis:    rds,
+		CacheTTL: cacheTTL,
+		tokens:   make(map[string]revocationEntry),
+		users:    make(map[string]revocationEntry),
+	}
+}
+
+// IsRevoked checking access token by its jti is revoked, either by itself or along with every token of users issued before logging out all sessions
+func (rl *RevocationList) IsRevoked(ctx context.Context, jti string, userID string, issuedAt int64) (bool, error) {
+	now := time.Now()
+
+	rl.mu.Lock()
+	token, tokenCached := rl.tokens[jti]
+	user, userCached := rl.users[userID]
+	rl.mu.Unlock()
+
+	if tokenCached && userCached && now.Before(token.expireAt) && now.Before(user.expireAt) {
+		return token.value != 0 || issuedAt < user.value, nil
+	}
+
+	values, err := rl.Redis.MGet(ctx, fmt.Sprintf(model.RevokedAccessTokenKey, jti), fmt.Sprintf(model.RevokedAccessUserKey, userID)).Result()
+	if err != nil {
+		return false, err
+	}
+
+	token = revocationEntry{expireAt: now.Add(rl.CacheTTL)}
+	if values[0] != nil {
+		token.value = 1
+	}
+
+	user = revocationEntry{expireAt: now.Add(rl.CacheTTL)}
+	if revokedBefore, ok := values[1].(string); ok {
+		user.value, err = strconv.ParseInt(revokedBefore, 10, 64)
+		if err != nil {
+			return false, err
+		}
+	}
+
+	rl.mu.Lock()
+	rl.tokens[jti] = token
+	rl.users[userID] = user
+	sweepRevocationEntries(rl.tokens, now)
+	sweepRevocationEntries(rl.users, now)
+	rl.mu.Unlock()
+
+	return token.value != 0 || issuedAt < user.value, nil
+}
+
+// sweepRevocationEntries remove expired entries once cache grown too large
+func sweepRevocationEntries(entries map[string]revocationEntry, now time.Time) {
+	if len(entries) < revocationCacheMaxEntries {
+		return
+	}
+
+	for key, entry := range entries {
+		if now.After(entry.expireAt) {
+			delete(entries, key)
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package helper
+
+import (
+	"context"
+
+	"github.com/streadway/amqp"
+	"go.opentelemetry.io/otel"
+)
+
+// HeadersCarrier adapt headers of RabbitMQ message as carrier of W3C trace context
+type HeadersCarrier amqp.Table
+
+// Get return value of header key
+func (c HeadersCarrier) Get(key string) string {
+	v, _ := c[key].(string)
+	return v
+}
+
+// Set will storing value into header key
+func (c HeadersCarrier) Set(key string, value string) {
+	c[key] = value
+}
+
+// Keys return all keys of headers
+func (c HeadersCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for k := range c {
+		keys = append(keys, k)
+	}
+
+	return keys
+}
+
+// InjectTraceContext will writing trace context of ctx into headers, so consumer spans continue the same trace
+func InjectTraceContext(ctx context.Context, headers amqp.Table) amqp.Table {
+	if headers == nil {
+		headers = amqp.Table{}
+	}
+
+	otel.GetTextMapPropagator().Inject(ctx, HeadersCarrier(headers))
+
+	return headers
+}
+
+// ExtractTraceContext return ctx carrying trace context of published message headers
+func ExtractTraceContext(ctx context.Context, headers amqp.Table) context.Context {
+	return otel.GetTextMapPropagator().Extract(ctx, HeadersCarrier(headers))
+}
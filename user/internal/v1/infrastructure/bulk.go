@@ -0,0 +1,20 @@
+package infrastructure
+
+import (
+	"github.com/PickHD/singkatin-revamp/user/internal/v1/application"
+)
+
+// RecoverBulkJobs will failing bulk jobs left unfinished by previously stopped instances
+func RecoverBulkJobs(app *application.App) {
+	dep := application.SetupDependencyInjection(app)
+
+	failed, err := dep.UserController.FailStaleBulkJobs()
+	if err != nil {
+		app.Logger.Error("FailStaleBulkJobs ERROR, ", err)
+		return
+	}
+
+	if failed > 0 {
+		app.Logger.Info("Failed Stale Bulk Jobs : ", failed)
+	}
+}
@@ -4,7 +4,10 @@ import (
 	"github.com/PickHD/singkatin-revamp/user/internal/v1/application"
 	"github.com/PickHD/singkatin-revamp/user/internal/v1/helper"
 	"github.com/PickHD/singkatin-revamp/user/internal/v1/middleware"
+	"github.com/PickHD/singkatin-revamp/user/internal/v1/model"
 	"github.com/gofiber/fiber/v2"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/valyala/fasthttp/fasthttpadaptor"
 
 	fiberSwagger "github.com/swaggo/fiber-swagger"
 )
@@ -21,25 +24,57 @@ func ServeHTTP(app *application.App) *fiber.App {
 func setupRouter(app *application.App) {
 	var dep = application.SetupDependencyInjection(app)
 
+	validateJWT := middleware.ValidateJWTMiddleware(app.JWKS, app.Revocations)
+
+	// link endpoints accept personal API keys as well, holding scope of the endpoint
+	readLinks := middleware.ValidateAccessMiddleware(app.JWKS, app.Revocations, dep.UserController, model.ScopeLinksRead)
+	writeLinks := middleware.ValidateAccessMiddleware(app.JWKS, app.Revocations, dep.UserController, model.ScopeLinksWrite)
+
+	metricsHandler := fasthttpadaptor.NewFastHTTPHandler(promhttp.Handler())
+	app.Application.Get("/metrics", func(c *fiber.Ctx) error {
+		metricsHandler(c.Context())
+		return nil
+	})
+
 	v1 := app.Application.Group("/v1")
 	{
 		v1.Get("/swagger/*any", fiberSwagger.WrapHandler)
 
-		v1.Get("/health-check", dep.HealthCheckController.Check)
+		v1.Get("/live", dep.HealthCheckController.Live)
+
+		v1.Get("/ready", dep.HealthCheckController.Ready)
+
+		v1.Get("/health-check", dep.HealthCheckController.Ready)
+
+		v1.Get("/me", validateJWT, dep.UserController.Profile)
+
+		v1.Put("/me/edit", validateJWT, dep.UserController.EditProfile)
+
+		v1.Post("/me/api-keys", validateJWT, dep.UserController.CreateAPIKey)
+
+		v1.Get("/me/api-keys", validateJWT, dep.UserController.APIKeys)
+
+		v1.Delete("/me/api-keys/:id", validateJWT, dep.UserController.RevokeAPIKey)
+
+		v1.Get("/dashboard", readLinks, dep.UserController.Dashboard)
+
+		v1.Post("/short/generate", writeLinks, dep.UserController.GenerateShort)
+
+		v1.Post("/short/bulk", writeLinks, dep.UserController.BulkGenerateShort)
 
-		v1.Get("/me", middleware.ValidateJWTMiddleware, dep.UserController.Profile)
+		v1.Get("/short/bulk/:id", readLinks, dep.UserController.BulkJob)
 
-		v1.Put("/me/edit", middleware.ValidateJWTMiddleware, dep.UserController.EditProfile)
+		v1.Get("/short/export", readLinks, dep.UserController.ExportShort)
 
-		v1.Get("/dashboard", middleware.ValidateJWTMiddleware, dep.UserController.Dashboard)
+		v1.Get("/short/operations/:id", readLinks, dep.UserController.ShortOperation)
 
-		v1.Post("/short/generate", middleware.ValidateJWTMiddleware, dep.UserController.GenerateShort)
+		v1.Post("/upload/avatar", validateJWT, dep.UserController.UploadAvatar)
 
-		v1.Post("/upload/avatar", middleware.ValidateJWTMiddleware, dep.UserController.UploadAvatar)
+		v1.Put("/short/:id", writeLinks, dep.UserController.UpdateShort)
 
-		v1.Put("/short/:id", middleware.ValidateJWTMiddleware, dep.UserController.UpdateShort)
+		v1.Delete("/short/:id", writeLinks, dep.UserController.DeleteShort)
 
-		v1.Delete("/short/:id", middleware.ValidateJWTMiddleware, dep.UserController.DeleteShort)
+		v1.Get("/short/:id/stats", readLinks, dep.UserController.ShortStats)
 	}
 
 	// handler for route not found
@@ -0,0 +1,33 @@
+package infrastructure
+
+import (
+	"time"
+
+	"github.com/PickHD/singkatin-revamp/user/internal/v1/application"
+)
+
+// defaultOutboxRelayInterval (seconds) is delay between outbox relays when not configured
+const defaultOutboxRelayInterval = 1
+
+// RelayOutbox will publishing pending outbox messages into queues periodically in background
+func RelayOutbox(app *application.App) {
+	dep := application.SetupDependencyInjection(app)
+
+	interval := app.Config.Outbox.RelayInterval
+	if interval <= 0 {
+		interval = defaultOutboxRelayInterval
+	}
+
+	app.Logger.Info("Relaying Outbox Messages every ", interval, " seconds.....")
+
+	go func() {
+		ticker := time.NewTicker(time.Duration(interval) * time.Second)
+		defer ticker.Stop()
+
+		for range ticker.C {
+			if err := dep.UserController.RelayOutboxMessages(); err != nil {
+				app.Logger.Error("RelayOutboxMessages ERROR, ", err)
+			}
+		}
+	}()
+}
@@ -0,0 +1,43 @@
+package infrastructure
+
+import (
+	"fmt"
+
+	"github.com/PickHD/singkatin-revamp/user/internal/v1/application"
+	"github.com/PickHD/singkatin-revamp/user/internal/v1/model"
+	"github.com/streadway/amqp"
+)
+
+// ConsumeOperationReplies will recording outcome of short operations replied by shortener in background,
+// replies kept in queue until recorded so none lost when instance stopped
+func ConsumeOperationReplies(app *application.App) {
+	dep := application.SetupDependencyInjection(app)
+
+	queueName := app.Config.RabbitMQ.QueueOperationReply
+
+	app.Logger.Info("Waiting Message in Queues ", queueName, ".....")
+
+	app.RabbitMQ.Consume(queueName, false, func(msg amqp.Delivery) {
+		err := dep.UserController.FinishShortOperation(msg.CorrelationId, msg.Body)
+		if err != nil && !model.IsKind(err, model.Validation) {
+			app.Logger.Error("FinishShortOperation ERROR, ", err)
+
+			// recording failed by database, redelivered later
+			if errNack := msg.Nack(false, true); errNack != nil {
+				app.Logger.Error("Nack message ERROR, ", errNack)
+			}
+
+			return
+		}
+
+		if err != nil {
+			app.Logger.Error("FinishShortOperation ERROR, ", err)
+		}
+
+		if errAck := msg.Ack(false); errAck != nil {
+			app.Logger.Error("Ack message ERROR, ", errAck)
+		}
+
+		app.Logger.Info(fmt.Sprintf("[%s] Success Process Message :", queueName), msg.CorrelationId)
+	})
+}
@@ -18,48 +18,97 @@ type (
 		FullName string `json:"full_name"`
 		Email    string `json:"email"`
 	}
+
+	// tokenIdentity consists identity of token used by revocation list
+	tokenIdentity struct {
+		jti      string
+		issuedAt int64
+	}
+
+	// APIKeyAuthenticator authenticate personal API keys holding scope, returning payload of its owner
+	APIKeyAuthenticator interface {
+		AuthenticateAPIKey(key string, scope string) (DecodePayloadData, error)
+	}
 )
 
 const (
 	payloadUserID   string = "user_id"
 	payloadFullName string = "full_name"
 	payloadEmail    string = "email"
+	payloadJTI      string = "jti"
+	payloadIssuedAt string = "iat"
 	payloadExpires  string = "exp"
 
-	JWTExpire time.Duration = time.Duration(7) * time.Hour
+	authSchemeAPIKey string = "ApiKey "
 )
 
-// ValidateJWTMiddleware responsible to validating jwt in header each request
-func ValidateJWTMiddleware(ctx *fiber.Ctx) error {
-	// validate JWT coming from request, if valid decode into a struct
-	decodedPayload, err := validate(ctx)
-	if err != nil {
-		return helper.NewResponses[any](ctx, fiber.StatusUnauthorized, fmt.Sprintf("Unauthorized access, reason : %s", err.Error()), err, nil, nil)
+// ValidateJWTMiddleware responsible to validating jwt in header each request against public keys published by auth services,
+// token revoked by logging out rejected as well
+func ValidateJWTMiddleware(jwks *helper.JWKS, revocations *helper.RevocationList) fiber.Handler {
+	return func(ctx *fiber.Ctx) error {
+		// validate JWT coming from request, if valid decode into a struct
+		decodedPayload, tokenID, err := validate(ctx, jwks)
+		if err != nil {
+			return helper.NewResponses[any](ctx, fiber.StatusUnauthorized, fmt.Sprintf("Unauthorized access, reason : %s", err.Error()), err, nil, nil)
+		}
+
+		// consult revocation list, mostly answered by local cache
+		revoked, err := revocations.IsRevoked(ctx.Context(), tokenID.jti, decodedPayload.UserID, tokenID.issuedAt)
+		if err != nil {
+			return helper.NewErrorResponses[any](ctx, err, "Failed validate token", nil)
+		}
+
+		if revoked {
+			err = model.NewError(model.Validation, "Token revoked")
+			return helper.NewResponses[any](ctx, fiber.StatusUnauthorized, fmt.Sprintf("Unauthorized access, reason : %s", err.Error()), err, nil, nil)
+		}
+
+		// pass decoded payload into ctx.Locals()
+		ctx.Locals(model.KeyJWTValidAccess, decodedPayload)
+
+		// going to next handler..
+		return ctx.Next()
 	}
+}
+
+// ValidateAccessMiddleware responsible to validating either Bearer jwt or ApiKey in header each request,
+// API keys only accepted when holding scope, so links can be managed programmatically without interactive login
+func ValidateAccessMiddleware(jwks *helper.JWKS, revocations *helper.RevocationList, apiKeys APIKeyAuthenticator, scope string) fiber.Handler {
+	validateJWT := ValidateJWTMiddleware(jwks, revocations)
 
-	// pass decoded payload into ctx.Locals()
-	ctx.Locals(model.KeyJWTValidAccess, decodedPayload)
+	return func(ctx *fiber.Ctx) error {
+		header := ctx.Get("Authorization", "")
+		if !strings.HasPrefix(header, authSchemeAPIKey) {
+			return validateJWT(ctx)
+		}
+
+		decodedPayload, err := apiKeys.AuthenticateAPIKey(strings.TrimPrefix(header, authSchemeAPIKey), scope)
+		if err != nil {
+			if model.IsKind(err, model.Validation) {
+				return helper.NewResponses[any](ctx, fiber.StatusUnauthorized, fmt.Sprintf("Unauthorized access, reason : %s", err.Error()), err, nil, nil)
+			}
+
+			return helper.NewErrorResponses[any](ctx, err, "Failed validate api key", nil)
+		}
 
-	// going to next handler..
-	return ctx.Next()
+		// pass owner of API key into ctx.Locals(), handled the same as decoded jwt payload
+		ctx.Locals(model.KeyJWTValidAccess, decodedPayload)
+
+		return ctx.Next()
+	}
 }
 
-// validate will checking validity of signed JWT token from request in
-func validate(ctx *fiber.Ctx) (DecodePayloadData, error) {
+// validate will checking validity of signed JWT token from request in, along with identity used to revoke the token
+func validate(ctx *fiber.Ctx, jwks *helper.JWKS) (DecodePayloadData, tokenIdentity, error) {
 	header := ctx.Get("Authorization", "")
 	if !strings.Contains(header, "Bearer") {
-		return DecodePayloadData{}, model.NewError(model.NotFound, "Token not found")
+		return DecodePayloadData{}, tokenIdentity{}, model.NewError(model.NotFound, "Token not found")
 	}
 
 	getToken := strings.Replace(header, "Bearer ", "", -1)
-	validToken, err := jwt.Parse(getToken, func(token *jwt.Token) (interface{}, error) {
-		if _, isValid := token.Method.(*jwt.SigningMethodHMAC); !isValid {
-			return nil, model.NewError(model.Validation, "Invalid token")
-		}
-		return []byte(helper.GetEnvString("JWT_SECRET")), nil
-	})
+	validToken, err := jwt.Parse(getToken, jwks.Keyfunc)
 	if err != nil {
-		return DecodePayloadData{}, err
+		return DecodePayloadData{}, tokenIdentity{}, err
 	}
 
 	claims := validToken.Claims.(jwt.MapClaims)
@@ -68,18 +117,32 @@ func validate(ctx *fiber.Ctx) (DecodePayloadData, error) {
 	if expInt, ok := claims[payloadExpires].(float64); ok {
 		now := time.Now().Unix()
 		if now > int64(expInt) {
-			return DecodePayloadData{}, model.NewError(model.Validation, "Token expired")
+			return DecodePayloadData{}, tokenIdentity{}, model.NewError(model.Validation, "Token expired")
 		}
 	} else {
-		return DecodePayloadData{}, model.NewError(model.Type, "type assertion payload exp error")
+		return DecodePayloadData{}, tokenIdentity{}, model.NewError(model.Type, "type assertion payload exp error")
 	}
 
 	decodePayload, err := insertPayload(claims)
 	if err != nil {
-		return DecodePayloadData{}, err
+		return DecodePayloadData{}, tokenIdentity{}, err
+	}
+
+	identity := tokenIdentity{}
+
+	if jti, ok := claims[payloadJTI].(string); ok {
+		identity.jti = jti
+	} else {
+		return DecodePayloadData{}, tokenIdentity{}, model.NewError(model.Type, "type assertion payload jti error")
+	}
+
+	if issuedAt, ok := claims[payloadIssuedAt].(float64); ok {
+		identity.issuedAt = int64(issuedAt)
+	} else {
+		return DecodePayloadData{}, tokenIdentity{}, model.NewError(model.Type, "type assertion payload iat error")
 	}
 
-	return decodePayload, nil
+	return decodePayload, identity, nil
 }
 
 // Extract will extracting payload data from ctx.Locals
@@ -0,0 +1,30 @@
+package middleware
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/PickHD/singkatin-revamp/user/internal/v1/helper"
+	"github.com/gofiber/fiber/v2"
+)
+
+// MetricsMiddleware will observing latency & status of every request
+func MetricsMiddleware(c *fiber.Ctx) error {
+	start := time.Now()
+
+	err := c.Next()
+
+	// status of returned error written by error handler after middleware, so taken from the error
+	code := c.Response().StatusCode()
+	if err != nil {
+		code = fiber.StatusInternalServerError
+
+		if fe, ok := err.(*fiber.Error); ok {
+			code = fe.Code
+		}
+	}
+
+	helper.HTTPRequestDuration.WithLabelValues(c.Method(), c.Route().Path, strconv.Itoa(code)).Observe(time.Since(start).Seconds())
+
+	return err
+}
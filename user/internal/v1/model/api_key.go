@@ -0,0 +1,52 @@
+package model
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	// ScopeLinksRead & ScopeLinksWrite are scopes granted to API keys, reading or managing short users
+	ScopeLinksRead  = "links:read"
+	ScopeLinksWrite = "links:write"
+
+	// APIKeyPrefix is prefix of every generated API keys, so leaked keys easily recognized
+	APIKeyPrefix = "sgk_"
+)
+
+var (
+	// APIKeyScopes is scopes API keys can be granted
+	APIKeyScopes = map[string]bool{
+		ScopeLinksRead:  true,
+		ScopeLinksWrite: true,
+	}
+)
+
+type (
+	// APIKey consist personal API key of users calling link endpoints programmatically, only hash of the key stored
+	APIKey struct {
+		ID         primitive.ObjectID `bson:"_id" json:"id"`
+		UserID     string             `bson:"user_id" json:"-"`
+		Name       string             `bson:"name" json:"name"`
+		Prefix     string             `bson:"prefix" json:"prefix"`
+		KeyHash    string             `bson:"key_hash" json:"-"`
+		Scopes     []string           `bson:"scopes" json:"scopes"`
+		ExpiresAt  *time.Time         `bson:"expires_at,omitempty" json:"expires_at,omitempty"`
+		LastUsedAt *time.Time         `bson:"last_used_at,omitempty" json:"last_used_at,omitempty"`
+		CreatedAt  time.Time          `bson:"created_at" json:"created_at"`
+	}
+
+	// CreateAPIKeyRequest consist request creating API key, never expired when expires_at empty
+	CreateAPIKeyRequest struct {
+		Name      string     `json:"name"`
+		Scopes    []string   `json:"scopes"`
+		ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	}
+
+	// CreateAPIKeyResponse consist created API key along with the key itself, only responded once
+	CreateAPIKeyResponse struct {
+		APIKey
+		Key string `json:"key"`
+	}
+)
@@ -0,0 +1,41 @@
+package model
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	// BulkJobPending etc. are status of bulk short users job
+	BulkJobPending    = "pending"
+	BulkJobProcessing = "processing"
+	BulkJobCompleted  = "completed"
+	BulkJobFailed     = "failed"
+)
+
+type (
+	// BulkJob consist progress & per-row report of bulk short users creation
+	BulkJob struct {
+		ID         primitive.ObjectID `bson:"_id" json:"id"`
+		UserID     string             `bson:"user_id" json:"-"`
+		Status     string             `bson:"status" json:"status"`
+		Total      int                `bson:"total" json:"total"`
+		Processed  int                `bson:"processed" json:"processed"`
+		Succeeded  int                `bson:"succeeded" json:"succeeded"`
+		Failed     int                `bson:"failed" json:"failed"`
+		Results    []BulkShortResult  `bson:"results" json:"results"`
+		Error      string             `bson:"error,omitempty" json:"error,omitempty"`
+		CreatedAt  time.Time          `bson:"created_at" json:"created_at"`
+		UpdatedAt  time.Time          `bson:"updated_at" json:"updated_at"`
+		FinishedAt *time.Time         `bson:"finished_at,omitempty" json:"finished_at,omitempty"`
+	}
+
+	// BulkShortResult consist result of single row bulk short users, row is 1-based index of JSON item / CSV data row
+	BulkShortResult struct {
+		Row      int    `bson:"row" json:"row"`
+		FullURL  string `bson:"full_url" json:"full_url"`
+		ShortURL string `bson:"short_url,omitempty" json:"short_url,omitempty"`
+		Error    string `bson:"error,omitempty" json:"error,omitempty"`
+	}
+)
@@ -1,6 +1,20 @@
 package model
 
+const (
+	// RevokedAccessTokenKey & RevokedAccessUserKey are revocation list keys maintained by auth services, of single access token by its jti
+	// & every access token of users issued before stored unix time
+	RevokedAccessTokenKey = "revoked_access_token:%s"
+	RevokedAccessUserKey  = "revoked_access_user:%s"
+)
+
 var (
 	// KeyJWTValidAccess is context key identifier for valid jwt token
 	KeyJWTValidAccess = "ValidJWTAccess"
+
+	// ExportFormats is supported format of exported short users with its content type
+	ExportFormats = map[string]string{
+		"csv":    "text/csv",
+		"json":   "application/json",
+		"ndjson": "application/x-ndjson",
+	}
 )
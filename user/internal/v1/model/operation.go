@@ -0,0 +1,41 @@
+package model
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	// OperationPending etc. are status of async short users operation
+	OperationPending   = "pending"
+	OperationCompleted = "completed"
+	OperationFailed    = "failed"
+
+	// OperationCreate etc. are type of async short users operation
+	OperationCreate = "create"
+	OperationUpdate = "update"
+	OperationDelete = "delete"
+)
+
+type (
+	// ShortOperation consist status & outcome of short users operation processed through queue
+	ShortOperation struct {
+		ID         primitive.ObjectID `bson:"_id" json:"id"`
+		UserID     string             `bson:"user_id" json:"-"`
+		Type       string             `bson:"type" json:"type"`
+		Status     string             `bson:"status" json:"status"`
+		ShortID    string             `bson:"short_id,omitempty" json:"short_id,omitempty"`
+		ShortURL   string             `bson:"short_url,omitempty" json:"short_url,omitempty"`
+		Error      string             `bson:"error,omitempty" json:"error,omitempty"`
+		CreatedAt  time.Time          `bson:"created_at" json:"created_at"`
+		UpdatedAt  time.Time          `bson:"updated_at" json:"updated_at"`
+		FinishedAt *time.Time         `bson:"finished_at,omitempty" json:"finished_at,omitempty"`
+	}
+
+	// ShortOperationReply consist outcome of short users operation replied by shortener
+	ShortOperationReply struct {
+		ShortURL string
+		Error    string
+	}
+)
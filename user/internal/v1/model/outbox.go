@@ -0,0 +1,23 @@
+package model
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type (
+	// OutboxMessage consist queue message written along with its database changes, published later by outbox relay
+	OutboxMessage struct {
+		ID          primitive.ObjectID  `bson:"_id"`
+		Queue       string              `bson:"queue"`
+		Body        []byte              `bson:"body"`
+		Headers     map[string]string   `bson:"headers,omitempty"`
+		OperationID *primitive.ObjectID `bson:"operation_id,omitempty"`
+		Attempts    int                 `bson:"attempts"`
+		LastError   string              `bson:"last_error,omitempty"`
+		LockedUntil time.Time           `bson:"locked_until"`
+		FailedAt    *time.Time          `bson:"failed_at,omitempty"`
+		CreatedAt   time.Time           `bson:"created_at"`
+	}
+)
@@ -6,6 +6,13 @@ import (
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
+const (
+	// ShortFieldExpiresAt etc. are limits of short users able to be cleared when updating short users
+	ShortFieldExpiresAt = "expires_at"
+	ShortFieldMaxClicks = "max_clicks"
+	ShortFieldPassword  = "password"
+)
+
 type (
 	// User consist data of users
 	User struct {
@@ -18,15 +25,36 @@ type (
 
 	// UserShorts consist data of user shorts
 	UserShorts struct {
-		ID       string `json:"id"`
-		FullURL  string `json:"full_url"`
-		ShortURL string `json:"short_url"`
-		Visited  int64  `json:"visited"`
+		ID          string     `json:"id"`
+		FullURL     string     `json:"full_url"`
+		ShortURL    string     `json:"short_url"`
+		Visited     int64      `json:"visited"`
+		ExpiresAt   *time.Time `json:"expires_at,omitempty"`
+		MaxClicks   int64      `json:"max_clicks,omitempty"`
+		IsProtected bool       `json:"is_protected"`
+		Tags        []string   `json:"tags,omitempty"`
+		QRURL       string     `json:"qr_url"`
+		CreatedAt   *time.Time `json:"created_at,omitempty"`
 	}
 
-	// ShortUserRequest consist request data generate/update short users
+	// ShortUserRequest consist request data generate short users
 	ShortUserRequest struct {
-		FullURL string `json:"full_url"`
+		FullURL   string     `json:"full_url"`
+		Alias     string     `json:"alias,omitempty"`
+		ExpiresAt *time.Time `json:"expires_at,omitempty"`
+		MaxClicks int64      `json:"max_clicks,omitempty"`
+		Password  string     `json:"password,omitempty"`
+		Tags      []string   `json:"tags,omitempty"`
+	}
+
+	// UpdateShortUserRequest consist request data update short users, only given fields updated & fields listed
+	// on clear (expires_at, max_clicks, password) removed
+	UpdateShortUserRequest struct {
+		FullURL   *string    `json:"full_url,omitempty"`
+		ExpiresAt *time.Time `json:"expires_at,omitempty"`
+		MaxClicks *int64     `json:"max_clicks,omitempty"`
+		Password  *string    `json:"password,omitempty"`
+		Clear     []string   `json:"clear,omitempty"`
 	}
 
 	// ShortUserResponse consist response data when success generate/update short users
@@ -37,9 +65,46 @@ type (
 
 	// GenerateShortUserMessage consist message short users to publish
 	GenerateShortUserMessage struct {
-		FullURL  string `json:"full_url"`
-		ShortURL string `json:"short_url"`
-		UserID   string `json:"user_id"`
+		FullURL      string     `json:"full_url"`
+		ShortURL     string     `json:"short_url"`
+		UserID       string     `json:"user_id"`
+		IsCustom     bool       `json:"is_custom"`
+		ExpiresAt    *time.Time `json:"expires_at"`
+		MaxClicks    int64      `json:"max_clicks"`
+		PasswordHash string     `json:"password_hash"`
+		Tags         []string   `json:"tags"`
+	}
+
+	// DashboardRequest consist request data pagination, sorting & filter of short users
+	DashboardRequest struct {
+		Page      int64  `query:"page"`
+		PageSize  int64  `query:"page_size"`
+		SortBy    string `query:"sort_by"`
+		SortOrder string `query:"sort_order"`
+		Search    string `query:"search"`
+		Tag       string `query:"tag"`
+	}
+
+	// ShortStatsRequest consist request data click stats of short users
+	ShortStatsRequest struct {
+		From string `query:"from"`
+		To   string `query:"to"`
+	}
+
+	// ShortStats consist aggregated clicks of short users
+	ShortStats struct {
+		ShortURL   string     `json:"short_url"`
+		Total      int64      `json:"total"`
+		ByDay      []StatItem `json:"by_day"`
+		ByReferrer []StatItem `json:"by_referrer"`
+		ByCountry  []StatItem `json:"by_country"`
+		ByDevice   []StatItem `json:"by_device"`
+	}
+
+	// StatItem consist count of clicks for single key of breakdown
+	StatItem struct {
+		Key   string `json:"key"`
+		Count int64  `json:"count"`
 	}
 
 	// EditProfileRequest consist request data edit profile users
@@ -47,13 +112,6 @@ type (
 		FullName string `json:"full_name"`
 	}
 
-	// UploadAvatarRequest consist request data upload avatar users
-	UploadAvatarRequest struct {
-		FileName    string
-		ContentType string
-		Avatars     []byte
-	}
-
 	// UploadAvatarResponse consist response data when success upload avatar users
 	UploadAvatarResponse struct {
 		FileURL string
@@ -2,48 +2,113 @@ package repository
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/PickHD/singkatin-revamp/user/internal/v1/config"
+	"github.com/PickHD/singkatin-revamp/user/internal/v1/helper"
+	shortenerpb "github.com/PickHD/singkatin-revamp/user/pkg/api/v1/proto/shortener"
+	"github.com/redis/go-redis/v9"
 	"github.com/sirupsen/logrus"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.opentelemetry.io/otel/sdk/trace"
+	"google.golang.org/grpc"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 )
 
 type (
 	// HealthCheckRepository is an interface that has all the function to be implemented inside health check repository
 	HealthCheckRepository interface {
-		Check() (bool, error)
+		PingDB(ctx context.Context) error
+		PingRedis(ctx context.Context) error
+		PingRabbitMQ(ctx context.Context) error
+		PingShortener(ctx context.Context) error
 	}
 
 	// HealthCheckRepositoryImpl is an app health check struct that consists of all the dependencies needed for health check repository
 	HealthCheckRepositoryImpl struct {
-		Context context.Context
-		Config  *config.Configuration
-		Logger  *logrus.Logger
-		Tracer  *trace.TracerProvider
-		DB      *mongo.Database
+		Context  context.Context
+		Config   *config.Configuration
+		Logger   *logrus.Logger
+		Tracer   *trace.TracerProvider
+		DB       *mongo.Database
+		Redis    *redis.Client
+		RabbitMQ *helper.Broker
+		GRPC     *grpc.ClientConn
 	}
 )
 
 // NewHealthCheckRepository return new instances health check repository
-func NewHealthCheckRepository(ctx context.Context, config *config.Configuration, logger *logrus.Logger, tracer *trace.TracerProvider, db *mongo.Database) *HealthCheckRepositoryImpl {
+func NewHealthCheckRepository(ctx context.Context, config *config.Configuration, logger *logrus.Logger, tracer *trace.TracerProvider, db *mongo.Database, rds *redis.Client, amqp *helper.Broker, grpcConn *grpc.ClientConn) *HealthCheckRepositoryImpl {
 	return &HealthCheckRepositoryImpl{
-		Context: ctx,
-		Config:  config,
-		Logger:  logger,
-		Tracer:  tracer,
-		DB:      db,
+		Context:  ctx,
+		Config:   config,
+		Logger:   logger,
+		Tracer:   tracer,
+		DB:       db,
+		Redis:    rds,
+		RabbitMQ: amqp,
+		GRPC:     grpcConn,
 	}
 }
 
-func (hr *HealthCheckRepositoryImpl) Check() (bool, error) {
-	tr := hr.Tracer.Tracer("User-Check Repository")
-	_, span := tr.Start(hr.Context, "Start Check")
+func (hr *HealthCheckRepositoryImpl) PingDB(ctx context.Context) error {
+	tr := hr.Tracer.Tracer("User-PingDB Repository")
+	ctx, span := tr.Start(ctx, "Start PingDB")
 	defer span.End()
 
-	if err := hr.DB.Client().Ping(hr.Context, nil); err != nil {
-		hr.Logger.Error("HealthCheckRepositoryImpl.Check() Ping DB ERROR, ", err)
-		return false, nil
+	if err := hr.DB.Client().Ping(ctx, nil); err != nil {
+		hr.Logger.Error("HealthCheckRepositoryImpl.PingDB ERROR, ", err)
+		return err
 	}
-	return true, nil
+
+	return nil
+}
+
+func (hr *HealthCheckRepositoryImpl) PingRedis(ctx context.Context) error {
+	tr := hr.Tracer.Tracer("User-PingRedis Repository")
+	ctx, span := tr.Start(ctx, "Start PingRedis")
+	defer span.End()
+
+	if err := hr.Redis.Ping(ctx).Err(); err != nil {
+		hr.Logger.Error("HealthCheckRepositoryImpl.PingRedis ERROR, ", err)
+		return err
+	}
+
+	return nil
+}
+
+func (hr *HealthCheckRepositoryImpl) PingRabbitMQ(ctx context.Context) error {
+	tr := hr.Tracer.Tracer("User-PingRabbitMQ Repository")
+	_, span := tr.Start(ctx, "Start PingRabbitMQ")
+	defer span.End()
+
+	if err := hr.RabbitMQ.Ping(); err != nil {
+		hr.Logger.Error("HealthCheckRepositoryImpl.PingRabbitMQ ERROR, ", err)
+		return err
+	}
+
+	return nil
+}
+
+func (hr *HealthCheckRepositoryImpl) PingShortener(ctx context.Context) error {
+	tr := hr.Tracer.Tracer("User-PingShortener Repository")
+	ctx, span := tr.Start(ctx, "Start PingShortener")
+	defer span.End()
+
+	res, err := healthpb.NewHealthClient(hr.GRPC).Check(ctx, &healthpb.HealthCheckRequest{
+		Service: shortenerpb.ShortenerService_ServiceDesc.ServiceName,
+	})
+	if err != nil {
+		hr.Logger.Error("HealthCheckRepositoryImpl.PingShortener ERROR, ", err)
+		return err
+	}
+
+	if res.GetStatus() != healthpb.HealthCheckResponse_SERVING {
+		err = fmt.Errorf("shortener grpc service %s", res.GetStatus().String())
+
+		hr.Logger.Error("HealthCheckRepositoryImpl.PingShortener ERROR, ", err)
+		return err
+	}
+
+	return nil
 }
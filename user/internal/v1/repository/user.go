@@ -2,16 +2,20 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/PickHD/singkatin-revamp/user/internal/v1/config"
+	"github.com/PickHD/singkatin-revamp/user/internal/v1/helper"
 	"github.com/PickHD/singkatin-revamp/user/internal/v1/model"
 	shortenerpb "github.com/PickHD/singkatin-revamp/user/pkg/api/v1/proto/shortener"
-	uploadpb "github.com/PickHD/singkatin-revamp/user/pkg/api/v1/proto/upload"
 	"github.com/sirupsen/logrus"
 	"github.com/streadway/amqp"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
 	"go.opentelemetry.io/otel/sdk/trace"
 	"google.golang.org/protobuf/proto"
 )
@@ -20,12 +24,33 @@ type (
 	// UserRepository is an interface that has all the function to be implemented inside user repository
 	UserRepository interface {
 		FindByEmail(ctx context.Context, email string) (*model.User, error)
-		PublishCreateUserShortener(ctx context.Context, req *model.GenerateShortUserMessage) error
+		PublishCreateUserShortener(ctx context.Context, msg *shortenerpb.CreateShortenerMessage) (string, error)
 		UpdateProfileByID(ctx context.Context, userID string, req *model.EditProfileRequest) error
-		PublishUploadAvatarUser(ctx context.Context, req *model.UploadAvatarRequest) error
 		UpdateAvatarUserByID(ctx context.Context, fileURL string, userID string) error
-		PublishUpdateUserShortener(ctx context.Context, shortID string, req *model.ShortUserRequest) error
-		PublishDeleteUserShortener(ctx context.Context, shortID string) error
+		CreateBulkJob(ctx context.Context, req *model.BulkJob) error
+		FindBulkJobByID(ctx context.Context, userID string, jobID string) (*model.BulkJob, error)
+		UpdateBulkJobProgressByID(ctx context.Context, jobID primitive.ObjectID, results []model.BulkShortResult, succeeded int, failed int) error
+		UpdateBulkJobStatusByID(ctx context.Context, jobID primitive.ObjectID, status string) error
+		FailBulkJobByID(ctx context.Context, jobID primitive.ObjectID, errMsg string) error
+		FailStaleBulkJobs(ctx context.Context, staleBefore time.Time, errMsg string) (int64, error)
+		CreateShortOperation(ctx context.Context, req *model.ShortOperation) error
+		FindShortOperationByID(ctx context.Context, userID string, operationID string) (*model.ShortOperation, error)
+		FindPendingShortOperationByID(ctx context.Context, operationID primitive.ObjectID) (*model.ShortOperation, error)
+		DecodeShortOperationReply(ctx context.Context, opType string, body []byte) (*model.ShortOperationReply, error)
+		UpdateShortOperationResultByID(ctx context.Context, operationID primitive.ObjectID, shortURL string, errMsg string) error
+		WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+		CreateOutboxMessage(ctx context.Context, queue string, operationID *primitive.ObjectID, msg proto.Message) error
+		ClaimOutboxMessage(ctx context.Context, lockDuration time.Duration) (*model.OutboxMessage, error)
+		PublishOutboxMessage(ctx context.Context, req *model.OutboxMessage) error
+		DeleteOutboxMessageByID(ctx context.Context, outboxID primitive.ObjectID) error
+		ReleaseOutboxMessageByID(ctx context.Context, outboxID primitive.ObjectID, errMsg string, retryAt time.Time) error
+		FailOutboxMessageByID(ctx context.Context, outboxID primitive.ObjectID, errMsg string) error
+		CreateAPIKey(ctx context.Context, req *model.APIKey) error
+		CountAPIKeysByUserID(ctx context.Context, userID string) (int64, error)
+		FindAPIKeysByUserID(ctx context.Context, userID string) ([]model.APIKey, error)
+		FindAPIKeyByHash(ctx context.Context, keyHash string) (*model.APIKey, error)
+		DeleteAPIKeyByID(ctx context.Context, userID string, keyID string) error
+		UpdateAPIKeyLastUsedByID(ctx context.Context, keyID primitive.ObjectID, usedAt time.Time, interval time.Duration) error
 	}
 
 	// UserRepositoryImpl is an app user struct that consists of all the dependencies needed for user repository
@@ -35,12 +60,13 @@ type (
 		Logger   *logrus.Logger
 		Tracer   *trace.TracerProvider
 		DB       *mongo.Database
-		RabbitMQ *amqp.Channel
+		RabbitMQ *helper.Broker
+		Replies  *helper.ReplyDispatcher
 	}
 )
 
 // NewUserRepository return new instances user repository
-func NewUserRepository(ctx context.Context, config *config.Configuration, logger *logrus.Logger, tracer *trace.TracerProvider, db *mongo.Database, amqp *amqp.Channel) *UserRepositoryImpl {
+func NewUserRepository(ctx context.Context, config *config.Configuration, logger *logrus.Logger, tracer *trace.TracerProvider, db *mongo.Database, amqp *helper.Broker, replies *helper.ReplyDispatcher) *UserRepositoryImpl {
 	return &UserRepositoryImpl{
 		Context:  ctx,
 		Config:   config,
@@ -48,6 +74,7 @@ func NewUserRepository(ctx context.Context, config *config.Configuration, logger
 		Tracer:   tracer,
 		DB:       db,
 		RabbitMQ: amqp,
+		Replies:  replies,
 	}
 }
 
@@ -71,27 +98,32 @@ func (ur *UserRepositoryImpl) FindByEmail(ctx context.Context, email string) (*m
 	return &user, nil
 }
 
-func (ur *UserRepositoryImpl) PublishCreateUserShortener(ctx context.Context, req *model.GenerateShortUserMessage) error {
+func (ur *UserRepositoryImpl) PublishCreateUserShortener(ctx context.Context, msg *shortenerpb.CreateShortenerMessage) (string, error) {
 	tr := ur.Tracer.Tracer("User-PublishCreateUserShortener Repository")
-	_, span := tr.Start(ctx, "Start PublishCreateUserShortener")
+	ctx, span := tr.Start(ctx, "Start PublishCreateUserShortener")
 	defer span.End()
 
-	ur.Logger.Info("data req before publish", req)
-
-	// transform data to proto
-	msg := ur.prepareProtoPublishCreateUserShortenerMessage(req)
+	ur.Logger.Info("data req before publish", msg)
 
 	b, err := proto.Marshal(msg)
 	if err != nil {
 		ur.Logger.Error("UserRepositoryImpl.PublishCreateUserShortener Marshal proto CreateShortenerMessage ERROR, ", err)
-		return err
+		return "", err
 	}
 
+	correlationID := primitive.NewObjectID().Hex()
+
 	message := amqp.Publishing{
-		ContentType: "text/plain",
-		Body:        []byte(b),
+		Headers:       helper.InjectTraceContext(ctx, nil),
+		ContentType:   "text/plain",
+		CorrelationId: correlationID,
+		ReplyTo:       ur.Replies.Queue(),
+		Body:          []byte(b),
 	}
 
+	replies := ur.Replies.Register(correlationID)
+	defer ur.Replies.Cancel(correlationID)
+
 	// Attempt to publish a message to the queue.
 	if err := ur.RabbitMQ.Publish(
 		"",                                      // exchange
@@ -101,12 +133,13 @@ func (ur *UserRepositoryImpl) PublishCreateUserShortener(ctx context.Context, re
 		message,                                 // message to publish
 	); err != nil {
 		ur.Logger.Error("UserRepositoryImpl.PublishCreateUserShortener RabbitMQ.Publish ERROR, ", err)
-		return err
+		return "", err
 	}
 
 	ur.Logger.Info("Success Publish User Shortener to Queue: ", ur.Config.RabbitMQ.QueueCreateShortener)
 
-	return nil
+	// wait shortener to reply the final short url
+	return ur.waitCreateShortenerReply("PublishCreateUserShortener", correlationID, replies)
 }
 
 func (ur *UserRepositoryImpl) UpdateProfileByID(ctx context.Context, userID string, req *model.EditProfileRequest) error {
@@ -132,168 +165,608 @@ func (ur *UserRepositoryImpl) UpdateProfileByID(ctx context.Context, userID stri
 	return nil
 }
 
-func (ur *UserRepositoryImpl) PublishUploadAvatarUser(ctx context.Context, req *model.UploadAvatarRequest) error {
-	tr := ur.Tracer.Tracer("User-PublishUploadAvatarUser Repository")
-	_, span := tr.Start(ctx, "Start PublishUploadAvatarUser")
+func (ur *UserRepositoryImpl) UpdateAvatarUserByID(ctx context.Context, fileURL string, userID string) error {
+	tr := ur.Tracer.Tracer("User-UpdateAvatarUserByID Repository")
+	_, span := tr.Start(ctx, "Start UpdateAvatarUserByID")
 	defer span.End()
 
-	ur.Logger.Info("data req before publish", req)
+	objUserID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		ur.Logger.Error("UserRepositoryImpl.UpdateAvatarUserByID primitive.ObjectIDFromHex ERROR, ", err)
+		return err
+	}
 
-	// transform data to proto
-	msg := ur.prepareProtoPublishUploadAvatarUserMessage(req)
+	_, err = ur.DB.Collection(ur.Config.Database.UsersCollection).UpdateOne(ctx,
+		bson.D{{Key: "_id", Value: objUserID}}, bson.M{
+			"$set": bson.D{{Key: "avatar_url", Value: fileURL}},
+		})
+	if err != nil {
+		ur.Logger.Error("UserRepositoryImpl.UpdateAvatarUserByID UpdateOne ERROR, ", err)
+		return err
+	}
 
-	b, err := proto.Marshal(msg)
+	return nil
+}
+
+func (ur *UserRepositoryImpl) CreateBulkJob(ctx context.Context, req *model.BulkJob) error {
+	tr := ur.Tracer.Tracer("User-CreateBulkJob Repository")
+	_, span := tr.Start(ctx, "Start CreateBulkJob")
+	defer span.End()
+
+	_, err := ur.DB.Collection(ur.Config.Database.BulkJobsCollection).InsertOne(ctx, req)
 	if err != nil {
-		ur.Logger.Error("UserRepositoryImpl.PublishUploadAvatarUser Marshal proto UploadAvatarMessage ERROR, ", err)
+		ur.Logger.Error("UserRepositoryImpl.CreateBulkJob InsertOne ERROR, ", err)
 		return err
 	}
 
-	message := amqp.Publishing{
-		ContentType: "text/plain",
-		Body:        []byte(b),
+	return nil
+}
+
+func (ur *UserRepositoryImpl) FindBulkJobByID(ctx context.Context, userID string, jobID string) (*model.BulkJob, error) {
+	tr := ur.Tracer.Tracer("User-FindBulkJobByID Repository")
+	_, span := tr.Start(ctx, "Start FindBulkJobByID")
+	defer span.End()
+
+	objJobID, err := primitive.ObjectIDFromHex(jobID)
+	if err != nil {
+		return nil, model.NewError(model.NotFound, "bulk job not found")
 	}
 
-	// Attempt to publish a message to the queue.
-	if err := ur.RabbitMQ.Publish(
-		"",                                   // exchange
-		ur.Config.RabbitMQ.QueueUploadAvatar, // queue name
-		false,                                // mandatory
-		false,                                // immediate
-		message,                              // message to publish
-	); err != nil {
-		ur.Logger.Error("UserRepositoryImpl.PublishUploadAvatarUser RabbitMQ.Publish ERROR, ", err)
+	job := model.BulkJob{}
+
+	err = ur.DB.Collection(ur.Config.Database.BulkJobsCollection).FindOne(ctx,
+		bson.D{{Key: "_id", Value: objJobID}, {Key: "user_id", Value: userID}}).Decode(&job)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, model.NewError(model.NotFound, "bulk job not found")
+		}
+
+		ur.Logger.Error("UserRepositoryImpl.FindBulkJobByID FindOne ERROR, ", err)
+		return nil, err
+	}
+
+	return &job, nil
+}
+
+func (ur *UserRepositoryImpl) UpdateBulkJobProgressByID(ctx context.Context, jobID primitive.ObjectID, results []model.BulkShortResult, succeeded int, failed int) error {
+	tr := ur.Tracer.Tracer("User-UpdateBulkJobProgressByID Repository")
+	_, span := tr.Start(ctx, "Start UpdateBulkJobProgressByID")
+	defer span.End()
+
+	_, err := ur.DB.Collection(ur.Config.Database.BulkJobsCollection).UpdateOne(ctx,
+		bson.D{{Key: "_id", Value: jobID}}, bson.M{
+			"$push": bson.D{{Key: "results", Value: bson.D{{Key: "$each", Value: results}}}},
+			"$inc": bson.D{{Key: "processed", Value: len(results)},
+				{Key: "succeeded", Value: succeeded},
+				{Key: "failed", Value: failed}},
+			"$set": bson.D{{Key: "updated_at", Value: time.Now()}},
+		})
+	if err != nil {
+		ur.Logger.Error("UserRepositoryImpl.UpdateBulkJobProgressByID UpdateOne ERROR, ", err)
 		return err
 	}
 
-	ur.Logger.Info("Success Publish Upload Avatar Users to Queue: ", ur.Config.RabbitMQ.QueueUploadAvatar)
+	return nil
+}
+
+func (ur *UserRepositoryImpl) UpdateBulkJobStatusByID(ctx context.Context, jobID primitive.ObjectID, status string) error {
+	tr := ur.Tracer.Tracer("User-UpdateBulkJobStatusByID Repository")
+	_, span := tr.Start(ctx, "Start UpdateBulkJobStatusByID")
+	defer span.End()
+
+	now := time.Now()
+
+	set := bson.D{{Key: "status", Value: status}, {Key: "updated_at", Value: now}}
+	if status == model.BulkJobCompleted {
+		set = append(set, bson.E{Key: "finished_at", Value: now})
+	}
+
+	_, err := ur.DB.Collection(ur.Config.Database.BulkJobsCollection).UpdateOne(ctx,
+		bson.D{{Key: "_id", Value: jobID}}, bson.M{"$set": set})
+	if err != nil {
+		ur.Logger.Error("UserRepositoryImpl.UpdateBulkJobStatusByID UpdateOne ERROR, ", err)
+		return err
+	}
 
 	return nil
 }
 
-func (ur *UserRepositoryImpl) UpdateAvatarUserByID(ctx context.Context, fileURL string, userID string) error {
-	tr := ur.Tracer.Tracer("User-UpdateAvatarUserByID Repository")
-	_, span := tr.Start(ctx, "Start UpdateAvatarUserByID")
+func (ur *UserRepositoryImpl) FailBulkJobByID(ctx context.Context, jobID primitive.ObjectID, errMsg string) error {
+	tr := ur.Tracer.Tracer("User-FailBulkJobByID Repository")
+	_, span := tr.Start(ctx, "Start FailBulkJobByID")
 	defer span.End()
 
-	objUserID, err := primitive.ObjectIDFromHex(userID)
+	now := time.Now()
+
+	_, err := ur.DB.Collection(ur.Config.Database.BulkJobsCollection).UpdateOne(ctx,
+		bson.D{{Key: "_id", Value: jobID}}, bson.M{"$set": bson.D{{Key: "status", Value: model.BulkJobFailed},
+			{Key: "error", Value: errMsg},
+			{Key: "updated_at", Value: now},
+			{Key: "finished_at", Value: now}}})
 	if err != nil {
-		ur.Logger.Error("UserRepositoryImpl.UpdateAvatarUserByID primitive.ObjectIDFromHex ERROR, ", err)
+		ur.Logger.Error("UserRepositoryImpl.FailBulkJobByID UpdateOne ERROR, ", err)
 		return err
 	}
 
-	_, err = ur.DB.Collection(ur.Config.Database.UsersCollection).UpdateOne(ctx,
-		bson.D{{Key: "_id", Value: objUserID}}, bson.M{
-			"$set": bson.D{{Key: "avatar_url", Value: fileURL}},
-		})
+	return nil
+}
+
+func (ur *UserRepositoryImpl) FailStaleBulkJobs(ctx context.Context, staleBefore time.Time, errMsg string) (int64, error) {
+	tr := ur.Tracer.Tracer("User-FailStaleBulkJobs Repository")
+	_, span := tr.Start(ctx, "Start FailStaleBulkJobs")
+	defer span.End()
+
+	now := time.Now()
+
+	// unfinished job without progress since staleBefore, its rows only kept in memory of stopped instance
+	res, err := ur.DB.Collection(ur.Config.Database.BulkJobsCollection).UpdateMany(ctx,
+		bson.D{{Key: "status", Value: bson.D{{Key: "$in", Value: bson.A{model.BulkJobPending, model.BulkJobProcessing}}}},
+			{Key: "updated_at", Value: bson.D{{Key: "$lt", Value: staleBefore}}}},
+		bson.M{"$set": bson.D{{Key: "status", Value: model.BulkJobFailed},
+			{Key: "error", Value: errMsg},
+			{Key: "updated_at", Value: now},
+			{Key: "finished_at", Value: now}}})
 	if err != nil {
-		ur.Logger.Error("UserRepositoryImpl.UpdateAvatarUserByID UpdateOne ERROR, ", err)
+		ur.Logger.Error("UserRepositoryImpl.FailStaleBulkJobs UpdateMany ERROR, ", err)
+		return 0, err
+	}
+
+	return res.ModifiedCount, nil
+}
+
+func (ur *UserRepositoryImpl) CreateShortOperation(ctx context.Context, req *model.ShortOperation) error {
+	tr := ur.Tracer.Tracer("User-CreateShortOperation Repository")
+	_, span := tr.Start(ctx, "Start CreateShortOperation")
+	defer span.End()
+
+	_, err := ur.DB.Collection(ur.Config.Database.OperationsCollection).InsertOne(ctx, req)
+	if err != nil {
+		ur.Logger.Error("UserRepositoryImpl.CreateShortOperation InsertOne ERROR, ", err)
+		return err
+	}
+
+	return nil
+}
+
+func (ur *UserRepositoryImpl) FindShortOperationByID(ctx context.Context, userID string, operationID string) (*model.ShortOperation, error) {
+	tr := ur.Tracer.Tracer("User-FindShortOperationByID Repository")
+	_, span := tr.Start(ctx, "Start FindShortOperationByID")
+	defer span.End()
+
+	objOperationID, err := primitive.ObjectIDFromHex(operationID)
+	if err != nil {
+		return nil, model.NewError(model.NotFound, "operation not found")
+	}
+
+	operation := model.ShortOperation{}
+
+	err = ur.DB.Collection(ur.Config.Database.OperationsCollection).FindOne(ctx,
+		bson.D{{Key: "_id", Value: objOperationID}, {Key: "user_id", Value: userID}}).Decode(&operation)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, model.NewError(model.NotFound, "operation not found")
+		}
+
+		ur.Logger.Error("UserRepositoryImpl.FindShortOperationByID FindOne ERROR, ", err)
+		return nil, err
+	}
+
+	return &operation, nil
+}
+
+func (ur *UserRepositoryImpl) FindPendingShortOperationByID(ctx context.Context, operationID primitive.ObjectID) (*model.ShortOperation, error) {
+	tr := ur.Tracer.Tracer("User-FindPendingShortOperationByID Repository")
+	_, span := tr.Start(ctx, "Start FindPendingShortOperationByID")
+	defer span.End()
+
+	operation := model.ShortOperation{}
+
+	err := ur.DB.Collection(ur.Config.Database.OperationsCollection).FindOne(ctx,
+		bson.D{{Key: "_id", Value: operationID}, {Key: "status", Value: model.OperationPending}}).Decode(&operation)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, model.NewError(model.NotFound, "pending operation not found")
+		}
+
+		ur.Logger.Error("UserRepositoryImpl.FindPendingShortOperationByID FindOne ERROR, ", err)
+		return nil, err
+	}
+
+	return &operation, nil
+}
+
+func (ur *UserRepositoryImpl) UpdateShortOperationResultByID(ctx context.Context, operationID primitive.ObjectID, shortURL string, errMsg string) error {
+	tr := ur.Tracer.Tracer("User-UpdateShortOperationResultByID Repository")
+	_, span := tr.Start(ctx, "Start UpdateShortOperationResultByID")
+	defer span.End()
+
+	now := time.Now()
+
+	set := bson.D{{Key: "status", Value: model.OperationCompleted},
+		{Key: "updated_at", Value: now},
+		{Key: "finished_at", Value: now}}
+
+	if errMsg != "" {
+		set[0].Value = model.OperationFailed
+		set = append(set, bson.E{Key: "error", Value: errMsg})
+	}
+
+	if shortURL != "" {
+		set = append(set, bson.E{Key: "short_url", Value: shortURL})
+	}
+
+	// finished operation never overwritten, so redelivered replies ignored
+	_, err := ur.DB.Collection(ur.Config.Database.OperationsCollection).UpdateOne(ctx,
+		bson.D{{Key: "_id", Value: operationID}, {Key: "status", Value: model.OperationPending}}, bson.M{"$set": set})
+	if err != nil {
+		ur.Logger.Error("UserRepositoryImpl.UpdateShortOperationResultByID UpdateOne ERROR, ", err)
 		return err
 	}
 
 	return nil
 }
 
-func (ur *UserRepositoryImpl) PublishUpdateUserShortener(ctx context.Context, shortID string, req *model.ShortUserRequest) error {
-	tr := ur.Tracer.Tracer("User-PublishUpdateUserShortener Repository")
-	_, span := tr.Start(ctx, "Start PublishUpdateUserShortener")
+func (ur *UserRepositoryImpl) WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	tr := ur.Tracer.Tracer("User-WithTransaction Repository")
+	_, span := tr.Start(ctx, "Start WithTransaction")
 	defer span.End()
 
-	ur.Logger.Info("data req before publish", req)
+	session, err := ur.DB.Client().StartSession()
+	if err != nil {
+		ur.Logger.Error("UserRepositoryImpl.WithTransaction StartSession ERROR, ", err)
+		return err
+	}
+	defer session.EndSession(ctx)
+
+	// every write inside fn using session context committed together or aborted together
+	_, err = session.WithTransaction(ctx, func(sessCtx mongo.SessionContext) (interface{}, error) {
+		return nil, fn(sessCtx)
+	})
 
-	// transform data to proto
-	msg := ur.prepareProtoPublishUpdateUserShortenerMessage(shortID, req)
+	return err
+}
+
+func (ur *UserRepositoryImpl) CreateOutboxMessage(ctx context.Context, queue string, operationID *primitive.ObjectID, msg proto.Message) error {
+	tr := ur.Tracer.Tracer("User-CreateOutboxMessage Repository")
+	ctx, span := tr.Start(ctx, "Start CreateOutboxMessage")
+	defer span.End()
 
 	b, err := proto.Marshal(msg)
 	if err != nil {
-		ur.Logger.Error("UserRepositoryImpl.PublishUpdateUserShortener Marshal proto UpdateShortenerMessage ERROR, ", err)
+		ur.Logger.Error("UserRepositoryImpl.CreateOutboxMessage Marshal proto ERROR, ", err)
 		return err
 	}
 
+	// trace context of the writer kept, so consumer continue its trace whenever message relayed
+	headers := propagation.MapCarrier{}
+	otel.GetTextMapPropagator().Inject(ctx, headers)
+
+	now := time.Now()
+
+	_, err = ur.DB.Collection(ur.Config.Database.OutboxCollection).InsertOne(ctx, &model.OutboxMessage{
+		ID:          primitive.NewObjectID(),
+		Queue:       queue,
+		Body:        b,
+		Headers:     headers,
+		OperationID: operationID,
+		LockedUntil: now,
+		CreatedAt:   now,
+	})
+	if err != nil {
+		ur.Logger.Error("UserRepositoryImpl.CreateOutboxMessage InsertOne ERROR, ", err)
+		return err
+	}
+
+	return nil
+}
+
+func (ur *UserRepositoryImpl) ClaimOutboxMessage(ctx context.Context, lockDuration time.Duration) (*model.OutboxMessage, error) {
+	tr := ur.Tracer.Tracer("User-ClaimOutboxMessage Repository")
+	_, span := tr.Start(ctx, "Start ClaimOutboxMessage")
+	defer span.End()
+
+	now := time.Now()
+	msg := model.OutboxMessage{}
+
+	// oldest message not failed & not locked by other relay, locked until relayed or lock expired
+	err := ur.DB.Collection(ur.Config.Database.OutboxCollection).FindOneAndUpdate(ctx,
+		bson.D{{Key: "locked_until", Value: bson.D{{Key: "$lte", Value: now}}},
+			{Key: "failed_at", Value: bson.D{{Key: "$exists", Value: false}}}},
+		bson.M{"$set": bson.D{{Key: "locked_until", Value: now.Add(lockDuration)}}},
+		options.FindOneAndUpdate().SetSort(bson.D{{Key: "created_at", Value: 1}}).SetReturnDocument(options.After)).Decode(&msg)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+
+		ur.Logger.Error("UserRepositoryImpl.ClaimOutboxMessage FindOneAndUpdate ERROR, ", err)
+		return nil, err
+	}
+
+	return &msg, nil
+}
+
+func (ur *UserRepositoryImpl) PublishOutboxMessage(ctx context.Context, req *model.OutboxMessage) error {
+	tr := ur.Tracer.Tracer("User-PublishOutboxMessage Repository")
+	_, span := tr.Start(ctx, "Start PublishOutboxMessage")
+	defer span.End()
+
 	message := amqp.Publishing{
-		ContentType: "text/plain",
-		Body:        []byte(b),
+		Headers:      amqp.Table{},
+		ContentType:  "text/plain",
+		DeliveryMode: amqp.Persistent,
+		Body:         req.Body,
 	}
 
-	// Attempt to publish a message to the queue.
+	for k, v := range req.Headers {
+		message.Headers[k] = v
+	}
+
+	// outcome of short operation replied by shortener into shared queue using operation id,
+	// so any instance can record it even when this one stopped
+	if req.OperationID != nil {
+		message.CorrelationId = req.OperationID.Hex()
+		message.ReplyTo = ur.Config.RabbitMQ.QueueOperationReply
+	}
+
+	// Attempt to publish a message to the queue, returned once broker confirmed it.
 	if err := ur.RabbitMQ.Publish(
-		"",                                      // exchange
-		ur.Config.RabbitMQ.QueueUpdateShortener, // queue name
-		false,                                   // mandatory
-		false,                                   // immediate
-		message,                                 // message to publish
+		"",        // exchange
+		req.Queue, // queue name
+		false,     // mandatory
+		false,     // immediate
+		message,   // message to publish
 	); err != nil {
-		ur.Logger.Error("UserRepositoryImpl.PublishUpdateUserShortener RabbitMQ.Publish ERROR, ", err)
+		ur.Logger.Error("UserRepositoryImpl.PublishOutboxMessage RabbitMQ.Publish ERROR, ", err)
 		return err
 	}
 
-	ur.Logger.Info("Success Publish User Shortener to Queue: ", ur.Config.RabbitMQ.QueueUpdateShortener)
+	ur.Logger.Info("Success Publish Outbox Message to Queue: ", req.Queue)
+
+	return nil
+}
+
+func (ur *UserRepositoryImpl) DeleteOutboxMessageByID(ctx context.Context, outboxID primitive.ObjectID) error {
+	tr := ur.Tracer.Tracer("User-DeleteOutboxMessageByID Repository")
+	_, span := tr.Start(ctx, "Start DeleteOutboxMessageByID")
+	defer span.End()
+
+	_, err := ur.DB.Collection(ur.Config.Database.OutboxCollection).DeleteOne(ctx, bson.D{{Key: "_id", Value: outboxID}})
+	if err != nil {
+		ur.Logger.Error("UserRepositoryImpl.DeleteOutboxMessageByID DeleteOne ERROR, ", err)
+		return err
+	}
 
 	return nil
 }
 
-func (ur *UserRepositoryImpl) PublishDeleteUserShortener(ctx context.Context, shortID string) error {
-	tr := ur.Tracer.Tracer("User-PublishDeleteUserShortener Repository")
-	_, span := tr.Start(ctx, "Start PublishDeleteUserShortener")
+func (ur *UserRepositoryImpl) ReleaseOutboxMessageByID(ctx context.Context, outboxID primitive.ObjectID, errMsg string, retryAt time.Time) error {
+	tr := ur.Tracer.Tracer("User-ReleaseOutboxMessageByID Repository")
+	_, span := tr.Start(ctx, "Start ReleaseOutboxMessageByID")
 	defer span.End()
 
-	ur.Logger.Info("data req before publish", shortID)
+	_, err := ur.DB.Collection(ur.Config.Database.OutboxCollection).UpdateOne(ctx,
+		bson.D{{Key: "_id", Value: outboxID}}, bson.M{
+			"$inc": bson.D{{Key: "attempts", Value: 1}},
+			"$set": bson.D{{Key: "last_error", Value: errMsg}, {Key: "locked_until", Value: retryAt}},
+		})
+	if err != nil {
+		ur.Logger.Error("UserRepositoryImpl.ReleaseOutboxMessageByID UpdateOne ERROR, ", err)
+		return err
+	}
 
-	// transform data to proto
-	msg := ur.prepareProtoPublishDeleteUserShortenerMessage(shortID)
+	return nil
+}
 
-	b, err := proto.Marshal(msg)
+func (ur *UserRepositoryImpl) FailOutboxMessageByID(ctx context.Context, outboxID primitive.ObjectID, errMsg string) error {
+	tr := ur.Tracer.Tracer("User-FailOutboxMessageByID Repository")
+	_, span := tr.Start(ctx, "Start FailOutboxMessageByID")
+	defer span.End()
+
+	// failed message kept for inspection, never claimed by relay again
+	_, err := ur.DB.Collection(ur.Config.Database.OutboxCollection).UpdateOne(ctx,
+		bson.D{{Key: "_id", Value: outboxID}}, bson.M{
+			"$inc": bson.D{{Key: "attempts", Value: 1}},
+			"$set": bson.D{{Key: "last_error", Value: errMsg}, {Key: "failed_at", Value: time.Now()}},
+		})
 	if err != nil {
-		ur.Logger.Error("UserRepositoryImpl.PublishDeleteUserShortener Marshal proto DeleteShortenerMessage ERROR, ", err)
+		ur.Logger.Error("UserRepositoryImpl.FailOutboxMessageByID UpdateOne ERROR, ", err)
 		return err
 	}
 
-	message := amqp.Publishing{
-		ContentType: "text/plain",
-		Body:        []byte(b),
+	return nil
+}
+
+func (ur *UserRepositoryImpl) CreateAPIKey(ctx context.Context, req *model.APIKey) error {
+	tr := ur.Tracer.Tracer("User-CreateAPIKey Repository")
+	_, span := tr.Start(ctx, "Start CreateAPIKey")
+	defer span.End()
+
+	_, err := ur.DB.Collection(ur.Config.Database.APIKeysCollection).InsertOne(ctx, req)
+	if err != nil {
+		ur.Logger.Error("UserRepositoryImpl.CreateAPIKey InsertOne ERROR, ", err)
+		return err
 	}
 
-	// Attempt to publish a message to the queue.
-	if err := ur.RabbitMQ.Publish(
-		"",                                      // exchange
-		ur.Config.RabbitMQ.QueueDeleteShortener, // queue name
-		false,                                   // mandatory
-		false,                                   // immediate
-		message,                                 // message to publish
-	); err != nil {
-		ur.Logger.Error("UserRepositoryImpl.PublishDeleteUserShortener RabbitMQ.Publish ERROR, ", err)
+	return nil
+}
+
+func (ur *UserRepositoryImpl) CountAPIKeysByUserID(ctx context.Context, userID string) (int64, error) {
+	tr := ur.Tracer.Tracer("User-CountAPIKeysByUserID Repository")
+	_, span := tr.Start(ctx, "Start CountAPIKeysByUserID")
+	defer span.End()
+
+	count, err := ur.DB.Collection(ur.Config.Database.APIKeysCollection).CountDocuments(ctx, bson.D{{Key: "user_id", Value: userID}})
+	if err != nil {
+		ur.Logger.Error("UserRepositoryImpl.CountAPIKeysByUserID CountDocuments ERROR, ", err)
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (ur *UserRepositoryImpl) FindAPIKeysByUserID(ctx context.Context, userID string) ([]model.APIKey, error) {
+	tr := ur.Tracer.Tracer("User-FindAPIKeysByUserID Repository")
+	_, span := tr.Start(ctx, "Start FindAPIKeysByUserID")
+	defer span.End()
+
+	cursor, err := ur.DB.Collection(ur.Config.Database.APIKeysCollection).Find(ctx,
+		bson.D{{Key: "user_id", Value: userID}}, options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}}))
+	if err != nil {
+		ur.Logger.Error("UserRepositoryImpl.FindAPIKeysByUserID Find ERROR, ", err)
+		return nil, err
+	}
+
+	keys := []model.APIKey{}
+
+	err = cursor.All(ctx, &keys)
+	if err != nil {
+		ur.Logger.Error("UserRepositoryImpl.FindAPIKeysByUserID cursor.All ERROR, ", err)
+		return nil, err
+	}
+
+	return keys, nil
+}
+
+func (ur *UserRepositoryImpl) FindAPIKeyByHash(ctx context.Context, keyHash string) (*model.APIKey, error) {
+	tr := ur.Tracer.Tracer("User-FindAPIKeyByHash Repository")
+	_, span := tr.Start(ctx, "Start FindAPIKeyByHash")
+	defer span.End()
+
+	key := model.APIKey{}
+
+	err := ur.DB.Collection(ur.Config.Database.APIKeysCollection).FindOne(ctx, bson.D{{Key: "key_hash", Value: keyHash}}).Decode(&key)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, model.NewError(model.NotFound, "api key not found")
+		}
+
+		ur.Logger.Error("UserRepositoryImpl.FindAPIKeyByHash FindOne ERROR, ", err)
+		return nil, err
+	}
+
+	return &key, nil
+}
+
+func (ur *UserRepositoryImpl) DeleteAPIKeyByID(ctx context.Context, userID string, keyID string) error {
+	tr := ur.Tracer.Tracer("User-DeleteAPIKeyByID Repository")
+	_, span := tr.Start(ctx, "Start DeleteAPIKeyByID")
+	defer span.End()
+
+	objKeyID, err := primitive.ObjectIDFromHex(keyID)
+	if err != nil {
+		return model.NewError(model.NotFound, "api key not found")
+	}
+
+	result, err := ur.DB.Collection(ur.Config.Database.APIKeysCollection).DeleteOne(ctx,
+		bson.D{{Key: "_id", Value: objKeyID}, {Key: "user_id", Value: userID}})
+	if err != nil {
+		ur.Logger.Error("UserRepositoryImpl.DeleteAPIKeyByID DeleteOne ERROR, ", err)
 		return err
 	}
 
-	ur.Logger.Info("Success Publish User Shortener to Queue: ", ur.Config.RabbitMQ.QueueDeleteShortener)
+	if result.DeletedCount == 0 {
+		return model.NewError(model.NotFound, "api key not found")
+	}
 
 	return nil
 }
 
-func (ur *UserRepositoryImpl) prepareProtoPublishCreateUserShortenerMessage(req *model.GenerateShortUserMessage) *shortenerpb.CreateShortenerMessage {
-	return &shortenerpb.CreateShortenerMessage{
-		FullUrl:  req.FullURL,
-		UserId:   req.UserID,
-		ShortUrl: req.ShortURL,
+func (ur *UserRepositoryImpl) UpdateAPIKeyLastUsedByID(ctx context.Context, keyID primitive.ObjectID, usedAt time.Time, interval time.Duration) error {
+	tr := ur.Tracer.Tracer("User-UpdateAPIKeyLastUsedByID Repository")
+	_, span := tr.Start(ctx, "Start UpdateAPIKeyLastUsedByID")
+	defer span.End()
+
+	// written at most once per interval, so busy keys don't cost a write every request
+	_, err := ur.DB.Collection(ur.Config.Database.APIKeysCollection).UpdateOne(ctx,
+		bson.D{{Key: "_id", Value: keyID}, {Key: "$or", Value: bson.A{
+			bson.D{{Key: "last_used_at", Value: bson.D{{Key: "$exists", Value: false}}}},
+			bson.D{{Key: "last_used_at", Value: bson.D{{Key: "$lt", Value: usedAt.Add(-interval)}}}},
+		}}}, bson.M{"$set": bson.D{{Key: "last_used_at", Value: usedAt}}})
+	if err != nil {
+		ur.Logger.Error("UserRepositoryImpl.UpdateAPIKeyLastUsedByID UpdateOne ERROR, ", err)
+		return err
 	}
+
+	return nil
 }
 
-func (ur *UserRepositoryImpl) prepareProtoPublishUploadAvatarUserMessage(req *model.UploadAvatarRequest) *uploadpb.UploadAvatarMessage {
-	return &uploadpb.UploadAvatarMessage{
-		FileName:    req.FileName,
-		ContentType: req.ContentType,
-		Avatars:     req.Avatars,
+// waitCreateShortenerReply will waiting shortener to reply final short url of published message
+func (ur *UserRepositoryImpl) waitCreateShortenerReply(op string, correlationID string, replies <-chan amqp.Delivery) (string, error) {
+	select {
+	case r := <-replies:
+		reply := &shortenerpb.CreateShortenerReplyMessage{}
+
+		err := proto.Unmarshal(r.Body, reply)
+		if err != nil {
+			ur.Logger.Error("UserRepositoryImpl."+op+" Unmarshal proto CreateShortenerReplyMessage ERROR, ", err)
+			return "", err
+		}
+
+		if reply.GetError() != "" {
+			return "", replyError(reply.GetError(), reply.GetErrorDetail())
+		}
+
+		return reply.GetShortUrl(), nil
+	case <-time.After(time.Duration(ur.Config.RabbitMQ.ReplyTimeout) * time.Second):
+		ur.Logger.Error("UserRepositoryImpl."+op+" waiting reply TIMEOUT, ", correlationID)
+		return "", model.NewError(model.Unknown, "timeout waiting shortener reply")
 	}
 }
 
-func (ur *UserRepositoryImpl) prepareProtoPublishUpdateUserShortenerMessage(shortID string, req *model.ShortUserRequest) *shortenerpb.UpdateShortenerMessage {
-	return &shortenerpb.UpdateShortenerMessage{
-		Id:      shortID,
-		FullUrl: req.FullURL,
+// DecodeShortOperationReply will decoding reply of short operation, create operation replied with final short url
+func (ur *UserRepositoryImpl) DecodeShortOperationReply(ctx context.Context, opType string, body []byte) (*model.ShortOperationReply, error) {
+	tr := ur.Tracer.Tracer("User-DecodeShortOperationReply Repository")
+	_, span := tr.Start(ctx, "Start DecodeShortOperationReply")
+	defer span.End()
+
+	if opType == model.OperationCreate {
+		reply := &shortenerpb.CreateShortenerReplyMessage{}
+
+		err := proto.Unmarshal(body, reply)
+		if err != nil {
+			ur.Logger.Error("UserRepositoryImpl.DecodeShortOperationReply Unmarshal proto CreateShortenerReplyMessage ERROR, ", err)
+			return nil, err
+		}
+
+		if reply.GetError() != "" {
+			return &model.ShortOperationReply{Error: replyError(reply.GetError(), reply.GetErrorDetail()).Error()}, nil
+		}
+
+		return &model.ShortOperationReply{ShortURL: reply.GetShortUrl()}, nil
+	}
+
+	reply := &shortenerpb.ShortenerReplyMessage{}
+
+	err := proto.Unmarshal(body, reply)
+	if err != nil {
+		ur.Logger.Error("UserRepositoryImpl.DecodeShortOperationReply Unmarshal proto ShortenerReplyMessage ERROR, ", err)
+		return nil, err
 	}
+
+	if reply.GetError() != "" {
+		return &model.ShortOperationReply{Error: replyError(reply.GetError(), reply.GetErrorDetail()).Error()}, nil
+	}
+
+	return &model.ShortOperationReply{}, nil
 }
 
-func (ur *UserRepositoryImpl) prepareProtoPublishDeleteUserShortenerMessage(shortID string) *shortenerpb.DeleteShortenerMessage {
-	return &shortenerpb.DeleteShortenerMessage{
-		Id: shortID,
+// replyError will decoding error replied by shortener back into typed error,
+// replies of older shortener only carrying error message so treated as internal error
+func replyError(msg string, detail *shortenerpb.ErrorDetail) error {
+	if detail == nil {
+		return model.NewError(model.Internal, msg)
+	}
+
+	e := &model.Error{
+		Kind:    model.ErrorKind(detail.GetKind()),
+		Code:    detail.GetCode(),
+		Message: detail.GetMessage(),
+	}
+
+	for k, v := range detail.GetDetails() {
+		e.WithDetail(k, v)
 	}
+
+	return e
 }
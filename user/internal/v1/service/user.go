@@ -1,32 +1,58 @@
 package service
 
 import (
+	"bufio"
 	"bytes"
 	"context"
+	"encoding/csv"
+	"encoding/json"
 	"fmt"
 	"io"
 	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/PickHD/singkatin-revamp/user/internal/v1/config"
 	"github.com/PickHD/singkatin-revamp/user/internal/v1/helper"
 	"github.com/PickHD/singkatin-revamp/user/internal/v1/model"
 	"github.com/PickHD/singkatin-revamp/user/internal/v1/repository"
 	shortenerpb "github.com/PickHD/singkatin-revamp/user/pkg/api/v1/proto/shortener"
+	uploadpb "github.com/PickHD/singkatin-revamp/user/pkg/api/v1/proto/upload"
 	"github.com/gofiber/fiber/v2"
 	"github.com/sirupsen/logrus"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.opentelemetry.io/otel/sdk/trace"
+	"google.golang.org/protobuf/proto"
 )
 
 type (
 	// UserService is an interface that has all the function to be implemented inside user service
 	UserService interface {
 		GetUserDetail(email string) (*model.User, error)
-		GetUserShorts(userID string) ([]model.UserShorts, error)
+		GetUserShorts(userID string, req *model.DashboardRequest) ([]model.UserShorts, *helper.Meta, error)
 		GenerateUserShorts(userID string, req *model.ShortUserRequest) (*model.ShortUserResponse, error)
 		UpdateUserProfile(userID string, req *model.EditProfileRequest) error
 		UploadUserAvatar(ctx *fiber.Ctx, userID string) (*model.UploadAvatarResponse, error)
-		UpdateUserShorts(shortID string, req *model.ShortUserRequest) (*model.ShortUserResponse, error)
-		DeleteUserShorts(shortID string) (*model.ShortUserResponse, error)
+		UpdateUserShorts(userID string, shortID string, req *model.UpdateShortUserRequest) (*model.ShortUserResponse, error)
+		DeleteUserShorts(userID string, shortID string) (*model.ShortUserResponse, error)
+		GetUserShortStats(userID string, shortID string, req *model.ShortStatsRequest) (*model.ShortStats, error)
+		BulkGenerateUserShorts(ctx *fiber.Ctx, userID string) (*model.BulkJob, error)
+		GetUserBulkJob(userID string, jobID string) (*model.BulkJob, error)
+		FailStaleBulkJobs() (int64, error)
+		ExportUserShorts(userID string, format string) (func(w *bufio.Writer) error, error)
+		GenerateUserShortsAsync(userID string, req *model.ShortUserRequest) (*model.ShortOperation, error)
+		UpdateUserShortsAsync(userID string, shortID string, req *model.UpdateShortUserRequest) (*model.ShortOperation, error)
+		DeleteUserShortsAsync(userID string, shortID string) (*model.ShortOperation, error)
+		GetUserShortOperation(userID string, operationID string) (*model.ShortOperation, error)
+		RelayOutboxMessages() error
+		FinishShortOperation(operationID string, body []byte) error
+		CreateUserAPIKey(userID string, req *model.CreateAPIKeyRequest) (*model.CreateAPIKeyResponse, error)
+		GetUserAPIKeys(userID string) ([]model.APIKey, error)
+		RevokeUserAPIKey(userID string, keyID string) error
+		AuthenticateAPIKey(key string, scope string) (*model.APIKey, error)
 	}
 
 	// UserServiceImpl is an app user struct that consists of all the dependencies needed for user service
@@ -40,6 +66,55 @@ type (
 	}
 )
 
+const (
+	// maxTags & maxTagLength is limit of tags every short users
+	maxTags      = 10
+	maxTagLength = 32
+
+	// minPasswordLength & maxPasswordLength is allowed length of short users password, bcrypt only use first 72 bytes
+	minPasswordLength = 4
+	maxPasswordLength = 72
+
+	// defaultBulkBatchSize is rows published concurrently per batch when not configured
+	defaultBulkBatchSize = 50
+
+	// defaultBulkStaleTimeout (minutes) is how long unfinished bulk job without progress considered stale when not configured
+	defaultBulkStaleTimeout = 10
+
+	// bulkJobInterrupted is error of bulk job stopped before all rows processed
+	bulkJobInterrupted = "bulk job interrupted, please retry"
+
+	// exportFlushRows is rows written before flushing exported short users into client
+	exportFlushRows = 100
+
+	// defaultOutboxLockDuration (seconds) is how long claimed outbox message hidden from other relays when not configured
+	defaultOutboxLockDuration = 30
+
+	// defaultOutboxMaxAttempts & defaultOutboxRetryBackoff (seconds) used when not configured, backoff doubled on
+	// every failed publish up to maxOutboxRetryBackoff
+	defaultOutboxMaxAttempts  = 10
+	defaultOutboxRetryBackoff = 2
+	maxOutboxRetryBackoff     = 10 * time.Minute
+
+	// apiKeySecretBytes is random bytes length of generated API keys, apiKeyDisplayLength is leading characters
+	// of the key kept to tell keys apart
+	apiKeySecretBytes   = 32
+	apiKeyDisplayLength = 12
+
+	// maxAPIKeyNameLength is limit of API key name
+	maxAPIKeyNameLength = 64
+
+	// defaultAPIKeyMaxPerUser & defaultAPIKeyLastUsedInterval (seconds) used when not configured
+	defaultAPIKeyMaxPerUser       = 20
+	defaultAPIKeyLastUsedInterval = 60
+)
+
+// bulkRow consist single row of bulk short users, err filled when row cannot be parsed
+type bulkRow struct {
+	req model.ShortUserRequest
+	err error
+}
+
 // NewUserService return new instances user service
 func NewUserService(ctx context.Context, config *config.Configuration, logger *logrus.Logger, tracer *trace.TracerProvider, userRepo repository.UserRepository, shortClients shortenerpb.ShortenerServiceClient) *UserServiceImpl {
 	return &UserServiceImpl{
@@ -60,34 +135,143 @@ func (us *UserServiceImpl) GetUserDetail(email string) (*model.User, error) {
 	return us.UserRepo.FindByEmail(us.Context, email)
 }
 
-func (us *UserServiceImpl) GetUserShorts(userID string) ([]model.UserShorts, error) {
+func (us *UserServiceImpl) GetUserShorts(userID string, req *model.DashboardRequest) ([]model.UserShorts, *helper.Meta, error) {
 	tr := us.Tracer.Tracer("User-GetUserShorts Service")
 	_, span := tr.Start(us.Context, "Start GetUserShorts")
 	defer span.End()
 
 	data, err := us.ShortClients.GetListShortenerByUserID(us.Context, &shortenerpb.ListShortenerRequest{
-		UserId: userID})
+		UserId:    userID,
+		Page:      req.Page,
+		PageSize:  req.PageSize,
+		SortBy:    req.SortBy,
+		SortOrder: req.SortOrder,
+		Search:    req.Search,
+		Tag:       req.Tag,
+	})
 	if err != nil {
 		us.Logger.Error("UserServiceImpl.GetUserShorts ShortClients ERROR, ", err)
-		return nil, err
+
+		return nil, nil, helper.FromStatusError(err)
 	}
 
-	if len(data.Shorteners) < 1 {
-		return nil, nil
+	shorteners := make([]model.UserShorts, len(data.GetShorteners()))
+
+	for i, q := range data.GetShorteners() {
+		shorteners[i] = us.prepareUserShorts(q)
+	}
+
+	meta := &helper.Meta{
+		Page:      data.GetPage(),
+		TotalData: data.GetTotal(),
+	}
+
+	if data.GetPageSize() > 0 {
+		meta.TotalPage = (data.GetTotal() + data.GetPageSize() - 1) / data.GetPageSize()
+	}
+
+	return shorteners, meta, nil
+}
+
+// ExportUserShorts will opening export stream of short users, returned writer streaming it in the given format.
+// First short received before returning, so failure of shortener responded before export started
+func (us *UserServiceImpl) ExportUserShorts(userID string, format string) (func(w *bufio.Writer) error, error) {
+	tr := us.Tracer.Tracer("User-ExportUserShorts Service")
+	_, span := tr.Start(us.Context, "Start ExportUserShorts")
+	defer span.End()
+
+	if _, ok := model.ExportFormats[format]; !ok {
+		return nil, model.NewError(model.Validation, "format must be csv, json or ndjson")
 	}
 
-	shorteners := make([]model.UserShorts, len(data.Shorteners))
+	// stop streaming from shortener when client gone
+	ctx, cancel := context.WithCancel(us.Context)
 
-	for i, q := range data.Shorteners {
-		shorteners[i] = model.UserShorts{
-			ID:       q.GetId(),
-			FullURL:  q.GetFullUrl(),
-			ShortURL: q.GetShortUrl(),
-			Visited:  q.GetVisited(),
+	stream, err := us.ShortClients.ExportShortenerByUserID(ctx, &shortenerpb.ListShortenerRequest{
+		UserId: userID})
+	if err != nil {
+		cancel()
+		us.Logger.Error("UserServiceImpl.ExportUserShorts ShortClients ERROR, ", err)
+		return nil, helper.FromStatusError(err)
+	}
+
+	first, err := stream.Recv()
+	if err != nil && err != io.EOF {
+		cancel()
+		us.Logger.Error("UserServiceImpl.ExportUserShorts stream.Recv ERROR, ", err)
+		return nil, helper.FromStatusError(err)
+	}
+
+	return func(w *bufio.Writer) error {
+		defer cancel()
+
+		return us.writeUserShortsExport(stream, first, format, w)
+	}, nil
+}
+
+// writeUserShortsExport will writing shorts received from export stream, starting with already received first one
+func (us *UserServiceImpl) writeUserShortsExport(stream shortenerpb.ShortenerService_ExportShortenerByUserIDClient, first *shortenerpb.Shortener, format string, w *bufio.Writer) error {
+	var err error
+
+	csvWriter := csv.NewWriter(w)
+	jsonEncoder := json.NewEncoder(w)
+
+	switch format {
+	case "csv":
+		err = csvWriter.Write([]string{"id", "full_url", "short_url", "visited", "expires_at", "max_clicks", "is_protected", "tags", "qr_url", "created_at"})
+	case "json":
+		_, err = w.WriteString("[")
+	}
+	if err != nil {
+		return err
+	}
+
+	for rows, q := 0, first; q != nil; rows++ {
+		short := us.prepareUserShorts(q)
+
+		switch format {
+		case "csv":
+			err = csvWriter.Write(exportCSVRecord(&short))
+			csvWriter.Flush()
+		case "json":
+			if rows > 0 {
+				if _, err := w.WriteString(","); err != nil {
+					return err
+				}
+			}
+
+			err = jsonEncoder.Encode(&short)
+		case "ndjson":
+			err = jsonEncoder.Encode(&short)
+		}
+		if err != nil {
+			return err
+		}
+
+		if rows%exportFlushRows == 0 {
+			if err := w.Flush(); err != nil {
+				return err
+			}
+		}
+
+		q, err = stream.Recv()
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil {
+			us.Logger.Error("UserServiceImpl.ExportUserShorts stream.Recv ERROR, ", err)
+			return helper.FromStatusError(err)
 		}
 	}
 
-	return shorteners, nil
+	if format == "json" {
+		if _, err := w.WriteString("]"); err != nil {
+			return err
+		}
+	}
+
+	return w.Flush()
 }
 
 func (us *UserServiceImpl) GenerateUserShorts(userID string, req *model.ShortUserRequest) (*model.ShortUserResponse, error) {
@@ -95,23 +279,37 @@ func (us *UserServiceImpl) GenerateUserShorts(userID string, req *model.ShortUse
 	_, span := tr.Start(us.Context, "Start GenerateUserShorts")
 	defer span.End()
 
-	msg := model.GenerateShortUserMessage{
-		FullURL:  req.FullURL,
-		UserID:   userID,
-		ShortURL: helper.RandomStringBytesMaskImprSrcSB(8),
+	msg, err := us.prepareGenerateShortUserMessage(userID, req)
+	if err != nil {
+		return nil, err
 	}
 
-	err := us.UserRepo.PublishCreateUserShortener(us.Context, &msg)
+	// short url allocated by shortener services
+	data, err := us.ShortClients.CreateShortener(us.Context, prepareProtoCreateShortenerMessage(msg))
 	if err != nil {
-		return nil, err
+		us.Logger.Error("UserServiceImpl.GenerateUserShorts ShortClients ERROR, ", err)
+		return nil, helper.FromStatusError(err)
 	}
 
 	return &model.ShortUserResponse{
-		ShortURL: fmt.Sprintf("%s/%s", us.Config.HttpService.ShortenerBaseAPIURL, msg.ShortURL),
+		ShortURL: fmt.Sprintf("%s/%s", us.Config.HttpService.ShortenerBaseAPIURL, data.GetShortUrl()),
 		Method:   "GET",
 	}, nil
 }
 
+func (us *UserServiceImpl) GenerateUserShortsAsync(userID string, req *model.ShortUserRequest) (*model.ShortOperation, error) {
+	tr := us.Tracer.Tracer("User-GenerateUserShortsAsync Service")
+	spanCtx, span := tr.Start(us.Context, "Start GenerateUserShortsAsync")
+	defer span.End()
+
+	msg, err := us.prepareGenerateShortUserMessage(userID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return us.startShortOperation(spanCtx, userID, model.OperationCreate, "", us.Config.RabbitMQ.QueueCreateShortener, prepareProtoCreateShortenerMessage(msg))
+}
+
 func (us *UserServiceImpl) UpdateUserProfile(userID string, req *model.EditProfileRequest) error {
 	tr := us.Tracer.Tracer("User-UpdateUserProfile Service")
 	_, span := tr.Start(us.Context, "Start UpdateUserProfile")
@@ -135,7 +333,7 @@ func (us *UserServiceImpl) UpdateUserProfile(userID string, req *model.EditProfi
 
 func (us *UserServiceImpl) UploadUserAvatar(ctx *fiber.Ctx, userID string) (*model.UploadAvatarResponse, error) {
 	tr := us.Tracer.Tracer("User-UploadUserAvatar Service")
-	_, span := tr.Start(us.Context, "Start UploadUserAvatar")
+	spanCtx, span := tr.Start(ctx.UserContext(), "Start UploadUserAvatar")
 	defer span.End()
 
 	file, err := ctx.FormFile("file")
@@ -165,23 +363,24 @@ func (us *UserServiceImpl) UploadUserAvatar(ctx *fiber.Ctx, userID string) (*mod
 		return nil, err
 	}
 
-	// publish to queue async
-	err = us.UserRepo.PublishUploadAvatarUser(us.Context, &model.UploadAvatarRequest{
-		FileName:    fileName,
-		ContentType: contentType,
-		Avatars:     buf.Bytes(),
-	})
-	if err != nil {
-		return nil, err
-	}
-
 	fileUrl := url.URL{
 		Scheme: "http",
 		Path:   fmt.Sprintf("%s/%s/%s", us.Config.MinIO.Endpoint, us.Config.MinIO.Bucket, fileName),
 	}
 
-	// update avatar_url users to db
-	err = us.UserRepo.UpdateAvatarUserByID(us.Context, fileUrl.String(), userID)
+	// update avatar_url users to db along with upload message, published to queue async by outbox relay
+	err = us.UserRepo.WithTransaction(spanCtx, func(ctx context.Context) error {
+		err := us.UserRepo.UpdateAvatarUserByID(ctx, fileUrl.String(), userID)
+		if err != nil {
+			return err
+		}
+
+		return us.UserRepo.CreateOutboxMessage(ctx, us.Config.RabbitMQ.QueueUploadAvatar, nil, &uploadpb.UploadAvatarMessage{
+			FileName:    fileName,
+			ContentType: contentType,
+			Avatars:     buf.Bytes(),
+		})
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -191,28 +390,934 @@ func (us *UserServiceImpl) UploadUserAvatar(ctx *fiber.Ctx, userID string) (*mod
 	}, nil
 }
 
-func (us *UserServiceImpl) UpdateUserShorts(shortID string, req *model.ShortUserRequest) (*model.ShortUserResponse, error) {
+func (us *UserServiceImpl) UpdateUserShorts(userID string, shortID string, req *model.UpdateShortUserRequest) (*model.ShortUserResponse, error) {
 	tr := us.Tracer.Tracer("User-UpdateUserShorts Service")
 	_, span := tr.Start(us.Context, "Start UpdateUserShorts")
 	defer span.End()
 
-	err := us.UserRepo.PublishUpdateUserShortener(us.Context, shortID, req)
+	msg, err := prepareProtoUpdateShortenerMessage(userID, shortID, req)
 	if err != nil {
 		return nil, err
 	}
 
+	_, err = us.ShortClients.UpdateShortener(us.Context, msg)
+	if err != nil {
+		us.Logger.Error("UserServiceImpl.UpdateUserShorts ShortClients ERROR, ", err)
+		return nil, helper.FromStatusError(err)
+	}
+
 	return &model.ShortUserResponse{}, nil
 }
 
-func (us *UserServiceImpl) DeleteUserShorts(shortID string) (*model.ShortUserResponse, error) {
+func (us *UserServiceImpl) UpdateUserShortsAsync(userID string, shortID string, req *model.UpdateShortUserRequest) (*model.ShortOperation, error) {
+	tr := us.Tracer.Tracer("User-UpdateUserShortsAsync Service")
+	spanCtx, span := tr.Start(us.Context, "Start UpdateUserShortsAsync")
+	defer span.End()
+
+	msg, err := prepareProtoUpdateShortenerMessage(userID, shortID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return us.startShortOperation(spanCtx, userID, model.OperationUpdate, shortID, us.Config.RabbitMQ.QueueUpdateShortener, msg)
+}
+
+func (us *UserServiceImpl) DeleteUserShorts(userID string, shortID string) (*model.ShortUserResponse, error) {
 	tr := us.Tracer.Tracer("User-DeleteUserShorts Service")
 	_, span := tr.Start(us.Context, "Start DeleteUserShorts")
 	defer span.End()
 
-	err := us.UserRepo.PublishDeleteUserShortener(us.Context, shortID)
+	_, err := us.ShortClients.DeleteShortener(us.Context, &shortenerpb.DeleteShortenerMessage{
+		Id:     shortID,
+		UserId: userID,
+	})
 	if err != nil {
-		return nil, err
+		us.Logger.Error("UserServiceImpl.DeleteUserShorts ShortClients ERROR, ", err)
+		return nil, helper.FromStatusError(err)
 	}
 
 	return &model.ShortUserResponse{}, nil
 }
+
+func (us *UserServiceImpl) DeleteUserShortsAsync(userID string, shortID string) (*model.ShortOperation, error) {
+	tr := us.Tracer.Tracer("User-DeleteUserShortsAsync Service")
+	spanCtx, span := tr.Start(us.Context, "Start DeleteUserShortsAsync")
+	defer span.End()
+
+	return us.startShortOperation(spanCtx, userID, model.OperationDelete, shortID, us.Config.RabbitMQ.QueueDeleteShortener, &shortenerpb.DeleteShortenerMessage{
+		Id:     shortID,
+		UserId: userID,
+	})
+}
+
+func (us *UserServiceImpl) GetUserShortOperation(userID string, operationID string) (*model.ShortOperation, error) {
+	tr := us.Tracer.Tracer("User-GetUserShortOperation Service")
+	_, span := tr.Start(us.Context, "Start GetUserShortOperation")
+	defer span.End()
+
+	return us.UserRepo.FindShortOperationByID(us.Context, userID, operationID)
+}
+
+func (us *UserServiceImpl) CreateUserAPIKey(userID string, req *model.CreateAPIKeyRequest) (*model.CreateAPIKeyResponse, error) {
+	tr := us.Tracer.Tracer("User-CreateUserAPIKey Service")
+	_, span := tr.Start(us.Context, "Start CreateUserAPIKey")
+	defer span.End()
+
+	scopes, err := validateCreateAPIKey(req)
+	if err != nil {
+		return nil, err
+	}
+
+	maxPerUser := us.Config.APIKey.MaxPerUser
+	if maxPerUser <= 0 {
+		maxPerUser = defaultAPIKeyMaxPerUser
+	}
+
+	count, err := us.UserRepo.CountAPIKeysByUserID(us.Context, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if count >= int64(maxPerUser) {
+		return nil, model.NewError(model.Conflict, fmt.Sprintf("api keys exceeded limit %d, please revoke unused keys", maxPerUser))
+	}
+
+	secret, err := helper.GenerateToken(apiKeySecretBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	key := model.APIKeyPrefix + secret
+
+	apiKey := model.APIKey{
+		ID:        primitive.NewObjectID(),
+		UserID:    userID,
+		Name:      strings.TrimSpace(req.Name),
+		Prefix:    key[:apiKeyDisplayLength],
+		KeyHash:   helper.HashToken(key),
+		Scopes:    scopes,
+		ExpiresAt: req.ExpiresAt,
+		CreatedAt: time.Now(),
+	}
+
+	err = us.UserRepo.CreateAPIKey(us.Context, &apiKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.CreateAPIKeyResponse{
+		APIKey: apiKey,
+		Key:    key,
+	}, nil
+}
+
+func (us *UserServiceImpl) GetUserAPIKeys(userID string) ([]model.APIKey, error) {
+	tr := us.Tracer.Tracer("User-GetUserAPIKeys Service")
+	_, span := tr.Start(us.Context, "Start GetUserAPIKeys")
+	defer span.End()
+
+	return us.UserRepo.FindAPIKeysByUserID(us.Context, userID)
+}
+
+func (us *UserServiceImpl) RevokeUserAPIKey(userID string, keyID string) error {
+	tr := us.Tracer.Tracer("User-RevokeUserAPIKey Service")
+	_, span := tr.Start(us.Context, "Start RevokeUserAPIKey")
+	defer span.End()
+
+	return us.UserRepo.DeleteAPIKeyByID(us.Context, userID, keyID)
+}
+
+func (us *UserServiceImpl) AuthenticateAPIKey(key string, scope string) (*model.APIKey, error) {
+	tr := us.Tracer.Tracer("User-AuthenticateAPIKey Service")
+	_, span := tr.Start(us.Context, "Start AuthenticateAPIKey")
+	defer span.End()
+
+	if !strings.HasPrefix(key, model.APIKeyPrefix) {
+		return nil, model.NewError(model.Validation, "invalid api key")
+	}
+
+	apiKey, err := us.UserRepo.FindAPIKeyByHash(us.Context, helper.HashToken(key))
+	if err != nil {
+		if model.IsKind(err, model.NotFound) {
+			return nil, model.NewError(model.Validation, "invalid api key")
+		}
+
+		return nil, err
+	}
+
+	now := time.Now()
+
+	if apiKey.ExpiresAt != nil && now.After(*apiKey.ExpiresAt) {
+		return nil, model.NewError(model.Validation, "api key expired")
+	}
+
+	if !hasScope(apiKey.Scopes, scope) {
+		return nil, model.NewError(model.Forbidden, "api key missing scope "+scope)
+	}
+
+	interval := us.Config.APIKey.LastUsedInterval
+	if interval <= 0 {
+		interval = defaultAPIKeyLastUsedInterval
+	}
+
+	// failing to track usage shouldn't reject request of valid key
+	err = us.UserRepo.UpdateAPIKeyLastUsedByID(us.Context, apiKey.ID, now, time.Duration(interval)*time.Second)
+	if err != nil {
+		us.Logger.Error("UserServiceImpl.AuthenticateAPIKey UpdateAPIKeyLastUsedByID ERROR, ", err)
+	}
+
+	return apiKey, nil
+}
+
+// startShortOperation will recording pending operation along with its queue message, published by outbox relay in background.
+// outcome replied by shortener can be polled by operation id
+func (us *UserServiceImpl) startShortOperation(ctx context.Context, userID string, opType string, shortID string, queue string, msg proto.Message) (*model.ShortOperation, error) {
+	now := time.Now()
+	operation := &model.ShortOperation{
+		ID:        primitive.NewObjectID(),
+		UserID:    userID,
+		Type:      opType,
+		Status:    model.OperationPending,
+		ShortID:   shortID,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	err := us.UserRepo.WithTransaction(ctx, func(ctx context.Context) error {
+		err := us.UserRepo.CreateShortOperation(ctx, operation)
+		if err != nil {
+			return err
+		}
+
+		return us.UserRepo.CreateOutboxMessage(ctx, queue, &operation.ID, msg)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return operation, nil
+}
+
+// RelayOutboxMessages will publishing pending outbox messages until none left, failed message retried later with backoff
+// & marked failed along with its operation once attempts exhausted
+func (us *UserServiceImpl) RelayOutboxMessages() error {
+	tr := us.Tracer.Tracer("User-RelayOutboxMessages Service")
+	_, span := tr.Start(us.Context, "Start RelayOutboxMessages")
+	defer span.End()
+
+	lockDuration := us.Config.Outbox.LockDuration
+	if lockDuration <= 0 {
+		lockDuration = defaultOutboxLockDuration
+	}
+
+	for {
+		msg, err := us.UserRepo.ClaimOutboxMessage(us.Context, time.Duration(lockDuration)*time.Second)
+		if err != nil {
+			return err
+		}
+
+		if msg == nil {
+			return nil
+		}
+
+		err = us.UserRepo.PublishOutboxMessage(us.Context, msg)
+		if err != nil {
+			// claimed message stays locked until retried or failed, so relaying continues with the next one
+			us.retryOutboxMessage(msg, err)
+			continue
+		}
+
+		// already confirmed by broker, when deleting failed message published again after lock expired
+		if err := us.UserRepo.DeleteOutboxMessageByID(us.Context, msg.ID); err != nil {
+			return err
+		}
+	}
+}
+
+// retryOutboxMessage will scheduling failed outbox message for retry, or marking it & its operation failed
+// once attempts exhausted
+func (us *UserServiceImpl) retryOutboxMessage(msg *model.OutboxMessage, cause error) {
+	maxAttempts := us.Config.Outbox.MaxAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = defaultOutboxMaxAttempts
+	}
+
+	attempts := msg.Attempts + 1
+	if attempts < maxAttempts {
+		if err := us.UserRepo.ReleaseOutboxMessageByID(us.Context, msg.ID, cause.Error(),
+			time.Now().Add(us.outboxRetryBackoff(attempts))); err != nil {
+			us.Logger.Error("UserServiceImpl.RelayOutboxMessages ReleaseOutboxMessageByID ERROR, ", err)
+		}
+
+		return
+	}
+
+	us.Logger.Error("UserServiceImpl.RelayOutboxMessages attempts exhausted, ", cause)
+
+	if err := us.UserRepo.FailOutboxMessageByID(us.Context, msg.ID, cause.Error()); err != nil {
+		us.Logger.Error("UserServiceImpl.RelayOutboxMessages FailOutboxMessageByID ERROR, ", err)
+		return
+	}
+
+	if msg.OperationID != nil {
+		if err := us.UserRepo.UpdateShortOperationResultByID(us.Context, *msg.OperationID, "",
+			fmt.Sprintf("failed publishing after %d attempts: %s", attempts, cause.Error())); err != nil {
+			us.Logger.Error("UserServiceImpl.RelayOutboxMessages UpdateShortOperationResultByID ERROR, ", err)
+		}
+	}
+}
+
+// outboxRetryBackoff will returning delay before retrying outbox message failed the given times
+func (us *UserServiceImpl) outboxRetryBackoff(attempts int) time.Duration {
+	backoff := time.Duration(us.Config.Outbox.RetryBackoff) * time.Second
+	if backoff <= 0 {
+		backoff = defaultOutboxRetryBackoff * time.Second
+	}
+
+	for i := 1; i < attempts && backoff < maxOutboxRetryBackoff; i++ {
+		backoff *= 2
+	}
+
+	if backoff > maxOutboxRetryBackoff {
+		backoff = maxOutboxRetryBackoff
+	}
+
+	return backoff
+}
+
+// FinishShortOperation will recording outcome of short operation replied by shortener, replies of operation
+// already finished ignored
+func (us *UserServiceImpl) FinishShortOperation(operationID string, body []byte) error {
+	tr := us.Tracer.Tracer("User-FinishShortOperation Service")
+	_, span := tr.Start(us.Context, "Start FinishShortOperation")
+	defer span.End()
+
+	objOperationID, err := primitive.ObjectIDFromHex(operationID)
+	if err != nil {
+		return model.NewError(model.Validation, "invalid operation id")
+	}
+
+	operation, err := us.UserRepo.FindPendingShortOperationByID(us.Context, objOperationID)
+	if err != nil {
+		if model.IsKind(err, model.NotFound) {
+			return nil
+		}
+
+		return err
+	}
+
+	reply, err := us.UserRepo.DecodeShortOperationReply(us.Context, operation.Type, body)
+	if err != nil {
+		// redelivering undecodable reply never succeed, so operation failed right away
+		reply = &model.ShortOperationReply{Error: "invalid shortener reply"}
+	}
+
+	shortURL := reply.ShortURL
+	if shortURL != "" {
+		shortURL = fmt.Sprintf("%s/%s", us.Config.HttpService.ShortenerBaseAPIURL, shortURL)
+	}
+
+	return us.UserRepo.UpdateShortOperationResultByID(us.Context, operation.ID, shortURL, reply.Error)
+}
+
+func (us *UserServiceImpl) GetUserShortStats(userID string, shortID string, req *model.ShortStatsRequest) (*model.ShortStats, error) {
+	tr := us.Tracer.Tracer("User-GetUserShortStats Service")
+	_, span := tr.Start(us.Context, "Start GetUserShortStats")
+	defer span.End()
+
+	from, err := parseStatsDate(req.From, "from")
+	if err != nil {
+		return nil, err
+	}
+
+	to, err := parseStatsDate(req.To, "to")
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := us.ShortClients.GetClickStatsByID(us.Context, &shortenerpb.ClickStatsRequest{
+		Id:     shortID,
+		UserId: userID,
+		From:   helper.TimeToUnix(from),
+		To:     helper.TimeToUnix(to),
+	})
+	if err != nil {
+		us.Logger.Error("UserServiceImpl.GetUserShortStats ShortClients ERROR, ", err)
+
+		return nil, helper.FromStatusError(err)
+	}
+
+	return &model.ShortStats{
+		ShortURL:   data.GetShortUrl(),
+		Total:      data.GetTotal(),
+		ByDay:      prepareStatItems(data.GetByDay()),
+		ByReferrer: prepareStatItems(data.GetByReferrer()),
+		ByCountry:  prepareStatItems(data.GetByCountry()),
+		ByDevice:   prepareStatItems(data.GetByDevice()),
+	}, nil
+}
+
+func (us *UserServiceImpl) BulkGenerateUserShorts(ctx *fiber.Ctx, userID string) (*model.BulkJob, error) {
+	tr := us.Tracer.Tracer("User-BulkGenerateUserShorts Service")
+	_, span := tr.Start(us.Context, "Start BulkGenerateUserShorts")
+	defer span.End()
+
+	rows, err := us.parseBulkRows(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(rows) < 1 {
+		return nil, model.NewError(model.Validation, "at least one row required")
+	}
+
+	if len(rows) > us.Config.Bulk.MaxRows {
+		return nil, model.NewError(model.Validation, fmt.Sprintf("maximum %d rows per bulk", us.Config.Bulk.MaxRows))
+	}
+
+	now := time.Now()
+	job := &model.BulkJob{
+		ID:        primitive.NewObjectID(),
+		UserID:    userID,
+		Status:    model.BulkJobPending,
+		Total:     len(rows),
+		Results:   []model.BulkShortResult{},
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	err = us.UserRepo.CreateBulkJob(us.Context, job)
+	if err != nil {
+		return nil, err
+	}
+
+	// large imports processed in background, progress can be polled by job id
+	if len(rows) > us.Config.Bulk.SyncLimit {
+		go us.processBulkJob(job.ID, userID, rows)
+
+		return job, nil
+	}
+
+	us.processBulkJob(job.ID, userID, rows)
+
+	return us.UserRepo.FindBulkJobByID(us.Context, userID, job.ID.Hex())
+}
+
+func (us *UserServiceImpl) GetUserBulkJob(userID string, jobID string) (*model.BulkJob, error) {
+	tr := us.Tracer.Tracer("User-GetUserBulkJob Service")
+	_, span := tr.Start(us.Context, "Start GetUserBulkJob")
+	defer span.End()
+
+	return us.UserRepo.FindBulkJobByID(us.Context, userID, jobID)
+}
+
+// FailStaleBulkJobs will failing unfinished bulk jobs left by stopped instance, their rows can't be resumed
+func (us *UserServiceImpl) FailStaleBulkJobs() (int64, error) {
+	tr := us.Tracer.Tracer("User-FailStaleBulkJobs Service")
+	_, span := tr.Start(us.Context, "Start FailStaleBulkJobs")
+	defer span.End()
+
+	staleTimeout := us.Config.Bulk.StaleTimeout
+	if staleTimeout <= 0 {
+		staleTimeout = defaultBulkStaleTimeout
+	}
+
+	return us.UserRepo.FailStaleBulkJobs(us.Context, time.Now().Add(-time.Duration(staleTimeout)*time.Minute), bulkJobInterrupted)
+}
+
+// processBulkJob will publishing rows batch by batch & recording result of every row into job
+func (us *UserServiceImpl) processBulkJob(jobID primitive.ObjectID, userID string, rows []bulkRow) {
+	// job left processing forever when panic not recovered
+	defer func() {
+		if r := recover(); r != nil {
+			us.Logger.Error("UserServiceImpl.processBulkJob PANIC, ", r)
+
+			if err := us.UserRepo.FailBulkJobByID(us.Context, jobID, bulkJobInterrupted); err != nil {
+				us.Logger.Error("UserServiceImpl.processBulkJob FailBulkJobByID ERROR, ", err)
+			}
+		}
+	}()
+
+	batchSize := us.Config.Bulk.BatchSize
+	if batchSize <= 0 {
+		batchSize = defaultBulkBatchSize
+	}
+
+	if err := us.UserRepo.UpdateBulkJobStatusByID(us.Context, jobID, model.BulkJobProcessing); err != nil {
+		us.Logger.Error("UserServiceImpl.processBulkJob UpdateBulkJobStatusByID ERROR, ", err)
+	}
+
+	for start := 0; start < len(rows); start += batchSize {
+		end := start + batchSize
+		if end > len(rows) {
+			end = len(rows)
+		}
+
+		results := make([]model.BulkShortResult, end-start)
+
+		var wg sync.WaitGroup
+
+		for i := start; i < end; i++ {
+			result := &results[i-start]
+			result.Row = i + 1
+			result.FullURL = rows[i].req.FullURL
+
+			if rows[i].err != nil {
+				result.Error = rows[i].err.Error()
+				continue
+			}
+
+			msg, err := us.prepareGenerateShortUserMessage(userID, &rows[i].req)
+			if err != nil {
+				result.Error = err.Error()
+				continue
+			}
+
+			wg.Add(1)
+
+			go func() {
+				defer wg.Done()
+
+				shortURL, err := us.UserRepo.PublishCreateUserShortener(us.Context, prepareProtoCreateShortenerMessage(msg))
+				if err != nil {
+					result.Error = err.Error()
+					return
+				}
+
+				result.ShortURL = fmt.Sprintf("%s/%s", us.Config.HttpService.ShortenerBaseAPIURL, shortURL)
+			}()
+		}
+
+		wg.Wait()
+
+		var succeeded, failed int
+		for _, result := range results {
+			if result.Error != "" {
+				failed++
+			} else {
+				succeeded++
+			}
+		}
+
+		if err := us.UserRepo.UpdateBulkJobProgressByID(us.Context, jobID, results, succeeded, failed); err != nil {
+			us.Logger.Error("UserServiceImpl.processBulkJob UpdateBulkJobProgressByID ERROR, ", err)
+		}
+	}
+
+	if err := us.UserRepo.UpdateBulkJobStatusByID(us.Context, jobID, model.BulkJobCompleted); err != nil {
+		us.Logger.Error("UserServiceImpl.processBulkJob UpdateBulkJobStatusByID ERROR, ", err)
+	}
+}
+
+// parseBulkRows will reading rows from CSV upload / CSV body / JSON array body
+func (us *UserServiceImpl) parseBulkRows(ctx *fiber.Ctx) ([]bulkRow, error) {
+	contentType := strings.ToLower(ctx.Get(fiber.HeaderContentType))
+
+	switch {
+	case strings.HasPrefix(contentType, fiber.MIMEMultipartForm):
+		file, err := ctx.FormFile("file")
+		if err != nil {
+			return nil, model.NewError(model.Validation, "file required")
+		}
+
+		buffer, err := file.Open()
+		if err != nil {
+			return nil, err
+		}
+		defer buffer.Close()
+
+		return parseBulkCSV(buffer)
+	case strings.HasPrefix(contentType, "text/csv"):
+		return parseBulkCSV(bytes.NewReader(ctx.Body()))
+	default:
+		var reqs []model.ShortUserRequest
+
+		if err := ctx.BodyParser(&reqs); err != nil {
+			return nil, model.NewError(model.Validation, err.Error())
+		}
+
+		rows := make([]bulkRow, len(reqs))
+		for i := range reqs {
+			rows[i].req = reqs[i]
+		}
+
+		return rows, nil
+	}
+}
+
+// parseBulkCSV will reading CSV with header full_url, alias, tags (separated by ;) & expires_at (RFC3339 / YYYY-MM-DD)
+func parseBulkCSV(r io.Reader) ([]bulkRow, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, model.NewError(model.Validation, "invalid csv, header required")
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, h := range header {
+		columns[strings.ToLower(strings.TrimSpace(strings.TrimPrefix(h, "\ufeff")))] = i
+	}
+
+	if _, ok := columns["full_url"]; !ok {
+		return nil, model.NewError(model.Validation, "invalid csv, full_url column required")
+	}
+
+	if i, ok := columns["expiry"]; ok {
+		columns["expires_at"] = i
+	}
+
+	var rows []bulkRow
+
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil {
+			return nil, model.NewError(model.Validation, fmt.Sprintf("invalid csv, %s", err.Error()))
+		}
+
+		value := func(column string) string {
+			i, ok := columns[column]
+			if !ok || i >= len(record) {
+				return ""
+			}
+
+			return strings.TrimSpace(record[i])
+		}
+
+		row := bulkRow{req: model.ShortUserRequest{
+			FullURL: value("full_url"),
+			Alias:   value("alias"),
+		}}
+
+		if tags := value("tags"); tags != "" {
+			row.req.Tags = strings.Split(tags, ";")
+		}
+
+		if maxClicks := value("max_clicks"); maxClicks != "" {
+			row.req.MaxClicks, err = strconv.ParseInt(maxClicks, 10, 64)
+			if err != nil {
+				row.err = model.NewError(model.Validation, "max_clicks must be a number")
+			}
+		}
+
+		if expiresAt := value("expires_at"); expiresAt != "" && row.err == nil {
+			row.req.ExpiresAt, row.err = parseBulkExpiresAt(expiresAt)
+		}
+
+		rows = append(rows, row)
+	}
+
+	return rows, nil
+}
+
+func parseBulkExpiresAt(expiresAt string) (*time.Time, error) {
+	for _, layout := range []string{time.RFC3339, "2006-01-02"} {
+		if t, err := time.Parse(layout, expiresAt); err == nil {
+			return &t, nil
+		}
+	}
+
+	return nil, model.NewError(model.Validation, "expires_at must be formatted as RFC3339 or YYYY-MM-DD")
+}
+
+func prepareProtoCreateShortenerMessage(req *model.GenerateShortUserMessage) *shortenerpb.CreateShortenerMessage {
+	return &shortenerpb.CreateShortenerMessage{
+		FullUrl:      req.FullURL,
+		UserId:       req.UserID,
+		ShortUrl:     req.ShortURL,
+		IsCustom:     req.IsCustom,
+		ExpiresAt:    helper.TimeToUnix(req.ExpiresAt),
+		MaxClicks:    req.MaxClicks,
+		PasswordHash: req.PasswordHash,
+		Tags:         req.Tags,
+	}
+}
+
+// prepareProtoUpdateShortenerMessage will building update message of given fields only, cleared fields sent as zero value & password hashed
+func prepareProtoUpdateShortenerMessage(userID string, shortID string, req *model.UpdateShortUserRequest) (*shortenerpb.UpdateShortenerMessage, error) {
+	err := validateUpdateShortUser(req)
+	if err != nil {
+		return nil, err
+	}
+
+	msg := &shortenerpb.UpdateShortenerMessage{
+		Id:        shortID,
+		UserId:    userID,
+		FullUrl:   req.FullURL,
+		MaxClicks: req.MaxClicks,
+	}
+
+	if req.ExpiresAt != nil {
+		msg.ExpiresAt = proto.Int64(helper.TimeToUnix(req.ExpiresAt))
+	}
+
+	if req.Password != nil {
+		passwordHash, err := hashShortPassword(*req.Password)
+		if err != nil {
+			return nil, err
+		}
+
+		msg.PasswordHash = proto.String(passwordHash)
+	}
+
+	for _, field := range req.Clear {
+		switch field {
+		case model.ShortFieldExpiresAt:
+			msg.ExpiresAt = proto.Int64(0)
+		case model.ShortFieldMaxClicks:
+			msg.MaxClicks = proto.Int64(0)
+		case model.ShortFieldPassword:
+			msg.PasswordHash = proto.String("")
+		}
+	}
+
+	return msg, nil
+}
+
+// validateUpdateShortUser will validating fields given on update short users, at least one of them updated or cleared
+func validateUpdateShortUser(req *model.UpdateShortUserRequest) error {
+	given := map[string]bool{
+		model.ShortFieldExpiresAt: req.ExpiresAt != nil,
+		model.ShortFieldMaxClicks: req.MaxClicks != nil,
+		model.ShortFieldPassword:  req.Password != nil,
+	}
+
+	for _, field := range req.Clear {
+		set, ok := given[field]
+		if !ok {
+			return model.NewError(model.Validation, fmt.Sprintf("%s cannot be cleared", field))
+		}
+
+		if set {
+			return model.NewError(model.Validation, fmt.Sprintf("%s cannot be updated & cleared at once", field))
+		}
+	}
+
+	if req.FullURL == nil && req.ExpiresAt == nil && req.MaxClicks == nil && req.Password == nil && len(req.Clear) == 0 {
+		return model.NewError(model.Validation, "nothing to update")
+	}
+
+	return nil
+}
+
+func (us *UserServiceImpl) prepareUserShorts(q *shortenerpb.Shortener) model.UserShorts {
+	return model.UserShorts{
+		ID:          q.GetId(),
+		FullURL:     q.GetFullUrl(),
+		ShortURL:    q.GetShortUrl(),
+		Visited:     q.GetVisited(),
+		ExpiresAt:   helper.UnixToTime(q.GetExpiresAt()),
+		MaxClicks:   q.GetMaxClicks(),
+		IsProtected: q.GetIsProtected(),
+		Tags:        q.GetTags(),
+		QRURL:       fmt.Sprintf("%s/%s/qr", us.Config.HttpService.ShortenerBaseAPIURL, q.GetShortUrl()),
+		CreatedAt:   helper.UnixToTime(q.GetCreatedAt()),
+	}
+}
+
+func exportCSVRecord(short *model.UserShorts) []string {
+	formatTime := func(t *time.Time) string {
+		if t == nil {
+			return ""
+		}
+
+		return t.UTC().Format(time.RFC3339)
+	}
+
+	return []string{
+		short.ID,
+		short.FullURL,
+		short.ShortURL,
+		strconv.FormatInt(short.Visited, 10),
+		formatTime(short.ExpiresAt),
+		strconv.FormatInt(short.MaxClicks, 10),
+		strconv.FormatBool(short.IsProtected),
+		strings.Join(short.Tags, ";"),
+		short.QRURL,
+		formatTime(short.CreatedAt),
+	}
+}
+
+// parseStatsDate will parsing optional YYYY-MM-DD date of stats range
+func parseStatsDate(date string, field string) (*time.Time, error) {
+	if date == "" {
+		return nil, nil
+	}
+
+	t, err := time.Parse("2006-01-02", date)
+	if err != nil {
+		return nil, model.NewError(model.Validation, fmt.Sprintf("%s must be formatted as YYYY-MM-DD", field))
+	}
+
+	return &t, nil
+}
+
+func prepareStatItems(stats []*shortenerpb.ClickStat) []model.StatItem {
+	items := make([]model.StatItem, len(stats))
+
+	for i, q := range stats {
+		items[i] = model.StatItem{
+			Key:   q.GetKey(),
+			Count: q.GetCount(),
+		}
+	}
+
+	return items
+}
+
+// prepareGenerateShortUserMessage will validating short users request & transform it into message
+func (us *UserServiceImpl) prepareGenerateShortUserMessage(userID string, req *model.ShortUserRequest) (*model.GenerateShortUserMessage, error) {
+	if _, err := url.ParseRequestURI(req.FullURL); err != nil {
+		return nil, model.NewError(model.Validation, "full_url must be a valid url")
+	}
+
+	if req.ExpiresAt != nil && !req.ExpiresAt.After(time.Now()) {
+		return nil, model.NewError(model.Validation, "expires_at must be in the future")
+	}
+
+	if req.MaxClicks < 0 {
+		return nil, model.NewError(model.Validation, "max_clicks cannot be negative")
+	}
+
+	tags, err := normalizeTags(req.Tags)
+	if err != nil {
+		return nil, err
+	}
+
+	passwordHash, err := hashShortPassword(req.Password)
+	if err != nil {
+		return nil, err
+	}
+
+	msg := &model.GenerateShortUserMessage{
+		FullURL:      req.FullURL,
+		UserID:       userID,
+		ExpiresAt:    req.ExpiresAt,
+		MaxClicks:    req.MaxClicks,
+		PasswordHash: passwordHash,
+		Tags:         tags,
+	}
+
+	if req.Alias != "" {
+		err := us.validateAlias(req.Alias)
+		if err != nil {
+			return nil, err
+		}
+
+		msg.ShortURL = req.Alias
+		msg.IsCustom = true
+	}
+
+	return msg, nil
+}
+
+// hashShortPassword will validating & hashing password of short users, empty when short not protected
+func hashShortPassword(password string) (string, error) {
+	if password == "" {
+		return "", nil
+	}
+
+	if len(password) < minPasswordLength || len(password) > maxPasswordLength {
+		return "", model.NewError(model.Validation, fmt.Sprintf("password length must between %d and %d", minPasswordLength, maxPasswordLength))
+	}
+
+	return helper.HashPassword(password)
+}
+
+// normalizeTags will trimming, lowercasing & deduplicating tags
+func normalizeTags(tags []string) ([]string, error) {
+	seen := make(map[string]bool, len(tags))
+	normalized := []string{}
+
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag == "" || seen[tag] {
+			continue
+		}
+
+		if len(tag) > maxTagLength {
+			return nil, model.NewError(model.Validation, fmt.Sprintf("tag length must not exceed %d", maxTagLength))
+		}
+
+		seen[tag] = true
+		normalized = append(normalized, tag)
+	}
+
+	if len(normalized) > maxTags {
+		return nil, model.NewError(model.Validation, fmt.Sprintf("maximum %d tags per short", maxTags))
+	}
+
+	sort.Strings(normalized)
+
+	return normalized, nil
+}
+
+// validateAlias will checking custom alias against configured charset, length range & reserved words
+func (us *UserServiceImpl) validateAlias(alias string) error {
+	if len(alias) < us.Config.Alias.MinLength || len(alias) > us.Config.Alias.MaxLength {
+		return model.NewError(model.Validation, fmt.Sprintf("alias length must between %d and %d", us.Config.Alias.MinLength, us.Config.Alias.MaxLength))
+	}
+
+	for _, c := range alias {
+		if !strings.ContainsRune(us.Config.Alias.Charset, c) {
+			return model.NewError(model.Validation, fmt.Sprintf("alias contains invalid character %q", c))
+		}
+	}
+
+	for _, w := range us.Config.Alias.ReservedWords {
+		if strings.EqualFold(alias, w) {
+			return model.NewError(model.Validation, fmt.Sprintf("alias %s is reserved", alias))
+		}
+	}
+
+	return nil
+}
+
+// validateCreateAPIKey will validating request creating API key, returning its scopes deduplicated
+func validateCreateAPIKey(req *model.CreateAPIKeyRequest) ([]string, error) {
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, model.NewError(model.Validation, "name required")
+	}
+
+	if len(name) > maxAPIKeyNameLength {
+		return nil, model.NewError(model.Validation, fmt.Sprintf("name at most %d characters", maxAPIKeyNameLength))
+	}
+
+	if len(req.Scopes) == 0 {
+		return nil, model.NewError(model.Validation, "scopes required")
+	}
+
+	scopes := make([]string, 0, len(req.Scopes))
+	for _, scope := range req.Scopes {
+		if !model.APIKeyScopes[scope] {
+			return nil, model.NewError(model.Validation, "invalid scope "+scope)
+		}
+
+		if !hasScope(scopes, scope) {
+			scopes = append(scopes, scope)
+		}
+	}
+
+	if req.ExpiresAt != nil && !req.ExpiresAt.After(time.Now()) {
+		return nil, model.NewError(model.Validation, "expires_at must be in the future")
+	}
+
+	return scopes, nil
+}
+
+func hasScope(scopes []string, scope string) bool {
+	for _, s := range scopes {
+		if s == scope {
+			return true
+		}
+	}
+
+	return false
+}